@@ -5,6 +5,7 @@ import (
 	. "github.com/orz-dsh/dsh/core/inspection"
 	. "github.com/orz-dsh/dsh/core/internal"
 	. "github.com/orz-dsh/dsh/utils"
+	"slices"
 )
 
 // region Application
@@ -30,6 +31,94 @@ func (a *Application) GetConfig() (map[string]any, error) {
 	return a.core.Config.Value, nil
 }
 
+// GetMainProject returns the main project, the projects are loaded at the first call of the project accessors.
+func (a *Application) GetMainProject() (*ApplicationProject, error) {
+	if err := a.core.LoadProjects(); err != nil {
+		return nil, err
+	}
+	return newApplicationProject(a.core, a.core.MainProject), nil
+}
+
+func (a *Application) GetAdditionProjects() ([]*ApplicationProject, error) {
+	if err := a.core.LoadProjects(); err != nil {
+		return nil, err
+	}
+	return a.newProjects(a.core.AdditionProjects), nil
+}
+
+func (a *Application) GetDependencyProjects() ([]*ApplicationProject, error) {
+	if err := a.core.LoadProjects(); err != nil {
+		return nil, err
+	}
+	return a.newProjects(a.core.DependencyProjects), nil
+}
+
+// GetProjects returns all projects in load order, the main project first.
+func (a *Application) GetProjects() ([]*ApplicationProject, error) {
+	if err := a.core.LoadProjects(); err != nil {
+		return nil, err
+	}
+	return a.newProjects(a.core.Projects), nil
+}
+
+// GetProject returns the project by name, or nil if the project is not loaded by the application.
+func (a *Application) GetProject(name string) (*ApplicationProject, error) {
+	if err := a.core.LoadProjects(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(a.core.Projects); i++ {
+		if a.core.Projects[i].Name == name {
+			return newApplicationProject(a.core, a.core.Projects[i]), nil
+		}
+	}
+	return nil, nil
+}
+
+// GetOptions returns the option results of all projects ordered by project name and option name.
+func (a *Application) GetOptions() ([]*ApplicationOptionValue, error) {
+	projects, err := a.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+	var options []*ApplicationOptionValue
+	for i := 0; i < len(projects); i++ {
+		options = append(options, projects[i].GetOptions()...)
+	}
+	slices.SortFunc(options, compareApplicationOptionValue)
+	return options, nil
+}
+
+// GetDependencyGraph returns the names of the dependency projects by project name, every loaded project is a key.
+func (a *Application) GetDependencyGraph() (map[string][]string, error) {
+	projects, err := a.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+	graph := map[string][]string{}
+	for i := 0; i < len(projects); i++ {
+		names := []string{}
+		dependencies := projects[i].GetDependencies()
+		for j := 0; j < len(dependencies); j++ {
+			names = append(names, dependencies[j].ProjectName)
+		}
+		graph[projects[i].GetName()] = names
+	}
+	return graph, nil
+}
+
+// GetTargets returns the target files of all projects in load order.
+func (a *Application) GetTargets() ([]*ApplicationTarget, error) {
+	projects, err := a.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+	var targets []*ApplicationTarget
+	for i := 0; i < len(projects); i++ {
+		targets = append(targets, projects[i].GetTargets()...)
+	}
+	return targets, nil
+}
+
 func (a *Application) MakeArtifact(options MakeArtifactOptions) (*Artifact, error) {
 	artifact, err := a.core.MakeArtifact(options)
 	if err != nil {
@@ -38,8 +127,29 @@ func (a *Application) MakeArtifact(options MakeArtifactOptions) (*Artifact, erro
 	return newArtifact(artifact), nil
 }
 
+func (a *Application) Help() (*ApplicationHelp, error) {
+	return a.core.Help()
+}
+
 func (a *Application) Inspect() (*ApplicationInspection, error) {
 	return a.core.Inspect()
 }
 
+// DiffInspection compares the old inspection, such as the inspection of yesterday's build, with the inspection of the application.
+func (a *Application) DiffInspection(old *ApplicationInspection) (*ApplicationInspectionDiff, error) {
+	inspection, err := a.core.Inspect()
+	if err != nil {
+		return nil, err
+	}
+	return DiffApplicationInspection(old, inspection), nil
+}
+
+func (a *Application) newProjects(cores []*Project) []*ApplicationProject {
+	var projects []*ApplicationProject
+	for i := 0; i < len(cores); i++ {
+		projects = append(projects, newApplicationProject(a.core, cores[i]))
+	}
+	return projects
+}
+
 // endregion
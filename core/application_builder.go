@@ -2,6 +2,7 @@ package core
 
 import (
 	. "github.com/orz-dsh/dsh/core/builder"
+	. "github.com/orz-dsh/dsh/core/common"
 	. "github.com/orz-dsh/dsh/core/internal"
 	. "github.com/orz-dsh/dsh/core/internal/setting"
 	. "github.com/orz-dsh/dsh/utils"
@@ -12,6 +13,9 @@ import (
 type ApplicationBuilder struct {
 	workspace       *WorkspaceCore
 	profileSettings []*ProfileSetting
+	prompt          *ApplicationPromptOptions
+	configItems     []*ConfigOverrideItemSettingModel
+	fileSystem      FileSystem
 	err             error
 }
 
@@ -22,12 +26,27 @@ func newAppBuilder(workspace *WorkspaceCore) *ApplicationBuilder {
 	}
 }
 
+// AddProfile adds a profile setting built by the returned builder, the source defaults to `builder`,
+// and the profile is inserted before all profiles by default, which has the highest priority.
+func (b *ApplicationBuilder) AddProfile(options ...ProfileOption) *ProfileSettingModelBuilder[*ApplicationBuilder] {
+	o := newProfileOptions(options)
+	return b.AddProfileSetting(o.source, o.position)
+}
+
+// AddProfileFile adds a profile setting loaded from the file, the source option is ignored because the file is the source.
+func (b *ApplicationBuilder) AddProfileFile(file string, options ...ProfileOption) *ApplicationBuilder {
+	o := newProfileOptions(options)
+	return b.AddProfileSettingFile(o.position, file)
+}
+
+// Deprecated: use AddProfile with WithProfileSource and WithProfilePosition instead.
 func (b *ApplicationBuilder) AddProfileSetting(source string, position int) *ProfileSettingModelBuilder[*ApplicationBuilder] {
 	return NewProfileSettingModelBuilder(func(model *ProfileSettingModel) *ApplicationBuilder {
 		return b.addProfileSettingModel(source, position, model)
 	})
 }
 
+// Deprecated: use AddProfileFile with WithProfilePosition instead.
 func (b *ApplicationBuilder) AddProfileSettingFile(position int, file string) *ApplicationBuilder {
 	path, err := filepath.Abs(file)
 	// TODO: error
@@ -41,6 +60,43 @@ func (b *ApplicationBuilder) AddProfileSettingFile(position int, file string) *A
 	return b.addProfileSetting(position, setting, nil)
 }
 
+// AddConfigOverride adds a config override like `config.db.port=5433`, the value is typed by yaml parsing.
+// The overrides are merged after all config files and the environment overrides.
+func (b *ApplicationBuilder) AddConfigOverride(expr string) *ApplicationBuilder {
+	if b.err != nil {
+		return b
+	}
+	item, err := ParseConfigOverrideItemSettingModel(expr, "builder")
+	if err != nil {
+		b.err = err
+		return b
+	}
+	b.configItems = append(b.configItems, item)
+	return b
+}
+
+func (b *ApplicationBuilder) AddConfigOverrideItem(path, value string) *ApplicationBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.configItems = append(b.configItems, NewConfigOverrideItemSettingModel(path, value, "builder"))
+	return b
+}
+
+// SetFileSystem sets the file system to load the projects and make the artifacts, such as a MountFileSystem
+// with an embed.FS mounted as the projects dir and a MemoryFileSystem mounted as the output dir.
+// The git projects are downloaded to the workspace, so they are read from the base file system of the mounts,
+// and the artifacts can only be executed if the output dir is in the os file system.
+func (b *ApplicationBuilder) SetFileSystem(fileSystem FileSystem) *ApplicationBuilder {
+	b.fileSystem = fileSystem
+	return b
+}
+
+func (b *ApplicationBuilder) SetPrompt(options ApplicationPromptOptions) *ApplicationBuilder {
+	b.prompt = &options
+	return b
+}
+
 func (b *ApplicationBuilder) Error() error {
 	return b.err
 }
@@ -52,8 +108,24 @@ func (b *ApplicationBuilder) Build(link string) (*Application, error) {
 		return nil, b.err
 	}
 
-	setting := NewApplicationSetting(b.workspace, b.profileSettings)
-	core, err := NewApplicationCore(b.workspace, setting, link)
+	config, err := NewConfigOverrideSettingModel(b.configItems).Convert(NewModelHelper(b.workspace.Logger, "config override setting", "builder"))
+	if err != nil {
+		return nil, err
+	}
+	fileSystem := b.fileSystem
+	if fileSystem == nil {
+		fileSystem = OSFileSystem
+	}
+	setting := NewApplicationSetting(b.workspace, b.profileSettings, config, fileSystem)
+	var prompter *ApplicationOptionPrompter
+	if b.prompt != nil {
+		terminal := NewTerminal(b.prompt.Input, b.prompt.Output)
+		if prompter, err = NewApplicationOptionPrompter(b.workspace.Logger.Named(LoggerNameOption), terminal, b.prompt.SaveFile); err != nil {
+			return nil, err
+		}
+	}
+
+	core, err := NewApplicationCore(b.workspace, setting, link, prompter)
 	if err != nil {
 		return nil, err
 	}
@@ -83,3 +155,41 @@ func (b *ApplicationBuilder) addProfileSetting(position int, setting *ProfileSet
 	}
 	return b
 }
+
+// region ProfileOption
+
+// ProfileOption is a functional option of AddProfile and AddProfileFile.
+type ProfileOption func(options *profileOptions)
+
+type profileOptions struct {
+	source   string
+	position int
+}
+
+func newProfileOptions(options []ProfileOption) *profileOptions {
+	o := &profileOptions{
+		source:   "builder",
+		position: 0,
+	}
+	for i := 0; i < len(options); i++ {
+		options[i](o)
+	}
+	return o
+}
+
+// WithProfileSource sets the source of the profile setting, which is shown in the errors and the inspections.
+func WithProfileSource(source string) ProfileOption {
+	return func(options *profileOptions) {
+		options.source = source
+	}
+}
+
+// WithProfilePosition sets the position of the profile setting in the profiles of the workspace,
+// the profile at the lower position has the higher priority, and a negative position appends the profile to the end.
+func WithProfilePosition(position int) ProfileOption {
+	return func(options *profileOptions) {
+		options.position = position
+	}
+}
+
+// endregion
@@ -0,0 +1,211 @@
+package core
+
+import (
+	. "github.com/orz-dsh/dsh/core/internal"
+	. "github.com/orz-dsh/dsh/utils"
+	"slices"
+)
+
+// region ApplicationProject
+
+// ApplicationProject is a loaded project of the application, the main project, the addition projects and the dependency projects.
+type ApplicationProject struct {
+	core *Project
+	app  *ApplicationCore
+}
+
+func newApplicationProject(app *ApplicationCore, core *Project) *ApplicationProject {
+	return &ApplicationProject{
+		core: core,
+		app:  app,
+	}
+}
+
+func (p *ApplicationProject) DescExtraKeyValues() KVS {
+	return KVS{
+		KV("core", p.core),
+	}
+}
+
+func (p *ApplicationProject) GetName() string {
+	return p.core.Name
+}
+
+func (p *ApplicationProject) GetDir() string {
+	return p.core.Dir
+}
+
+// GetOptions returns the option results of the project ordered by name, the values of the secret options are not masked.
+func (p *ApplicationProject) GetOptions() []*ApplicationOptionValue {
+	var options []*ApplicationOptionValue
+	for name, item := range p.app.Option.Result.Items[p.core.Name] {
+		options = append(options, newApplicationOptionValue(p.core.Name, name, item))
+	}
+	slices.SortFunc(options, compareApplicationOptionValue)
+	return options
+}
+
+// GetDependencies returns the matched dependencies of the project in declaration order.
+func (p *ApplicationProject) GetDependencies() []*ApplicationProjectDependency {
+	var dependencies []*ApplicationProjectDependency
+	items := p.core.GetDependency().Items
+	for i := 0; i < len(items); i++ {
+		dependencies = append(dependencies, newApplicationProjectDependency(items[i]))
+	}
+	return dependencies
+}
+
+// GetResources returns the scanned resource files of the project, the config files first, then the template files,
+// the template lib files and the plain files.
+func (p *ApplicationProject) GetResources() []*ApplicationProjectResource {
+	var resources []*ApplicationProjectResource
+	resource := p.core.GetResource()
+	for i := 0; i < len(resource.ConfigItems); i++ {
+		resources = append(resources, newApplicationProjectResource(ApplicationProjectResourceKindConfig, resource.ConfigItems[i].File, ""))
+	}
+	for i := 0; i < len(resource.TemplateItems); i++ {
+		resources = append(resources, newApplicationProjectResource(ApplicationProjectResourceKindTemplate, resource.TemplateItems[i].File, resource.TemplateItems[i].Target))
+	}
+	for i := 0; i < len(resource.TemplateLibItems); i++ {
+		resources = append(resources, newApplicationProjectResource(ApplicationProjectResourceKindTemplateLib, resource.TemplateLibItems[i].File, ""))
+	}
+	for i := 0; i < len(resource.PlainItems); i++ {
+		resources = append(resources, newApplicationProjectResource(ApplicationProjectResourceKindPlain, resource.PlainItems[i].File, resource.PlainItems[i].Target))
+	}
+	return resources
+}
+
+// GetTargets returns the target files made from the template files and the plain files of the project.
+func (p *ApplicationProject) GetTargets() []*ApplicationTarget {
+	var targets []*ApplicationTarget
+	resources := p.GetResources()
+	for i := 0; i < len(resources); i++ {
+		if resources[i].Target != "" {
+			targets = append(targets, newApplicationTarget(resources[i].Target, p.core.Name, resources[i].File, resources[i].Kind))
+		}
+	}
+	return targets
+}
+
+// endregion
+
+// region ApplicationOptionValue
+
+// ApplicationOptionValue is the result of an option, the source is one of `unset`, `export`, `assign`, `compute`, `default` and `prompt`.
+type ApplicationOptionValue struct {
+	ProjectName string
+	Name        string
+	Value       any
+	Source      string
+	Secret      bool
+}
+
+func newApplicationOptionValue(projectName, name string, item *ApplicationOptionResultItem) *ApplicationOptionValue {
+	return &ApplicationOptionValue{
+		ProjectName: projectName,
+		Name:        name,
+		Value:       item.Value,
+		Source:      string(item.Source),
+		Secret:      item.Secret,
+	}
+}
+
+func compareApplicationOptionValue(l, r *ApplicationOptionValue) int {
+	if l.ProjectName != r.ProjectName {
+		if l.ProjectName < r.ProjectName {
+			return -1
+		}
+		return 1
+	}
+	if l.Name < r.Name {
+		return -1
+	} else if l.Name > r.Name {
+		return 1
+	}
+	return 0
+}
+
+// endregion
+
+// region ApplicationProjectDependency
+
+// ApplicationProjectDependency is a resolved dependency, the link is normalized, the git url and ref are empty if the link is not a git link,
+// and the oci ref and digest are empty if the link is not an oci link.
+type ApplicationProjectDependency struct {
+	Link        string
+	Dir         string
+	GitUrl      string
+	GitRef      string
+	OciRef      string
+	OciDigest   string
+	ProjectName string
+}
+
+func newApplicationProjectDependency(item *ProjectDependencyItem) *ApplicationProjectDependency {
+	dependency := &ApplicationProjectDependency{
+		Link: item.Target.Link.Normalized,
+		Dir:  item.Target.Dir,
+	}
+	if item.Target.Git != nil {
+		dependency.GitUrl = item.Target.Git.Url
+		dependency.GitRef = item.Target.Git.Ref
+	}
+	if item.Target.Oci != nil {
+		dependency.OciRef = item.Target.Oci.Ref
+		dependency.OciDigest = item.Target.Oci.ParsedRef.Digest
+	}
+	if project := item.GetProject(); project != nil {
+		dependency.ProjectName = project.Name
+	}
+	return dependency
+}
+
+// endregion
+
+// region ApplicationProjectResource
+
+type ApplicationProjectResourceKind string
+
+const (
+	ApplicationProjectResourceKindConfig      ApplicationProjectResourceKind = "config"
+	ApplicationProjectResourceKindTemplate    ApplicationProjectResourceKind = "template"
+	ApplicationProjectResourceKindTemplateLib ApplicationProjectResourceKind = "templateLib"
+	ApplicationProjectResourceKindPlain       ApplicationProjectResourceKind = "plain"
+)
+
+// ApplicationProjectResource is a scanned resource file, the target is empty for the config files and the template lib files.
+type ApplicationProjectResource struct {
+	Kind   ApplicationProjectResourceKind
+	File   string
+	Target string
+}
+
+func newApplicationProjectResource(kind ApplicationProjectResourceKind, file, target string) *ApplicationProjectResource {
+	return &ApplicationProjectResource{
+		Kind:   kind,
+		File:   file,
+		Target: target,
+	}
+}
+
+// endregion
+
+// region ApplicationTarget
+
+type ApplicationTarget struct {
+	Name        string
+	ProjectName string
+	File        string
+	Kind        ApplicationProjectResourceKind
+}
+
+func newApplicationTarget(name, projectName, file string, kind ApplicationProjectResourceKind) *ApplicationTarget {
+	return &ApplicationTarget{
+		Name:        name,
+		ProjectName: projectName,
+		File:        file,
+		Kind:        kind,
+	}
+}
+
+// endregion
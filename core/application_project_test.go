@@ -0,0 +1,186 @@
+package core_test
+
+import (
+	. "github.com/orz-dsh/dsh/core"
+	. "github.com/orz-dsh/dsh/utils"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeTestProjectFile(t *testing.T, dir, name, content string) {
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestProjectApp(t *testing.T, setup func(builder *ApplicationBuilder) *ApplicationBuilder) (*Application, string, string) {
+	appDir := t.TempDir()
+	libDir := t.TempDir()
+	writeTestProjectFile(t, appDir, "project.yml", "name: app\n"+
+		"option:\n  items:\n    - name: greeting\n      default: hello\n    - name: level\n      type: integer\n      default: 1\n"+
+		"dependency:\n  items:\n    - link: dir:"+filepath.ToSlash(libDir)+"\n"+
+		"resource:\n  items:\n    - dir: script\n")
+	writeTestProjectFile(t, appDir, "script/main.sh.dtpl", "echo {{ .option.greeting }}\n")
+	writeTestProjectFile(t, appDir, "script/common.dtpl.lib", "")
+	writeTestProjectFile(t, libDir, "project.yml", "name: lib\nresource:\n  items:\n    - dir: script\n")
+	writeTestProjectFile(t, libDir, "script/lib.sh", "echo lib\n")
+
+	environment, err := NewEnvironment(NewLogger(LogLevelError), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspace, err := NewWorkspace(environment, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	app, err := setup(workspace.NewAppBuilder()).Build("dir:" + appDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return app, appDir, libDir
+}
+
+func TestApplicationProjects(t *testing.T) {
+	app, appDir, libDir := newTestProjectApp(t, func(builder *ApplicationBuilder) *ApplicationBuilder {
+		return builder
+	})
+
+	mainProject, err := app.GetMainProject()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mainProject.GetName() != "app" || mainProject.GetDir() != appDir {
+		t.Fatal("main project mismatch", mainProject.GetName(), mainProject.GetDir())
+	}
+	projects, err := app.GetProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for i := 0; i < len(projects); i++ {
+		names = append(names, projects[i].GetName())
+	}
+	if !slices.Equal(names, []string{"app", "lib"}) {
+		t.Fatal("projects mismatch", names)
+	}
+
+	lib, err := app.GetProject("lib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lib == nil || lib.GetDir() != libDir {
+		t.Fatal("lib project mismatch", lib)
+	}
+	if missing, err := app.GetProject("missing"); err != nil || missing != nil {
+		t.Fatal("missing project should be nil", missing, err)
+	}
+
+	dependencies := mainProject.GetDependencies()
+	if len(dependencies) != 1 || dependencies[0].ProjectName != "lib" || dependencies[0].Dir != libDir {
+		t.Fatal("dependencies mismatch", dependencies)
+	}
+	graph, err := app.GetDependencyGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(graph) != 2 || !slices.Equal(graph["app"], []string{"lib"}) || len(graph["lib"]) != 0 {
+		t.Fatal("dependency graph mismatch", graph)
+	}
+
+	var kinds []ApplicationProjectResourceKind
+	resources := mainProject.GetResources()
+	for i := 0; i < len(resources); i++ {
+		kinds = append(kinds, resources[i].Kind)
+	}
+	if !slices.Equal(kinds, []ApplicationProjectResourceKind{ApplicationProjectResourceKindTemplate, ApplicationProjectResourceKindTemplateLib}) {
+		t.Fatal("resources mismatch", kinds)
+	}
+	targets, err := app.GetTargets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var targetNames []string
+	for i := 0; i < len(targets); i++ {
+		targetNames = append(targetNames, targets[i].ProjectName+":"+targets[i].Name)
+	}
+	if !slices.Equal(targetNames, []string{"app:app/main.sh", "lib:lib/lib.sh"}) {
+		t.Fatal("targets mismatch", targetNames)
+	}
+	if targets[0].Kind != ApplicationProjectResourceKindTemplate || targets[1].Kind != ApplicationProjectResourceKindPlain {
+		t.Fatal("target kinds mismatch", targets[0].Kind, targets[1].Kind)
+	}
+}
+
+func TestApplicationOptions(t *testing.T) {
+	app, _, _ := newTestProjectApp(t, func(builder *ApplicationBuilder) *ApplicationBuilder {
+		return builder.AddProfile().
+			SetArgumentSetting().
+			AddItem("level", "3", "").
+			CommitArgumentSetting().
+			CommitProfileSetting()
+	})
+
+	options, err := app.GetOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(options) != 2 {
+		t.Fatal("options mismatch", options)
+	}
+	if options[0].ProjectName != "app" || options[0].Name != "greeting" || options[0].Value != "hello" || options[0].Source != "default" {
+		t.Fatal("default option mismatch", options[0])
+	}
+	if options[1].Name != "level" || options[1].Value != int64(3) || options[1].Source != "assign" {
+		t.Fatal("assigned option mismatch", options[1])
+	}
+}
+
+func TestApplicationProfileOptions(t *testing.T) {
+	app, _, _ := newTestProjectApp(t, func(builder *ApplicationBuilder) *ApplicationBuilder {
+		return builder.
+			AddProfile(WithProfileSource("low"), WithProfilePosition(-1)).
+			SetArgumentSetting().
+			AddItem("greeting", "low", "").
+			CommitArgumentSetting().
+			CommitProfileSetting().
+			AddProfile(WithProfileSource("high")).
+			SetArgumentSetting().
+			AddItem("greeting", "high", "").
+			CommitArgumentSetting().
+			CommitProfileSetting()
+	})
+
+	project, err := app.GetMainProject()
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := project.GetOptions()
+	if len(options) == 0 || options[0].Name != "greeting" || options[0].Value != "high" {
+		t.Fatal("the profile at the lower position should have the higher priority", options)
+	}
+
+	environment, err := NewEnvironment(NewLogger(LogLevelError), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspace, err := NewWorkspace(environment, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder := workspace.NewAppBuilder().
+		AddProfile(WithProfileSource("custom-source")).
+		SetArgumentSetting().
+		AddItem("", "value", "").
+		CommitArgumentSetting().
+		CommitProfileSetting()
+	if err = builder.Error(); err == nil || !strings.Contains(err.Error(), "custom-source") {
+		t.Fatal("the profile source should be reported in the error", err)
+	}
+}
@@ -0,0 +1,308 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	. "github.com/orz-dsh/dsh/core/builder"
+	. "github.com/orz-dsh/dsh/core/common"
+	. "github.com/orz-dsh/dsh/core/inspection"
+	. "github.com/orz-dsh/dsh/core/internal/setting"
+	. "github.com/orz-dsh/dsh/utils"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// region reproduce
+
+// Reproduce loads the inspection from the output dir of a previous build, and builds the application again,
+// see ReproduceInspection.
+func (w *Workspace) Reproduce(dir string) (*Application, *ApplicationReproduceReport, error) {
+	inspection, err := Load(dir)
+	if err != nil {
+		return nil, nil, ErrW(err, "reproduce application error",
+			Reason("load inspection error"),
+			KV("dir", dir),
+		)
+	}
+	return w.ReproduceInspection(inspection)
+}
+
+// ReproduceInspection builds the application again with the assigned options, the addition projects, the executors,
+// the registries, the redirects and the config overrides recorded in the inspection, instead of the profiles of the workspace,
+// and the dependency links are redirected to the recorded dirs, git commits and oci digests.
+// The report contains the settings can not be reproduced, and the differences between the inspections.
+func (w *Workspace) ReproduceInspection(inspection *ApplicationInspection) (*Application, *ApplicationReproduceReport, error) {
+	if inspection.MainProject == nil || inspection.Setting == nil || inspection.Option == nil {
+		return nil, nil, ErrN("reproduce application error",
+			Reason("inspection incomplete"),
+		)
+	}
+	if !IsDirExists(inspection.MainProject.Dir) {
+		return nil, nil, ErrN("reproduce application error",
+			Reason("main project dir not found"),
+			KV("projectName", inspection.MainProject.Name),
+			KV("dir", inspection.MainProject.Dir),
+		)
+	}
+
+	report := NewApplicationReproduceReport()
+	builder := &ApplicationBuilder{workspace: w.core}
+	profile := builder.AddProfile(WithProfileSource("reproduce"))
+	addReproduceArguments(profile.SetArgumentSetting(), inspection, report).CommitArgumentSetting()
+	addReproduceAdditions(profile.SetAdditionSetting(), inspection, report).CommitAdditionSetting()
+	addReproduceRedirects(profile.SetRedirectSetting(), inspection, report).CommitRedirectSetting()
+	registry := profile.SetRegistrySetting()
+	if inspection.Setting.Registry != nil {
+		for _, item := range inspection.Setting.Registry.Items {
+			registry.AddItem(item.Name, item.Link, item.Match)
+		}
+	}
+	registry.CommitRegistrySetting()
+	executor := profile.SetExecutorSetting()
+	if inspection.Setting.Executor != nil {
+		for _, item := range inspection.Setting.Executor.Items {
+			executor.AddItem(item.Name, item.File, item.Exts, item.Args, item.Match)
+		}
+	}
+	executor.CommitExecutorSetting().CommitProfileSetting()
+	if inspection.Setting.Config != nil {
+		for _, item := range inspection.Setting.Config.Items {
+			value, err := json.Marshal(item.Value)
+			if err != nil {
+				return nil, nil, ErrW(err, "reproduce application error",
+					Reason("marshal config override value error"),
+					KV("path", item.Path),
+				)
+			}
+			builder.configItems = append(builder.configItems, NewConfigOverrideItemSettingModel(item.Path, string(value), item.Source))
+		}
+	}
+
+	app, err := builder.Build("dir:" + inspection.MainProject.Dir)
+	if err != nil {
+		return nil, nil, ErrW(err, "reproduce application error",
+			Reason("build application error"),
+		)
+	}
+	reproduced, err := app.Inspect()
+	if err != nil {
+		return nil, nil, ErrW(err, "reproduce application error",
+			Reason("inspect application error"),
+		)
+	}
+	checkReproduceCommits(inspection, reproduced, report)
+	report.Diff = removeReproducePinnedDiffs(DiffApplicationInspection(inspection, reproduced), inspection, reproduced)
+	return app, report, nil
+}
+
+// addReproduceArguments adds the assigned options and the prompted options of the main project as the arguments,
+// the masked secrets can not be reproduced.
+func addReproduceArguments(builder *ProfileArgumentSettingModelBuilder[*ProfileSettingModelBuilder[*ApplicationBuilder]], inspection *ApplicationInspection, report *ApplicationReproduceReport) *ProfileArgumentSettingModelBuilder[*ProfileSettingModelBuilder[*ApplicationBuilder]] {
+	arguments := map[string]string{}
+	var assigns []map[string]string
+	if inspection.Option.Assign != nil {
+		assigns = append(assigns, inspection.Option.Assign.Common, inspection.Option.Assign.Export, inspection.Option.Assign.Project[inspection.MainProject.Name])
+		// the arguments are assigned to the main project only, the options of the other projects are reported
+		for projectName, items := range inspection.Option.Assign.Project {
+			if projectName == inspection.MainProject.Name {
+				continue
+			}
+			for optionName := range items {
+				report.AddIssue(ApplicationReproduceIssueKindOption, projectName+"."+optionName, "assigned value of the non-main project can not be assigned")
+			}
+		}
+	}
+	for i := 0; i < len(assigns); i++ {
+		for name, value := range assigns[i] {
+			if value == ProjectOptionSecretMask {
+				report.AddIssue(ApplicationReproduceIssueKindOption, name, "secret value is masked in the inspection")
+				continue
+			}
+			arguments[name] = value
+		}
+	}
+
+	if inspection.Option.Result != nil {
+		for projectName, items := range inspection.Option.Result.Items {
+			for optionName, item := range items {
+				if item.Source != "prompt" {
+					continue
+				}
+				key := projectName + "." + optionName
+				if projectName != inspection.MainProject.Name {
+					report.AddIssue(ApplicationReproduceIssueKindOption, key, "prompted value of the non-main project can not be assigned")
+				} else if item.Value == ProjectOptionSecretMask {
+					report.AddIssue(ApplicationReproduceIssueKindOption, key, "secret value is masked in the inspection")
+				} else if _, exist := arguments[optionName]; !exist {
+					arguments[optionName] = formatReproduceArgument(item.Value)
+				}
+			}
+		}
+	}
+
+	names := MapKeys(arguments)
+	slices.Sort(names)
+	for i := 0; i < len(names); i++ {
+		builder.AddItem(names[i], arguments[names[i]], "")
+	}
+	return builder
+}
+
+func addReproduceAdditions(builder *ProfileAdditionSettingModelBuilder[*ProfileSettingModelBuilder[*ApplicationBuilder]], inspection *ApplicationInspection, report *ApplicationReproduceReport) *ProfileAdditionSettingModelBuilder[*ProfileSettingModelBuilder[*ApplicationBuilder]] {
+	items := map[string]*ProfileAdditionItemSettingInspection{}
+	if inspection.Setting.Addition != nil {
+		for _, item := range inspection.Setting.Addition.Items {
+			if _, exist := items[item.Name]; !exist {
+				items[item.Name] = item
+			}
+		}
+	}
+	// only the matched addition items are added, and the match expressions are not evaluated again
+	for _, project := range inspection.AdditionProjects {
+		item, exist := items[project.Name]
+		if !exist {
+			report.AddIssue(ApplicationReproduceIssueKindProject, project.Name, "addition setting not found in the inspection")
+			continue
+		}
+		if !IsDirExists(item.Dir) {
+			report.AddIssue(ApplicationReproduceIssueKindProject, project.Name, "addition project dir not found")
+			continue
+		}
+		itemBuilder := builder.AddItemSetting(item.Name, item.Dir)
+		if item.Dependency != nil {
+			for _, dependency := range item.Dependency.Items {
+				itemBuilder.AddDependencyItem(dependency.Link, dependency.Match)
+			}
+		}
+		if item.Resource != nil {
+			for _, resource := range item.Resource.Items {
+				itemBuilder.AddResourceItem(resource.Dir, resource.Includes, resource.Excludes, resource.Match)
+			}
+		}
+		itemBuilder.CommitItemSetting()
+	}
+	return builder
+}
+
+// addReproduceRedirects redirects the dependency links to the recorded git commits, pinned oci refs or dirs, before the recorded redirects,
+// the git dependencies without the recorded commits are redirected to the recorded git refs.
+func addReproduceRedirects(builder *RedirectSettingModelBuilder[*ProfileSettingModelBuilder[*ApplicationBuilder]], inspection *ApplicationInspection, report *ApplicationReproduceReport) *RedirectSettingModelBuilder[*ProfileSettingModelBuilder[*ApplicationBuilder]] {
+	projects := append([]*ProjectInspection{inspection.MainProject}, inspection.AdditionProjects...)
+	projects = append(projects, inspection.DependencyProjects...)
+	linksDict := map[string]bool{}
+	for _, project := range projects {
+		if project.Dependency == nil {
+			continue
+		}
+		for _, item := range project.Dependency.Items {
+			if linksDict[item.Link] {
+				continue
+			}
+			linksDict[item.Link] = true
+			link := "dir:" + item.Dir
+			if item.GitUrl != "" && item.GitCommit != "" {
+				link = "git:" + item.GitUrl + "#ref=commit/" + item.GitCommit
+			} else if item.GitUrl != "" {
+				link = "git:" + item.GitUrl + "#ref=" + item.GitRef
+			} else if item.OciRef != "" {
+				link = "oci://" + item.OciRef
+			} else if !IsDirExists(item.Dir) {
+				report.AddIssue(ApplicationReproduceIssueKindDependency, item.Link, "dependency project dir not found")
+				continue
+			}
+			builder.AddItem("^"+regexp.QuoteMeta(item.Link)+"$", link, "")
+		}
+	}
+	if inspection.Setting.Redirect != nil {
+		for _, item := range inspection.Setting.Redirect.Items {
+			builder.AddItem(item.Regex, item.Link, item.Match)
+		}
+	}
+	return builder
+}
+
+// checkReproduceCommits checks the commits of the git dependencies, the commit is changed if the branch has moved.
+func checkReproduceCommits(inspection, reproduced *ApplicationInspection, report *ApplicationReproduceReport) {
+	commits := map[string]string{}
+	for _, project := range append([]*ProjectInspection{reproduced.MainProject}, append(reproduced.AdditionProjects, reproduced.DependencyProjects...)...) {
+		if project.Dependency != nil {
+			for _, item := range project.Dependency.Items {
+				commits[item.Link] = item.GitCommit
+			}
+		}
+	}
+	for _, project := range append([]*ProjectInspection{inspection.MainProject}, append(inspection.AdditionProjects, inspection.DependencyProjects...)...) {
+		if project.Dependency == nil {
+			continue
+		}
+		for _, item := range project.Dependency.Items {
+			if commit, exist := commits[item.Link]; exist && item.GitCommit != "" && commit != item.GitCommit {
+				report.AddIssue(ApplicationReproduceIssueKindDependency, item.Link, fmt.Sprintf("git commit changed from %s to %s", item.GitCommit, commit))
+				commits[item.Link] = item.GitCommit
+			}
+		}
+	}
+}
+
+// removeReproducePinnedDiffs removes the differences caused by pinning the git dependencies to the recorded commits,
+// the pinned projects are loaded from the commit dirs, and the refs are changed to the commits.
+func removeReproducePinnedDiffs(diff *ApplicationInspectionDiff, inspection, reproduced *ApplicationInspection) *ApplicationInspectionDiff {
+	items := map[string]*ProjectDependencyItemInspection{}
+	for _, project := range append([]*ProjectInspection{reproduced.MainProject}, append(reproduced.AdditionProjects, reproduced.DependencyProjects...)...) {
+		if project.Dependency != nil {
+			for _, item := range project.Dependency.Items {
+				items[item.Link] = item
+			}
+		}
+	}
+	pinnedDirs := map[string]string{}
+	for _, project := range append([]*ProjectInspection{inspection.MainProject}, append(inspection.AdditionProjects, inspection.DependencyProjects...)...) {
+		if project.Dependency == nil {
+			continue
+		}
+		for _, item := range project.Dependency.Items {
+			if pinned, exist := items[item.Link]; exist && item.GitCommit != "" && pinned.GitRef == "commit/"+item.GitCommit {
+				pinnedDirs[item.Dir] = pinned.Dir
+			}
+		}
+	}
+
+	var result []*ApplicationInspectionDiffItem
+	for _, item := range diff.Items {
+		if item.Action == ApplicationInspectionDiffActionChanged {
+			if item.Kind == ApplicationInspectionDiffKindProject && pinnedDirs[fmt.Sprint(item.OldValue)] == item.NewValue {
+				continue
+			}
+			if item.Kind == ApplicationInspectionDiffKindGitRef && isReproducePinnedGitRef(fmt.Sprint(item.OldValue), fmt.Sprint(item.NewValue)) {
+				continue
+			}
+		}
+		result = append(result, item)
+	}
+	return NewApplicationInspectionDiff(result)
+}
+
+// isReproducePinnedGitRef checks whether the git ref like `main@<commit>` is pinned to the same commit like `commit/<commit>@<commit>`.
+func isReproducePinnedGitRef(old, new string) bool {
+	index := strings.LastIndex(old, "@")
+	if index < 0 {
+		return false
+	}
+	commit := old[index+1:]
+	return new == "commit/"+commit+"@"+commit
+}
+
+// formatReproduceArgument formats the option value as the argument, the values except strings are formatted as json.
+func formatReproduceArgument(value any) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// endregion
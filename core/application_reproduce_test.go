@@ -0,0 +1,123 @@
+package core
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	. "github.com/orz-dsh/dsh/core/common"
+	. "github.com/orz-dsh/dsh/core/inspection"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func commitTestGitFile(t *testing.T, repo *git.Repository, dir, file, content string) string {
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = worktree.Add(file); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := worktree.Commit("update "+file, &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash.String()
+}
+
+func getTestDependencyItem(inspection *ApplicationInspection) *ProjectDependencyItemInspection {
+	if inspection.MainProject.Dependency == nil || len(inspection.MainProject.Dependency.Items) != 1 {
+		return nil
+	}
+	return inspection.MainProject.Dependency.Items[0]
+}
+
+func TestReproduceGitCommit(t *testing.T) {
+	workspace := newTestWorkspace(t)
+	libDir := t.TempDir()
+	repo, err := git.PlainInit(libDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := commitTestGitFile(t, repo, libDir, "project.yml", "name: lib\n")
+
+	appDir := t.TempDir()
+	if err = os.WriteFile(filepath.Join(appDir, "project.yml"), []byte("name: app\ndependency:\n  items:\n    - link: registry:local#ref=master\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	app, err := workspace.NewAppBuilder().
+		AddProfileSetting("test", 0).
+		SetRegistrySetting().
+		AddItem("local", "git:file://"+filepath.ToSlash(libDir)+"#ref={{ .registry.ref }}", "").
+		CommitRegistrySetting().
+		CommitProfileSetting().
+		Build("dir:" + appDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inspection, err := app.Inspect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item := getTestDependencyItem(inspection); item == nil || item.GitCommit != commit {
+		t.Fatal("dependency commit", item)
+	}
+
+	// the branch has moved, but the registry dependency is pinned to the recorded commit
+	commitTestGitFile(t, repo, libDir, "project.yml", "name: lib\n# moved\n")
+	_, report, err := workspace.ReproduceInspection(inspection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.IsReproduced() {
+		t.Fatal("build should be reproduced", report.Issues, report.Diff)
+	}
+}
+
+func TestReproduceArgumentsNonMainProject(t *testing.T) {
+	workspace := newTestWorkspace(t)
+	inspection := &ApplicationInspection{
+		MainProject: NewProjectInspection("app", t.TempDir(), nil, nil, nil, nil),
+		Option: NewApplicationOptionInspection(NewApplicationOptionAssignInspection(nil, nil, map[string]map[string]string{
+			"app": {"env": "dev"},
+			"lib": {"level": "debug"},
+		}), nil, nil, nil),
+	}
+	report := NewApplicationReproduceReport()
+	builder := &ApplicationBuilder{workspace: workspace.core}
+	addReproduceArguments(builder.AddProfile(WithProfileSource("reproduce")).SetArgumentSetting(), inspection, report)
+	if len(report.Issues) != 1 || report.Issues[0].Key != "lib.level" || report.Issues[0].Kind != ApplicationReproduceIssueKindOption {
+		t.Fatal("non-main project assign should be reported", report.Issues)
+	}
+}
+
+func TestReproducePinnedDiffs(t *testing.T) {
+	commit := "0123456789abcdef0123456789abcdef01234567"
+	newInspection := func(dir, ref string) *ApplicationInspection {
+		dependency := NewProjectDependencyInspection([]*ProjectDependencyItemInspection{
+			NewProjectDependencyItemInspection("git:https://example.com/lib.git#ref=branch/main", dir, "https://example.com/lib.git", ref, commit),
+		})
+		return &ApplicationInspection{
+			MainProject:        NewProjectInspection("app", "/app", nil, dependency, nil, nil),
+			DependencyProjects: []*ProjectInspection{NewProjectInspection("lib", dir, nil, nil, nil, nil)},
+		}
+	}
+	inspection := newInspection("/workspace/lib@branch-main", "branch/main")
+	reproduced := newInspection("/workspace/lib@commit-"+commit, "commit/"+commit)
+	diff := removeReproducePinnedDiffs(DiffApplicationInspection(inspection, reproduced), inspection, reproduced)
+	if !diff.IsEmpty() {
+		t.Fatal("pinned differences should be removed", diff.Items)
+	}
+
+	reproduced = newInspection("/workspace/lib@branch-dev", "branch/dev")
+	diff = removeReproducePinnedDiffs(DiffApplicationInspection(inspection, reproduced), inspection, reproduced)
+	if len(diff.GetItems(ApplicationInspectionDiffKindProject)) != 1 || len(diff.GetItems(ApplicationInspectionDiffKindGitRef)) != 1 {
+		t.Fatal("unpinned differences should be kept", diff.Items)
+	}
+}
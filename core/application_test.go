@@ -0,0 +1,111 @@
+package core
+
+import (
+	. "github.com/orz-dsh/dsh/core/common"
+	. "github.com/orz-dsh/dsh/core/inspection"
+	. "github.com/orz-dsh/dsh/utils"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func makeTestCachedArtifact(t *testing.T, workspaceDir, appDir, var1 string) *Artifact {
+	environment, err := NewEnvironment(NewLogger(LogLevelError), map[string]string{
+		"argument_item_var1": var1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspace, err := NewWorkspace(environment, workspaceDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app, err := workspace.NewAppBuilder().Build("dir:" + appDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	artifact, err := app.MakeArtifact(MakeArtifactOptions{OutputCache: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return artifact
+}
+
+func TestMakeArtifactOutputCache(t *testing.T) {
+	workspaceDir := t.TempDir()
+	appDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(appDir, "project.yml"), []byte("name: app\nresource:\n  items:\n    - dir: script\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(appDir, "script"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(appDir, "script", "main.sh.dtpl"), []byte("echo {{ .global.var1 }}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	artifact1 := makeTestCachedArtifact(t, workspaceDir, appDir, "a")
+	artifact2 := makeTestCachedArtifact(t, workspaceDir, appDir, "a")
+	if artifact1.GetOutputDir() != artifact2.GetOutputDir() {
+		t.Fatal("identical inputs should hit the output cache", artifact1.GetOutputDir(), artifact2.GetOutputDir())
+	}
+
+	artifact3 := makeTestCachedArtifact(t, workspaceDir, appDir, "b")
+	if artifact3.GetOutputDir() == artifact1.GetOutputDir() {
+		t.Fatal("changed global argument should miss the output cache", artifact3.GetOutputDir())
+	}
+	data, err := os.ReadFile(filepath.Join(artifact3.GetOutputDir(), "app", "main.sh"))
+	if err != nil || string(data) != "echo b\n" {
+		t.Fatal("output should be remade", string(data), err)
+	}
+}
+
+func TestMakeArtifactInspectionLoad(t *testing.T) {
+	appDir := t.TempDir()
+	libDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(appDir, "project.yml"), []byte("name: app\n"+
+		"option:\n  items:\n    - name: timeout\n      type: duration\n      default: 30s\n    - name: ports\n      type: array<integer>\n      default: '[80, 443]'\n"+
+		"dependency:\n  items:\n    - link: dir:"+filepath.ToSlash(libDir)+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(libDir, "project.yml"), []byte("name: lib\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	environment, err := NewEnvironment(NewLogger(LogLevelError), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspace, err := NewWorkspace(environment, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	app, err := workspace.NewAppBuilder().Build("dir:" + appDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inspection, err := app.Inspect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, serializer := range []Serializer{YamlSerializerDefault, TomlSerializerDefault, JsonSerializerDefault} {
+		artifact, err := app.MakeArtifact(MakeArtifactOptions{InspectSerializer: serializer})
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := Load(artifact.GetOutputDir())
+		if err != nil {
+			t.Fatal(serializer.GetFormat(), err)
+		}
+		if diff := DiffApplicationInspection(inspection, loaded); !diff.IsEmpty() {
+			t.Fatal("loaded inspection should equal the application inspection", serializer.GetFormat(), diff.Items)
+		}
+		if value := loaded.Option.Result.Items["app"]["timeout"].Value; value != "30s" {
+			t.Fatal("duration option should be loaded as formatted", serializer.GetFormat(), value)
+		}
+		if loaded.MainProject.Name != "app" || len(loaded.DependencyProjects) != 1 || loaded.DependencyProjects[0].Name != "lib" {
+			t.Fatal("projects should be loaded", serializer.GetFormat(), loaded.MainProject, loaded.DependencyProjects)
+		}
+	}
+}
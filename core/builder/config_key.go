@@ -0,0 +1,33 @@
+package builder
+
+import . "github.com/orz-dsh/dsh/core/internal/setting"
+
+// region ConfigKeySettingModelBuilder
+
+type ConfigKeySettingModelBuilder[R any] struct {
+	commit func(*ConfigKeySettingModel) R
+	keys   []string
+	files  []string
+}
+
+func NewConfigKeySettingModelBuilder[R any](commit func(*ConfigKeySettingModel) R) *ConfigKeySettingModelBuilder[R] {
+	return &ConfigKeySettingModelBuilder[R]{
+		commit: commit,
+	}
+}
+
+func (b *ConfigKeySettingModelBuilder[R]) AddKeys(keys ...string) *ConfigKeySettingModelBuilder[R] {
+	b.keys = append(b.keys, keys...)
+	return b
+}
+
+func (b *ConfigKeySettingModelBuilder[R]) AddFiles(files ...string) *ConfigKeySettingModelBuilder[R] {
+	b.files = append(b.files, files...)
+	return b
+}
+
+func (b *ConfigKeySettingModelBuilder[R]) CommitConfigKeySetting() R {
+	return b.commit(NewConfigKeySettingModel(b.keys, b.files))
+}
+
+// endregion
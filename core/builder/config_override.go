@@ -0,0 +1,36 @@
+package builder
+
+import . "github.com/orz-dsh/dsh/core/internal/setting"
+
+// region ConfigOverrideSettingModelBuilder
+
+type ConfigOverrideSettingModelBuilder[R any] struct {
+	commit func(*ConfigOverrideSettingModel) R
+	items  []*ConfigOverrideItemSettingModel
+}
+
+func NewConfigOverrideSettingModelBuilder[R any](commit func(*ConfigOverrideSettingModel) R) *ConfigOverrideSettingModelBuilder[R] {
+	return &ConfigOverrideSettingModelBuilder[R]{
+		commit: commit,
+	}
+}
+
+func (b *ConfigOverrideSettingModelBuilder[R]) SetItems(items []*ConfigOverrideItemSettingModel) *ConfigOverrideSettingModelBuilder[R] {
+	b.items = items
+	return b
+}
+
+func (b *ConfigOverrideSettingModelBuilder[R]) AddItem(path, value, source string) *ConfigOverrideSettingModelBuilder[R] {
+	return b.AddItemModel(NewConfigOverrideItemSettingModel(path, value, source))
+}
+
+func (b *ConfigOverrideSettingModelBuilder[R]) AddItemModel(item *ConfigOverrideItemSettingModel) *ConfigOverrideSettingModelBuilder[R] {
+	b.items = append(b.items, item)
+	return b
+}
+
+func (b *ConfigOverrideSettingModelBuilder[R]) CommitConfigSetting() R {
+	return b.commit(NewConfigOverrideSettingModel(b.items))
+}
+
+// endregion
@@ -8,6 +8,9 @@ type EnvironmentSettingModelBuilder[R any] struct {
 	commit    func(*EnvironmentSettingModel) R
 	argument  *EnvironmentArgumentSettingModel
 	workspace *EnvironmentWorkspaceSettingModel
+	config    *ConfigOverrideSettingModel
+	configKey *ConfigKeySettingModel
+	log       *LogSettingModel
 }
 
 func NewEnvironmentSettingModelBuilder[R any](commit func(*EnvironmentSettingModel) R) *EnvironmentSettingModelBuilder[R] {
@@ -24,8 +27,20 @@ func (b *EnvironmentSettingModelBuilder[R]) SetWorkspaceSetting() *EnvironmentWo
 	return NewEnvironmentWorkspaceSettingModelBuilder(b.setWorkspaceSettingModel)
 }
 
+func (b *EnvironmentSettingModelBuilder[R]) SetConfigSetting() *ConfigOverrideSettingModelBuilder[*EnvironmentSettingModelBuilder[R]] {
+	return NewConfigOverrideSettingModelBuilder(b.setConfigSettingModel)
+}
+
+func (b *EnvironmentSettingModelBuilder[R]) SetConfigKeySetting() *ConfigKeySettingModelBuilder[*EnvironmentSettingModelBuilder[R]] {
+	return NewConfigKeySettingModelBuilder(b.setConfigKeySettingModel)
+}
+
+func (b *EnvironmentSettingModelBuilder[R]) SetLogSetting() *LogSettingModelBuilder[*EnvironmentSettingModelBuilder[R]] {
+	return NewLogSettingModelBuilder(b.setLogSettingModel)
+}
+
 func (b *EnvironmentSettingModelBuilder[R]) CommitEnvironmentSetting() R {
-	return b.commit(NewEnvironmentSettingModel(b.argument, b.workspace))
+	return b.commit(NewEnvironmentSettingModel(b.argument, b.workspace, b.config, b.configKey, b.log))
 }
 
 func (b *EnvironmentSettingModelBuilder[R]) setArgumentSettingModel(argument *EnvironmentArgumentSettingModel) *EnvironmentSettingModelBuilder[R] {
@@ -38,4 +53,19 @@ func (b *EnvironmentSettingModelBuilder[R]) setWorkspaceSettingModel(workspace *
 	return b
 }
 
+func (b *EnvironmentSettingModelBuilder[R]) setConfigSettingModel(config *ConfigOverrideSettingModel) *EnvironmentSettingModelBuilder[R] {
+	b.config = config
+	return b
+}
+
+func (b *EnvironmentSettingModelBuilder[R]) setConfigKeySettingModel(configKey *ConfigKeySettingModel) *EnvironmentSettingModelBuilder[R] {
+	b.configKey = configKey
+	return b
+}
+
+func (b *EnvironmentSettingModelBuilder[R]) setLogSettingModel(log *LogSettingModel) *EnvironmentSettingModelBuilder[R] {
+	b.log = log
+	return b
+}
+
 // endregion
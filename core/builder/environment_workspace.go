@@ -8,6 +8,7 @@ type EnvironmentWorkspaceSettingModelBuilder[R any] struct {
 	commit   func(*EnvironmentWorkspaceSettingModel) R
 	dir      string
 	clean    *WorkspaceCleanSettingModel
+	lock     *WorkspaceLockSettingModel
 	profile  *WorkspaceProfileSettingModel
 	executor *ExecutorSettingModel
 	registry *RegistrySettingModel
@@ -29,6 +30,10 @@ func (b *EnvironmentWorkspaceSettingModelBuilder[R]) SetCleanSetting() *Workspac
 	return NewWorkspaceCleanSettingModelBuilder(b.setCleanSettingModel)
 }
 
+func (b *EnvironmentWorkspaceSettingModelBuilder[R]) SetLockSetting() *WorkspaceLockSettingModelBuilder[*EnvironmentWorkspaceSettingModelBuilder[R]] {
+	return NewWorkspaceLockSettingModelBuilder(b.setLockSettingModel)
+}
+
 func (b *EnvironmentWorkspaceSettingModelBuilder[R]) SetProfileSetting() *WorkspaceProfileSettingModelBuilder[*EnvironmentWorkspaceSettingModelBuilder[R]] {
 	return NewWorkspaceProfileSettingModelBuilder(b.setProfileSettingModel)
 }
@@ -46,7 +51,7 @@ func (b *EnvironmentWorkspaceSettingModelBuilder[R]) SetRedirectSetting() *Redir
 }
 
 func (b *EnvironmentWorkspaceSettingModelBuilder[R]) CommitWorkspaceSetting() R {
-	return b.commit(NewEnvironmentWorkspaceSettingModel(b.dir, b.clean, b.profile, b.executor, b.registry, b.redirect))
+	return b.commit(NewEnvironmentWorkspaceSettingModel(b.dir, b.clean, b.lock, b.profile, b.executor, b.registry, b.redirect))
 }
 
 func (b *EnvironmentWorkspaceSettingModelBuilder[R]) setCleanSettingModel(clean *WorkspaceCleanSettingModel) *EnvironmentWorkspaceSettingModelBuilder[R] {
@@ -54,6 +59,11 @@ func (b *EnvironmentWorkspaceSettingModelBuilder[R]) setCleanSettingModel(clean
 	return b
 }
 
+func (b *EnvironmentWorkspaceSettingModelBuilder[R]) setLockSettingModel(lock *WorkspaceLockSettingModel) *EnvironmentWorkspaceSettingModelBuilder[R] {
+	b.lock = lock
+	return b
+}
+
 func (b *EnvironmentWorkspaceSettingModelBuilder[R]) setProfileSettingModel(profile *WorkspaceProfileSettingModel) *EnvironmentWorkspaceSettingModelBuilder[R] {
 	b.profile = profile
 	return b
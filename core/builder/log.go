@@ -0,0 +1,33 @@
+package builder
+
+import . "github.com/orz-dsh/dsh/core/internal/setting"
+
+// region LogSettingModelBuilder
+
+type LogSettingModelBuilder[R any] struct {
+	commit func(*LogSettingModel) R
+	level  string
+	format string
+}
+
+func NewLogSettingModelBuilder[R any](commit func(*LogSettingModel) R) *LogSettingModelBuilder[R] {
+	return &LogSettingModelBuilder[R]{
+		commit: commit,
+	}
+}
+
+func (b *LogSettingModelBuilder[R]) SetLevel(level string) *LogSettingModelBuilder[R] {
+	b.level = level
+	return b
+}
+
+func (b *LogSettingModelBuilder[R]) SetFormat(format string) *LogSettingModelBuilder[R] {
+	b.format = format
+	return b
+}
+
+func (b *LogSettingModelBuilder[R]) CommitLogSetting() R {
+	return b.commit(NewLogSettingModel(b.level, b.format))
+}
+
+// endregion
@@ -7,6 +7,7 @@ import . "github.com/orz-dsh/dsh/core/internal/setting"
 type WorkspaceSettingModelBuilder[R any] struct {
 	commit   func(*WorkspaceSettingModel) R
 	clean    *WorkspaceCleanSettingModel
+	lock     *WorkspaceLockSettingModel
 	profile  *WorkspaceProfileSettingModel
 	executor *ExecutorSettingModel
 	registry *RegistrySettingModel
@@ -23,6 +24,10 @@ func (b *WorkspaceSettingModelBuilder[R]) SetCleanSetting() *WorkspaceCleanSetti
 	return NewWorkspaceCleanSettingModelBuilder(b.setCleanSettingModel)
 }
 
+func (b *WorkspaceSettingModelBuilder[R]) SetLockSetting() *WorkspaceLockSettingModelBuilder[*WorkspaceSettingModelBuilder[R]] {
+	return NewWorkspaceLockSettingModelBuilder(b.setLockSettingModel)
+}
+
 func (b *WorkspaceSettingModelBuilder[R]) SetProfileSetting() *WorkspaceProfileSettingModelBuilder[*WorkspaceSettingModelBuilder[R]] {
 	return NewWorkspaceProfileSettingModelBuilder(b.setProfileSettingModel)
 }
@@ -40,7 +45,7 @@ func (b *WorkspaceSettingModelBuilder[R]) SetRedirectSetting() *RedirectSettingM
 }
 
 func (b *WorkspaceSettingModelBuilder[R]) CommitWorkspaceSetting() R {
-	return b.commit(NewWorkspaceSettingModel(b.clean, b.profile, b.executor, b.registry, b.redirect))
+	return b.commit(NewWorkspaceSettingModel(b.clean, b.lock, b.profile, b.executor, b.registry, b.redirect))
 }
 
 func (b *WorkspaceSettingModelBuilder[R]) setCleanSettingModel(clean *WorkspaceCleanSettingModel) *WorkspaceSettingModelBuilder[R] {
@@ -48,6 +53,11 @@ func (b *WorkspaceSettingModelBuilder[R]) setCleanSettingModel(clean *WorkspaceC
 	return b
 }
 
+func (b *WorkspaceSettingModelBuilder[R]) setLockSettingModel(lock *WorkspaceLockSettingModel) *WorkspaceSettingModelBuilder[R] {
+	b.lock = lock
+	return b
+}
+
 func (b *WorkspaceSettingModelBuilder[R]) setProfileSettingModel(profile *WorkspaceProfileSettingModel) *WorkspaceSettingModelBuilder[R] {
 	b.profile = profile
 	return b
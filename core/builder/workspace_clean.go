@@ -5,8 +5,9 @@ import . "github.com/orz-dsh/dsh/core/internal/setting"
 // region WorkspaceCleanSettingModelBuilder
 
 type WorkspaceCleanSettingModelBuilder[R any] struct {
-	commit func(model *WorkspaceCleanSettingModel) R
-	output *WorkspaceCleanOutputSettingModel
+	commit  func(model *WorkspaceCleanSettingModel) R
+	output  *WorkspaceCleanOutputSettingModel
+	project *WorkspaceCleanProjectSettingModel
 }
 
 func NewWorkspaceCleanSettingModelBuilder[R any](commit func(model *WorkspaceCleanSettingModel) R) *WorkspaceCleanSettingModelBuilder[R] {
@@ -15,21 +16,31 @@ func NewWorkspaceCleanSettingModelBuilder[R any](commit func(model *WorkspaceCle
 	}
 }
 
-func (b *WorkspaceCleanSettingModelBuilder[R]) SetOutputCount(count int) *WorkspaceCleanSettingModelBuilder[R] {
+func (b *WorkspaceCleanSettingModelBuilder[R]) getOutput() *WorkspaceCleanOutputSettingModel {
 	if b.output == nil {
-		b.output = NewWorkspaceCleanOutputSettingModel(&count, "")
-	} else {
-		b.output.Count = &count
+		b.output = NewWorkspaceCleanOutputSettingModel(nil, "", "", nil)
 	}
+	return b.output
+}
+
+func (b *WorkspaceCleanSettingModelBuilder[R]) SetOutputCount(count int) *WorkspaceCleanSettingModelBuilder[R] {
+	b.getOutput().Count = &count
 	return b
 }
 
 func (b *WorkspaceCleanSettingModelBuilder[R]) SetOutputExpires(expires string) *WorkspaceCleanSettingModelBuilder[R] {
-	if b.output == nil {
-		b.output = NewWorkspaceCleanOutputSettingModel(nil, expires)
-	} else {
-		b.output.Expires = expires
-	}
+	b.getOutput().Expires = expires
+	return b
+}
+
+func (b *WorkspaceCleanSettingModelBuilder[R]) SetOutputSize(size string) *WorkspaceCleanSettingModelBuilder[R] {
+	b.getOutput().Size = size
+	return b
+}
+
+func (b *WorkspaceCleanSettingModelBuilder[R]) AddOutputItem(name string, count *int, expires string) *WorkspaceCleanSettingModelBuilder[R] {
+	output := b.getOutput()
+	output.Items = append(output.Items, NewWorkspaceCleanOutputItemSettingModel(name, count, expires))
 	return b
 }
 
@@ -38,8 +49,22 @@ func (b *WorkspaceCleanSettingModelBuilder[R]) SetOutputModel(output *WorkspaceC
 	return b
 }
 
+func (b *WorkspaceCleanSettingModelBuilder[R]) SetProjectExpires(expires string) *WorkspaceCleanSettingModelBuilder[R] {
+	if b.project == nil {
+		b.project = NewWorkspaceCleanProjectSettingModel(expires)
+	} else {
+		b.project.Expires = expires
+	}
+	return b
+}
+
+func (b *WorkspaceCleanSettingModelBuilder[R]) SetProjectModel(project *WorkspaceCleanProjectSettingModel) *WorkspaceCleanSettingModelBuilder[R] {
+	b.project = project
+	return b
+}
+
 func (b *WorkspaceCleanSettingModelBuilder[R]) CommitCleanSetting() R {
-	return b.commit(NewWorkspaceCleanSettingModel(b.output))
+	return b.commit(NewWorkspaceCleanSettingModel(b.output, b.project))
 }
 
 // endregion
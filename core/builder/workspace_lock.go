@@ -0,0 +1,27 @@
+package builder
+
+import . "github.com/orz-dsh/dsh/core/internal/setting"
+
+// region WorkspaceLockSettingModelBuilder
+
+type WorkspaceLockSettingModelBuilder[R any] struct {
+	commit  func(model *WorkspaceLockSettingModel) R
+	timeout string
+}
+
+func NewWorkspaceLockSettingModelBuilder[R any](commit func(model *WorkspaceLockSettingModel) R) *WorkspaceLockSettingModelBuilder[R] {
+	return &WorkspaceLockSettingModelBuilder[R]{
+		commit: commit,
+	}
+}
+
+func (b *WorkspaceLockSettingModelBuilder[R]) SetTimeout(timeout string) *WorkspaceLockSettingModelBuilder[R] {
+	b.timeout = timeout
+	return b
+}
+
+func (b *WorkspaceLockSettingModelBuilder[R]) CommitLockSetting() R {
+	return b.commit(NewWorkspaceLockSettingModel(b.timeout))
+}
+
+// endregion
@@ -0,0 +1,217 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	. "github.com/orz-dsh/dsh/utils"
+	"strings"
+)
+
+// region ApplicationHelpFormat
+
+type ApplicationHelpFormat string
+
+const (
+	ApplicationHelpFormatText     ApplicationHelpFormat = "text"
+	ApplicationHelpFormatMarkdown ApplicationHelpFormat = "markdown"
+	ApplicationHelpFormatJson     ApplicationHelpFormat = "json"
+)
+
+// endregion
+
+// region ApplicationHelp
+
+type ApplicationHelp struct {
+	Projects []*ApplicationHelpProject `yaml:"projects,omitempty" toml:"projects,omitempty" json:"projects,omitempty"`
+}
+
+func NewApplicationHelp(projects []*ApplicationHelpProject) *ApplicationHelp {
+	return &ApplicationHelp{
+		Projects: projects,
+	}
+}
+
+func (h *ApplicationHelp) Render(format ApplicationHelpFormat) (string, error) {
+	switch format {
+	case ApplicationHelpFormatText:
+		return h.renderText(), nil
+	case ApplicationHelpFormatMarkdown:
+		return h.renderMarkdown(), nil
+	case ApplicationHelpFormatJson:
+		data, err := json.MarshalIndent(h, "", "  ")
+		if err != nil {
+			return "", ErrW(err, "render help error",
+				Reason("marshal json error"),
+			)
+		}
+		return string(data), nil
+	default:
+		return "", ErrN("render help error",
+			Reason("format not supported"),
+			KV("format", format),
+		)
+	}
+}
+
+func (h *ApplicationHelp) renderText() string {
+	var builder strings.Builder
+	for i := 0; i < len(h.Projects); i++ {
+		project := h.Projects[i]
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(fmt.Sprintf("%s options:\n", project.Name))
+		if len(project.Options) == 0 {
+			builder.WriteString("  (none)\n")
+		}
+		for j := 0; j < len(project.Options); j++ {
+			option := project.Options[j]
+			attrs := []string{option.Type}
+			if option.Required {
+				attrs = append(attrs, "required")
+			} else if option.Default != nil {
+				attrs = append(attrs, "default: "+formatApplicationHelpValue(option.Default))
+			} else {
+				attrs = append(attrs, "optional")
+			}
+			builder.WriteString(fmt.Sprintf("  %s (%s)\n", option.Name, strings.Join(attrs, ", ")))
+			if option.Usage != "" {
+				builder.WriteString(fmt.Sprintf("    %s\n", option.Usage))
+			}
+			builder.WriteString(fmt.Sprintf("    export: %s\n", option.Export))
+			if len(option.Choices) > 0 {
+				builder.WriteString("    choices:\n")
+				for k := 0; k < len(option.Choices); k++ {
+					choice := option.Choices[k]
+					if choice.Usage != "" {
+						builder.WriteString(fmt.Sprintf("      %s - %s\n", formatApplicationHelpValue(choice.Value), choice.Usage))
+					} else {
+						builder.WriteString(fmt.Sprintf("      %s\n", formatApplicationHelpValue(choice.Value)))
+					}
+				}
+			}
+		}
+	}
+	return builder.String()
+}
+
+func (h *ApplicationHelp) renderMarkdown() string {
+	var builder strings.Builder
+	for i := 0; i < len(h.Projects); i++ {
+		project := h.Projects[i]
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(fmt.Sprintf("## %s\n\n", project.Name))
+		if len(project.Options) == 0 {
+			builder.WriteString("No options.\n")
+			continue
+		}
+		builder.WriteString("| Name | Export | Type | Required | Default | Choices | Usage |\n")
+		builder.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+		for j := 0; j < len(project.Options); j++ {
+			option := project.Options[j]
+			required := "no"
+			if option.Required {
+				required = "yes"
+			}
+			defaultValue := ""
+			if option.Default != nil {
+				defaultValue = "`" + escapeApplicationHelpMarkdown(formatApplicationHelpValue(option.Default)) + "`"
+			}
+			var choices []string
+			for k := 0; k < len(option.Choices); k++ {
+				choice := option.Choices[k]
+				value := "`" + escapeApplicationHelpMarkdown(formatApplicationHelpValue(choice.Value)) + "`"
+				if choice.Usage != "" {
+					value += " (" + escapeApplicationHelpMarkdown(choice.Usage) + ")"
+				}
+				choices = append(choices, value)
+			}
+			builder.WriteString(fmt.Sprintf("| `%s` | `%s` | %s | %s | %s | %s | %s |\n",
+				option.Name,
+				option.Export,
+				escapeApplicationHelpMarkdown(option.Type),
+				required,
+				defaultValue,
+				strings.Join(choices, ", "),
+				escapeApplicationHelpMarkdown(option.Usage),
+			))
+		}
+	}
+	return builder.String()
+}
+
+func formatApplicationHelpValue(value any) string {
+	if str, err := CastToString(value); err == nil && str != nil {
+		return *str
+	}
+	return fmt.Sprint(value)
+}
+
+func escapeApplicationHelpMarkdown(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", " ")
+}
+
+// endregion
+
+// region ApplicationHelpProject
+
+type ApplicationHelpProject struct {
+	Name    string                   `yaml:"name" toml:"name" json:"name"`
+	Dir     string                   `yaml:"dir" toml:"dir" json:"dir"`
+	Options []*ApplicationHelpOption `yaml:"options,omitempty" toml:"options,omitempty" json:"options,omitempty"`
+}
+
+func NewApplicationHelpProject(name, dir string, options []*ApplicationHelpOption) *ApplicationHelpProject {
+	return &ApplicationHelpProject{
+		Name:    name,
+		Dir:     dir,
+		Options: options,
+	}
+}
+
+// endregion
+
+// region ApplicationHelpOption
+
+type ApplicationHelpOption struct {
+	Name     string                   `yaml:"name" toml:"name" json:"name"`
+	Export   string                   `yaml:"export" toml:"export" json:"export"`
+	Type     string                   `yaml:"type" toml:"type" json:"type"`
+	Usage    string                   `yaml:"usage,omitempty" toml:"usage,omitempty" json:"usage,omitempty"`
+	Default  any                      `yaml:"default,omitempty" toml:"default,omitempty" json:"default,omitempty"`
+	Choices  []*ApplicationHelpChoice `yaml:"choices,omitempty" toml:"choices,omitempty" json:"choices,omitempty"`
+	Required bool                     `yaml:"required" toml:"required" json:"required"`
+}
+
+func NewApplicationHelpOption(name, export, typ, usage string, defaultValue any, choices []*ApplicationHelpChoice, required bool) *ApplicationHelpOption {
+	return &ApplicationHelpOption{
+		Name:     name,
+		Export:   export,
+		Type:     typ,
+		Usage:    usage,
+		Default:  defaultValue,
+		Choices:  choices,
+		Required: required,
+	}
+}
+
+// endregion
+
+// region ApplicationHelpChoice
+
+type ApplicationHelpChoice struct {
+	Value any    `yaml:"value" toml:"value" json:"value"`
+	Usage string `yaml:"usage,omitempty" toml:"usage,omitempty" json:"usage,omitempty"`
+}
+
+func NewApplicationHelpChoice(value any, usage string) *ApplicationHelpChoice {
+	return &ApplicationHelpChoice{
+		Value: value,
+		Usage: usage,
+	}
+}
+
+// endregion
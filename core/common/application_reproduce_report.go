@@ -0,0 +1,55 @@
+package common
+
+import . "github.com/orz-dsh/dsh/core/inspection"
+
+// region ApplicationReproduceReport
+
+type ApplicationReproduceReport struct {
+	Issues []*ApplicationReproduceReportIssue `yaml:"issues,omitempty" toml:"issues,omitempty" json:"issues,omitempty"`
+	Diff   *ApplicationInspectionDiff         `yaml:"diff,omitempty" toml:"diff,omitempty" json:"diff,omitempty"`
+}
+
+func NewApplicationReproduceReport() *ApplicationReproduceReport {
+	return &ApplicationReproduceReport{}
+}
+
+func (r *ApplicationReproduceReport) AddIssue(kind ApplicationReproduceIssueKind, key, reason string) {
+	r.Issues = append(r.Issues, NewApplicationReproduceReportIssue(kind, key, reason))
+}
+
+// IsReproduced checks whether the build is reproduced exactly, there are no issues and no differences between the inspections.
+func (r *ApplicationReproduceReport) IsReproduced() bool {
+	return len(r.Issues) == 0 && (r.Diff == nil || r.Diff.IsEmpty())
+}
+
+// endregion
+
+// region ApplicationReproduceReportIssue
+
+type ApplicationReproduceReportIssue struct {
+	Kind   ApplicationReproduceIssueKind `yaml:"kind" toml:"kind" json:"kind"`
+	Key    string                        `yaml:"key" toml:"key" json:"key"`
+	Reason string                        `yaml:"reason" toml:"reason" json:"reason"`
+}
+
+func NewApplicationReproduceReportIssue(kind ApplicationReproduceIssueKind, key, reason string) *ApplicationReproduceReportIssue {
+	return &ApplicationReproduceReportIssue{
+		Kind:   kind,
+		Key:    key,
+		Reason: reason,
+	}
+}
+
+// endregion
+
+// region ApplicationReproduceIssueKind
+
+type ApplicationReproduceIssueKind string
+
+const (
+	ApplicationReproduceIssueKindOption     ApplicationReproduceIssueKind = "option"
+	ApplicationReproduceIssueKindProject    ApplicationReproduceIssueKind = "project"
+	ApplicationReproduceIssueKindDependency ApplicationReproduceIssueKind = "dependency"
+)
+
+// endregion
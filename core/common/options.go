@@ -1,14 +1,26 @@
 package common
 
-import . "github.com/orz-dsh/dsh/utils"
+import (
+	. "github.com/orz-dsh/dsh/utils"
+	"io"
+	"os"
+)
 
 type WorkspaceCleanOptions struct {
 	ExcludeOutputDir string
+	DryRun           bool
+}
+
+type ApplicationPromptOptions struct {
+	Input    *os.File
+	Output   io.Writer
+	SaveFile string
 }
 
 type MakeArtifactOptions struct {
 	OutputDir         string
 	OutputDirClear    bool
+	OutputCache       bool
 	UseHardLink       bool
 	InspectSerializer Serializer
 }
@@ -16,6 +16,8 @@ type ProjectLink struct {
 	Registry   *ProjectLinkRegistry
 	Dir        *ProjectLinkDir
 	Git        *ProjectLinkGit
+	Archive    *ProjectLinkArchive
+	Oci        *ProjectLinkOci
 }
 
 type ProjectLinkRegistry struct {
@@ -37,6 +39,21 @@ type ProjectLinkGit struct {
 	ParsedRef *ProjectLinkGitRef
 }
 
+// ProjectLinkArchive is the archive link, the url is set for the remote archive and the path is set for the local archive.
+type ProjectLinkArchive struct {
+	Url       string
+	Path      string
+	Sha256    string
+	Format    ArchiveFormat
+	ParsedUrl *url.URL
+}
+
+// ProjectLinkOci is the oci link, the ref is the normalized reference without the `oci://` prefix.
+type ProjectLinkOci struct {
+	Ref       string
+	ParsedRef *OciReference
+}
+
 type ProjectLinkGitRef struct {
 	Raw           string
 	Normalized    string
@@ -46,9 +63,11 @@ type ProjectLinkGitRef struct {
 }
 
 type ProjectLinkTarget struct {
-	Link *ProjectLink
-	Dir  string
-	Git  *ProjectLinkGit
+	Link    *ProjectLink
+	Dir     string
+	Git     *ProjectLinkGit
+	Archive *ProjectLinkArchive
+	Oci     *ProjectLinkOci
 }
 
 type ProjectLinkType string
@@ -57,6 +76,8 @@ const (
 	ProjectLinkTypeRegistry ProjectLinkType = "registry"
 	ProjectLinkTypeDir      ProjectLinkType = "dir"
 	ProjectLinkTypeGit      ProjectLinkType = "git"
+	ProjectLinkTypeArchive  ProjectLinkType = "archive"
+	ProjectLinkTypeOci      ProjectLinkType = "oci"
 )
 
 type ProjectLinkGitRefType string
@@ -64,6 +85,7 @@ type ProjectLinkGitRefType string
 const (
 	ProjectLinkGitRefTypeBranch ProjectLinkGitRefType = "branch"
 	ProjectLinkGitRefTypeTag    ProjectLinkGitRefType = "tag"
+	ProjectLinkGitRefTypeCommit ProjectLinkGitRefType = "commit"
 )
 
 const (
@@ -71,14 +93,24 @@ const (
 	projectLinkPrefixRegistryAbbr = "@"
 	projectLinkPrefixDir          = "dir:"
 	projectLinkPrefixGit          = "git:"
+	projectLinkPrefixArchive      = "archive:"
+	projectLinkPrefixHttp         = "http:"
+	projectLinkPrefixHttps        = "https:"
+	projectLinkPrefixOci          = "oci://"
 	projectLinkGitRefPrefixTag    = "tag/"
 	projectLinkGitRefPrefixBranch = "branch/"
+	projectLinkGitRefPrefixCommit = "commit/"
 	projectLinkRefSeparator       = "#ref="
 	projectLinkRefSeparatorLen    = len(projectLinkRefSeparator)
+	projectLinkSha256Separator    = "#sha256="
 )
 
 var projectLinkRegistryNameCheckRegex = regexp.MustCompile("^[a-z][a-z0-9-]*[a-z0-9]$")
 
+var projectLinkSha256CheckRegex = regexp.MustCompile("^[0-9a-f]{64}$")
+
+var projectLinkGitCommitCheckRegex = regexp.MustCompile("^[0-9a-f]{40}$")
+
 func ParseProjectLink(rawLink string) (*ProjectLink, error) {
 	var content string
 	var matched bool
@@ -90,8 +122,15 @@ func ParseProjectLink(rawLink string) (*ProjectLink, error) {
 		return parseProjectLinkDir(rawLink, content)
 	} else if content, matched = strings.CutPrefix(rawLink, projectLinkPrefixGit); matched {
 		return parseProjectLinkGit(rawLink, content)
+	} else if content, matched = strings.CutPrefix(rawLink, projectLinkPrefixArchive); matched {
+		return parseProjectLinkArchive(rawLink, content)
+	} else if content, matched = strings.CutPrefix(rawLink, projectLinkPrefixOci); matched {
+		return parseProjectLinkOci(rawLink, content)
+	} else if strings.HasPrefix(rawLink, projectLinkPrefixHttp) || strings.HasPrefix(rawLink, projectLinkPrefixHttps) {
+		return parseProjectLinkArchive(rawLink, rawLink)
 	} else {
 		return nil, ErrN("parse project link error",
+			Code(ErrorCodeLinkInvalid),
 			Reason("unsupported link"),
 			KV("rawLink", rawLink),
 		)
@@ -126,12 +165,14 @@ func parseProjectLinkRegistry(rawLink string, content string) (link *ProjectLink
 	}
 	if name == "" {
 		return nil, ErrN("parse project link error",
+			Code(ErrorCodeLinkInvalid),
 			Reason("name is empty"),
 			KV("rawLink", rawLink),
 		)
 	}
 	if !projectLinkRegistryNameCheckRegex.MatchString(name) {
 		return nil, ErrN("parse project link error",
+			Code(ErrorCodeLinkInvalid),
 			Reason("name is invalid"),
 			KV("rawLink", rawLink),
 			KV("name", name),
@@ -139,6 +180,7 @@ func parseProjectLinkRegistry(rawLink string, content string) (link *ProjectLink
 	}
 	if rawRef == "" {
 		return nil, ErrN("parse project link error",
+			Code(ErrorCodeLinkInvalid),
 			Reason("ref is empty"),
 			KV("rawLink", rawLink),
 		)
@@ -146,6 +188,7 @@ func parseProjectLinkRegistry(rawLink string, content string) (link *ProjectLink
 	parsedRef, err := ParseProjectLinkGitRef(rawRef)
 	if err != nil {
 		return nil, ErrW(err, "parse project link error",
+			Code(ErrorCodeLinkInvalid),
 			Reason("parse ref error"),
 			KV("rawLink", rawLink),
 			KV("rawRef", rawRef),
@@ -174,6 +217,7 @@ func parseProjectLinkDir(rawLink string, content string) (link *ProjectLink, err
 	dir := content
 	if dir == "" {
 		return nil, ErrN("parse project link error",
+			Code(ErrorCodeLinkInvalid),
 			Reason("dir is empty"),
 			KV("rawLink", rawLink),
 		)
@@ -181,6 +225,7 @@ func parseProjectLinkDir(rawLink string, content string) (link *ProjectLink, err
 	absPath, err := filepath.Abs(dir)
 	if err != nil {
 		return nil, ErrW(err, "parse project link error",
+			Code(ErrorCodeLinkInvalid),
 			Reason("get abs-path error"),
 			KV("rawLink", rawLink),
 			KV("dir", dir),
@@ -212,12 +257,14 @@ func parseProjectLinkGit(rawLink string, content string) (link *ProjectLink, err
 	}
 	if rawUrl == "" {
 		return nil, ErrN("parse project link error",
+			Code(ErrorCodeLinkInvalid),
 			Reason("url is empty"),
 			KV("rawLink", rawLink),
 		)
 	}
 	if rawRef == "" {
 		return nil, ErrN("parse project link error",
+			Code(ErrorCodeLinkInvalid),
 			Reason("ref is empty"),
 			KV("rawLink", rawLink),
 		)
@@ -225,6 +272,7 @@ func parseProjectLinkGit(rawLink string, content string) (link *ProjectLink, err
 	parsedUrl, err := url.Parse(rawUrl)
 	if err != nil {
 		return nil, ErrW(err, "parse project link error",
+			Code(ErrorCodeLinkInvalid),
 			Reason("parse url error"),
 			KV("rawLink", rawLink),
 			KV("rawUrl", rawUrl),
@@ -233,6 +281,7 @@ func parseProjectLinkGit(rawLink string, content string) (link *ProjectLink, err
 	parsedRef, err := ParseProjectLinkGitRef(rawRef)
 	if err != nil {
 		return nil, ErrW(err, "parse project link error",
+			Code(ErrorCodeLinkInvalid),
 			Reason("parse ref error"),
 			KV("rawLink", rawLink),
 			KV("rawRef", rawRef),
@@ -253,9 +302,131 @@ func parseProjectLinkGit(rawLink string, content string) (link *ProjectLink, err
 	return link, nil
 }
 
+// parseProjectLinkArchive parses the archive link such as `archive:./lib.tar.gz`, `archive:https://host/lib.zip#sha256=<hex>`
+// and `https://host/lib.zip#sha256=<hex>`, the sha256 is required for the remote archive.
+func parseProjectLinkArchive(rawLink string, content string) (link *ProjectLink, err error) {
+	location, sha256 := content, ""
+	if sha256Index := strings.Index(location, projectLinkSha256Separator); sha256Index >= 0 {
+		sha256 = strings.ToLower(location[sha256Index+len(projectLinkSha256Separator):])
+		location = location[:sha256Index]
+		if !projectLinkSha256CheckRegex.MatchString(sha256) {
+			return nil, ErrN("parse project link error",
+				Code(ErrorCodeLinkInvalid),
+				Reason("sha256 is invalid"),
+				KV("rawLink", rawLink),
+				KV("sha256", sha256),
+			)
+		}
+	}
+	if location == "" {
+		return nil, ErrN("parse project link error",
+			Code(ErrorCodeLinkInvalid),
+			Reason("archive is empty"),
+			KV("rawLink", rawLink),
+		)
+	}
+
+	archive := &ProjectLinkArchive{
+		Sha256: sha256,
+	}
+	if strings.HasPrefix(location, projectLinkPrefixHttp) || strings.HasPrefix(location, projectLinkPrefixHttps) {
+		parsedUrl, err := url.Parse(location)
+		if err != nil {
+			return nil, ErrW(err, "parse project link error",
+				Code(ErrorCodeLinkInvalid),
+				Reason("parse url error"),
+				KV("rawLink", rawLink),
+				KV("rawUrl", location),
+			)
+		}
+		if parsedUrl.Host == "" {
+			return nil, ErrN("parse project link error",
+				Code(ErrorCodeLinkInvalid),
+				Reason("url host is empty"),
+				KV("rawLink", rawLink),
+			)
+		}
+		if sha256 == "" {
+			return nil, ErrN("parse project link error",
+				Code(ErrorCodeLinkInvalid),
+				Reason("sha256 is required for remote archive"),
+				KV("rawLink", rawLink),
+			)
+		}
+		archive.Url = location
+		archive.ParsedUrl = parsedUrl
+		archive.Format = GetArchiveFormat(parsedUrl.Path)
+	} else {
+		absPath, err := filepath.Abs(location)
+		if err != nil {
+			return nil, ErrW(err, "parse project link error",
+				Code(ErrorCodeLinkInvalid),
+				Reason("get abs-path error"),
+				KV("rawLink", rawLink),
+				KV("path", location),
+			)
+		}
+		archive.Path = absPath
+		archive.Format = GetArchiveFormat(absPath)
+	}
+	if archive.Format == "" {
+		return nil, ErrN("parse project link error",
+			Code(ErrorCodeLinkInvalid),
+			Reason("archive format not supported"),
+			KV("rawLink", rawLink),
+		)
+	}
+
+	normalizeLink := projectLinkPrefixArchive + archive.Url + archive.Path
+	if sha256 != "" {
+		normalizeLink += projectLinkSha256Separator + sha256
+	}
+	link = &ProjectLink{
+		Raw:        rawLink,
+		Normalized: normalizeLink,
+		Type:       ProjectLinkTypeArchive,
+		Archive:    archive,
+	}
+	return link, nil
+}
+
+// parseProjectLinkOci parses the oci link such as `oci://ghcr.io/org/repo:tag` and `oci://ghcr.io/org/repo@sha256:<hex>`.
+func parseProjectLinkOci(rawLink string, content string) (link *ProjectLink, err error) {
+	if content == "" {
+		return nil, ErrN("parse project link error",
+			Code(ErrorCodeLinkInvalid),
+			Reason("ref is empty"),
+			KV("rawLink", rawLink),
+		)
+	}
+	parsedRef, err := ParseOciReference(content)
+	if err != nil {
+		return nil, ErrW(err, "parse project link error",
+			Code(ErrorCodeLinkInvalid),
+			Reason("parse ref error"),
+			KV("rawLink", rawLink),
+		)
+	}
+	link = &ProjectLink{
+		Raw:        rawLink,
+		Normalized: projectLinkPrefixOci + parsedRef.String(),
+		Type:       ProjectLinkTypeOci,
+		Oci:        NewProjectLinkOci(parsedRef),
+	}
+	return link, nil
+}
+
+func NewProjectLinkOci(parsedRef *OciReference) *ProjectLinkOci {
+	return &ProjectLinkOci{
+		Ref:       parsedRef.String(),
+		ParsedRef: parsedRef,
+	}
+}
+
 func ParseProjectLinkGitRef(rawRef string) (ref *ProjectLinkGitRef, err error) {
 	if rawRef == "" {
 		return nil, ErrN("parse project link git ref error",
+			Code(ErrorCodeLinkInvalid),
 			Reason("ref is empty"),
 		)
 	}
@@ -264,6 +435,7 @@ func ParseProjectLinkGitRef(rawRef string) (ref *ProjectLinkGitRef, err error) {
 	if name, matched = strings.CutPrefix(rawRef, projectLinkGitRefPrefixTag); matched {
 		if name == "" {
 			return nil, ErrN("parse project link git ref error",
+				Code(ErrorCodeLinkInvalid),
 				Reason("tag name is empty"),
 				KV("rawRef", rawRef),
 			)
@@ -275,9 +447,26 @@ func ParseProjectLinkGitRef(rawRef string) (ref *ProjectLinkGitRef, err error) {
 			Name:          name,
 			ReferenceName: plumbing.NewTagReferenceName(name),
 		}
+	} else if name, matched = strings.CutPrefix(rawRef, projectLinkGitRefPrefixCommit); matched {
+		// the commit ref pins the project to a full commit hash, it has no reference name
+		name = strings.ToLower(name)
+		if !projectLinkGitCommitCheckRegex.MatchString(name) {
+			return nil, ErrN("parse project link git ref error",
+				Code(ErrorCodeLinkInvalid),
+				Reason("commit hash is invalid"),
+				KV("rawRef", rawRef),
+			)
+		}
+		ref = &ProjectLinkGitRef{
+			Raw:        rawRef,
+			Normalized: projectLinkGitRefPrefixCommit + name,
+			Type:       ProjectLinkGitRefTypeCommit,
+			Name:       name,
+		}
 	} else if name, matched = strings.CutPrefix(rawRef, projectLinkGitRefPrefixBranch); matched {
 		if name == "" {
 			return nil, ErrN("parse project link git ref error",
+				Code(ErrorCodeLinkInvalid),
 				Reason("branch name is empty"),
 				KV("rawRef", rawRef),
 			)
@@ -217,4 +217,103 @@ func TestParseProjectLinkGit(t *testing.T) {
 			KV("link", link),
 		))
 	}
+
+	link, err = ParseProjectLink("git:https://github.com/group/project.git#ref=commit/0123abc")
+	if err != nil {
+		t.Log("link git commit error", err)
+	} else {
+		Impossible()
+	}
+
+	link, err = ParseProjectLink("git:https://github.com/group/project.git#ref=commit/0123456789ABCDEF0123456789abcdef01234567")
+	if err != nil {
+		t.Fatal(err)
+	} else if link.Git.Ref != "commit/0123456789abcdef0123456789abcdef01234567" || link.Git.ParsedRef.Type != ProjectLinkGitRefTypeCommit {
+		t.Fatal("link git commit", link.Git.Ref, link.Git.ParsedRef.Type)
+	}
+}
+
+func TestParseProjectLinkArchive(t *testing.T) {
+	sha256 := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	link, err := ParseProjectLink("https://example.com/lib.tar.gz")
+	if err != nil {
+		t.Log("link archive without sha256 error", err)
+	} else {
+		Impossible()
+	}
+
+	link, err = ParseProjectLink("archive:./lib.rar")
+	if err != nil {
+		t.Log("link archive format error", err)
+	} else {
+		Impossible()
+	}
+
+	link, err = ParseProjectLink("archive:./lib.zip#sha256=abc")
+	if err != nil {
+		t.Log("link archive sha256 error", err)
+	} else {
+		Impossible()
+	}
+
+	link, err = ParseProjectLink("https://example.com/lib-1.0.0.tar.gz#sha256=" + sha256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Type != ProjectLinkTypeArchive || link.Archive.Format != ArchiveFormatTarGz || link.Normalized != "archive:https://example.com/lib-1.0.0.tar.gz#sha256="+sha256 {
+		t.Fatal(link.Normalized)
+	}
+	t.Log(DescN("parse link archive remote",
+		KV("link", link),
+	))
+
+	link, err = ParseProjectLink("archive:./lib.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Archive.Path == "" || link.Archive.Url != "" || link.Archive.Format != ArchiveFormatZip {
+		t.Fatal(link.Archive)
+	}
+	t.Log(DescN("parse link archive local",
+		KV("link", link),
+	))
+}
+
+func TestParseProjectLinkOci(t *testing.T) {
+	digest := "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	link, err := ParseProjectLink("oci://ghcr.io/org/lib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Type != ProjectLinkTypeOci || link.Normalized != "oci://ghcr.io/org/lib:latest" || link.Oci.ParsedRef.Repository != "org/lib" {
+		t.Fatal(link.Normalized)
+	}
+	t.Log(DescN("parse link oci tag",
+		KV("link", link),
+	))
+
+	link, err = ParseProjectLink("oci://localhost:5000/lib@" + digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Oci.ParsedRef.Registry != "localhost:5000" || link.Oci.ParsedRef.Tag != "" || link.Oci.ParsedRef.Digest != digest {
+		t.Fatal(link.Oci.ParsedRef)
+	}
+	t.Log(DescN("parse link oci digest",
+		KV("link", link),
+	))
+
+	link, err = ParseProjectLink("oci://ghcr.io/org/lib@sha256:abc")
+	if err != nil {
+		t.Log("link oci digest error", err)
+	} else {
+		Impossible()
+	}
+
+	link, err = ParseProjectLink("oci://")
+	if err != nil {
+		t.Log("link oci empty error", err)
+	} else {
+		Impossible()
+	}
 }
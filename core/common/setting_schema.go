@@ -0,0 +1,23 @@
+package common
+
+// region SettingSchemaKind
+
+type SettingSchemaKind string
+
+const (
+	SettingSchemaKindProject        SettingSchemaKind = "project"
+	SettingSchemaKindProjectInclude SettingSchemaKind = "project-include"
+	SettingSchemaKindProfile        SettingSchemaKind = "profile"
+	SettingSchemaKindWorkspace      SettingSchemaKind = "workspace"
+	SettingSchemaKindConfig         SettingSchemaKind = "config"
+)
+
+var SettingSchemaKinds = []SettingSchemaKind{
+	SettingSchemaKindProject,
+	SettingSchemaKindProjectInclude,
+	SettingSchemaKindProfile,
+	SettingSchemaKindWorkspace,
+	SettingSchemaKindConfig,
+}
+
+// endregion
@@ -0,0 +1,76 @@
+package common
+
+// region WorkspaceCleanReport
+
+type WorkspaceCleanReport struct {
+	DryRun  bool                        `yaml:"dryRun" toml:"dryRun" json:"dryRun"`
+	Items   []*WorkspaceCleanReportItem `yaml:"items,omitempty" toml:"items,omitempty" json:"items,omitempty"`
+	Skipped []*WorkspaceCleanReportItem `yaml:"skipped,omitempty" toml:"skipped,omitempty" json:"skipped,omitempty"`
+}
+
+func NewWorkspaceCleanReport(dryRun bool) *WorkspaceCleanReport {
+	return &WorkspaceCleanReport{
+		DryRun: dryRun,
+	}
+}
+
+func (r *WorkspaceCleanReport) GetSize() (size int64) {
+	for i := 0; i < len(r.Items); i++ {
+		size += r.Items[i].Size
+	}
+	return size
+}
+
+// endregion
+
+// region WorkspaceCleanReportItem
+
+type WorkspaceCleanReportItem struct {
+	Kind        WorkspaceCleanItemKind   `yaml:"kind" toml:"kind" json:"kind"`
+	Path        string                   `yaml:"path" toml:"path" json:"path"`
+	ProjectName string                   `yaml:"projectName,omitempty" toml:"projectName,omitempty" json:"projectName,omitempty"`
+	Size        int64                    `yaml:"size" toml:"size" json:"size"`
+	Reason      WorkspaceCleanItemReason `yaml:"reason" toml:"reason" json:"reason"`
+	Removed     bool                     `yaml:"removed" toml:"removed" json:"removed"`
+}
+
+func NewWorkspaceCleanReportItem(kind WorkspaceCleanItemKind, path, projectName string, size int64, reason WorkspaceCleanItemReason) *WorkspaceCleanReportItem {
+	return &WorkspaceCleanReportItem{
+		Kind:        kind,
+		Path:        path,
+		ProjectName: projectName,
+		Size:        size,
+		Reason:      reason,
+	}
+}
+
+// endregion
+
+// region WorkspaceCleanItemKind
+
+type WorkspaceCleanItemKind string
+
+const (
+	WorkspaceCleanItemKindOutput  WorkspaceCleanItemKind = "output"
+	WorkspaceCleanItemKindCache   WorkspaceCleanItemKind = "cache"
+	WorkspaceCleanItemKindProject WorkspaceCleanItemKind = "project"
+	WorkspaceCleanItemKindArchive WorkspaceCleanItemKind = "archive"
+	WorkspaceCleanItemKindOci     WorkspaceCleanItemKind = "oci"
+)
+
+// endregion
+
+// region WorkspaceCleanItemReason
+
+type WorkspaceCleanItemReason string
+
+const (
+	WorkspaceCleanItemReasonCount    WorkspaceCleanItemReason = "count"
+	WorkspaceCleanItemReasonExpires  WorkspaceCleanItemReason = "expires"
+	WorkspaceCleanItemReasonSize     WorkspaceCleanItemReason = "size"
+	WorkspaceCleanItemReasonUnused   WorkspaceCleanItemReason = "unused"
+	WorkspaceCleanItemReasonLocked   WorkspaceCleanItemReason = "locked"
+	WorkspaceCleanItemReasonExcluded WorkspaceCleanItemReason = "excluded"
+)
+
+// endregion
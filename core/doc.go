@@ -0,0 +1,24 @@
+// Package core is the library entry of dsh, the typical usage is:
+//
+//	environment, err := core.NewEnvironment(logger, nil)
+//	workspace, err := core.NewWorkspace(environment, "")
+//	app, err := workspace.NewAppBuilder().
+//		AddProfile(core.WithProfileSource("cli")).
+//		SetArgumentSetting().
+//		AddItem("env", "prod", "").
+//		CommitArgumentSetting().
+//		CommitProfileSetting().
+//		Build("dir:./app")
+//	artifact, err := app.MakeArtifact(common.MakeArtifactOptions{OutputDir: "./output"})
+//
+// The loaded projects, options, dependencies, resources and targets are accessible by the typed accessors of Application,
+// such as GetProjects, GetOptions, GetDependencyGraph and GetTargets, and the whole state is accessible by Inspect.
+//
+// # Compatibility
+//
+// The public API follows semantic versioning of the module. It is the exported identifiers of the packages
+// core, core/builder, core/common and core/inspection, and the fields of the inspections with their serialized names.
+// Within a major version, exported identifiers are not removed or changed incompatibly, new fields, methods
+// and functional options may be added, and replaced identifiers are marked as deprecated and kept until the next major version.
+// The package core/internal and its sub packages are not part of the public API, and can be changed in any version.
+package core
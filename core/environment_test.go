@@ -0,0 +1,73 @@
+package core
+
+import (
+	. "github.com/orz-dsh/dsh/core/common"
+	. "github.com/orz-dsh/dsh/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnvironmentLogSetting(t *testing.T) {
+	logger := NewLogger(LogLevelInfo)
+	_, err := NewEnvironment(logger, map[string]string{
+		"log_level":  "warn,git=debug",
+		"log_format": "json",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logger.Level != LogLevelWarn || logger.GetFormat() != LogFormatJson {
+		t.Fatal("log setting not applied", logger.Level, logger.GetFormat())
+	}
+	if !logger.Named(LoggerNameGit).IsDebugEnabled() || logger.Named(LoggerNameOption).IsInfoEnabled() {
+		t.Fatal("named log level not applied")
+	}
+
+	if _, err = NewEnvironment(NewLogger(LogLevelInfo), map[string]string{"log_format": "xml"}); err == nil {
+		t.Fatal("invalid log format should fail")
+	}
+}
+
+func TestEnvironmentInspectionSecretVariable(t *testing.T) {
+	// the variables out of and with the dsh prefix are both inspected
+	t.Setenv("TEST_SECRET_PASS", "s3cr3t-value")
+	t.Setenv("DSH_TEST_SECRET_TOKEN", "s3cr3t-token")
+	environment, err := NewEnvironment(NewLogger(LogLevelError), map[string]string{
+		"argument_item_pass":  "secret:env:TEST_SECRET_PASS",
+		"argument_item_token": "secret:env:DSH_TEST_SECRET_TOKEN",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspace, err := NewWorkspace(environment, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	appDir := t.TempDir()
+	if err = os.WriteFile(filepath.Join(appDir, "project.yml"), []byte("name: app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	app, err := workspace.NewAppBuilder().Build("dir:" + appDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	artifact, err := app.MakeArtifact(MakeArtifactOptions{InspectSerializer: YamlSerializerDefault})
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := filepath.Glob(filepath.Join(artifact.GetOutputDir(), "@inspection", "*"))
+	if err != nil || len(files) == 0 {
+		t.Fatal("inspection files", files, err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "s3cr3t-value") || strings.Contains(string(data), "s3cr3t-token") {
+			t.Fatal("secret variable is not masked", file)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+package inspection
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// region base
+
+type ApplicationInspectionDiffKind string
+
+const (
+	ApplicationInspectionDiffKindOption  ApplicationInspectionDiffKind = "option"
+	ApplicationInspectionDiffKindConfig  ApplicationInspectionDiffKind = "config"
+	ApplicationInspectionDiffKindProject ApplicationInspectionDiffKind = "project"
+	ApplicationInspectionDiffKindGitRef  ApplicationInspectionDiffKind = "gitRef"
+	ApplicationInspectionDiffKindTarget  ApplicationInspectionDiffKind = "target"
+)
+
+var applicationInspectionDiffKindOrders = map[ApplicationInspectionDiffKind]int{
+	ApplicationInspectionDiffKindOption:  1,
+	ApplicationInspectionDiffKindConfig:  2,
+	ApplicationInspectionDiffKindProject: 3,
+	ApplicationInspectionDiffKindGitRef:  4,
+	ApplicationInspectionDiffKindTarget:  5,
+}
+
+type ApplicationInspectionDiffAction string
+
+const (
+	ApplicationInspectionDiffActionAdded   ApplicationInspectionDiffAction = "added"
+	ApplicationInspectionDiffActionRemoved ApplicationInspectionDiffAction = "removed"
+	ApplicationInspectionDiffActionChanged ApplicationInspectionDiffAction = "changed"
+)
+
+// endregion
+
+// region ApplicationInspectionDiff
+
+// ApplicationInspectionDiff contains the semantic differences between two application inspections:
+//   - option: the option results of the projects with their sources, the key is `<project>.<option>`;
+//   - config: the config values with their traces relative to the project dirs, the key is the config path such as `db.port`;
+//   - project: the addition and dependency projects, the value is the project dir;
+//   - gitRef: the git refs of the dependencies with the commits like `main@<commit>`, the key is `<project>:<gitUrl>`;
+//   - target: the target files of the projects, the value is the resource file relative to the project dir.
+type ApplicationInspectionDiff struct {
+	Items []*ApplicationInspectionDiffItem `yaml:"items,omitempty" toml:"items,omitempty" json:"items,omitempty"`
+}
+
+func NewApplicationInspectionDiff(items []*ApplicationInspectionDiffItem) *ApplicationInspectionDiff {
+	return &ApplicationInspectionDiff{
+		Items: items,
+	}
+}
+
+// DiffApplicationInspection compares the old inspection with the new inspection, the values are compared
+// after json normalization, so a live inspection can be compared with an inspection loaded from the disk.
+func DiffApplicationInspection(old, new *ApplicationInspection) *ApplicationInspectionDiff {
+	differ := &applicationInspectionDiffer{}
+	differ.diffOption(old.Option, new.Option)
+	differ.diffConfig(old, new)
+	differ.diffProject(old, new)
+	differ.diffGitRef(old, new)
+	differ.diffTarget(old, new)
+	slices.SortStableFunc(differ.items, func(l, r *ApplicationInspectionDiffItem) int {
+		if n := applicationInspectionDiffKindOrders[l.Kind] - applicationInspectionDiffKindOrders[r.Kind]; n != 0 {
+			return n
+		}
+		return strings.Compare(l.Key, r.Key)
+	})
+	return NewApplicationInspectionDiff(differ.items)
+}
+
+func (d *ApplicationInspectionDiff) IsEmpty() bool {
+	return len(d.Items) == 0
+}
+
+func (d *ApplicationInspectionDiff) GetItems(kind ApplicationInspectionDiffKind) []*ApplicationInspectionDiffItem {
+	var items []*ApplicationInspectionDiffItem
+	for i := 0; i < len(d.Items); i++ {
+		if d.Items[i].Kind == kind {
+			items = append(items, d.Items[i])
+		}
+	}
+	return items
+}
+
+// endregion
+
+// region ApplicationInspectionDiffItem
+
+type ApplicationInspectionDiffItem struct {
+	Kind      ApplicationInspectionDiffKind   `yaml:"kind" toml:"kind" json:"kind"`
+	Action    ApplicationInspectionDiffAction `yaml:"action" toml:"action" json:"action"`
+	Key       string                          `yaml:"key" toml:"key" json:"key"`
+	OldValue  any                             `yaml:"oldValue,omitempty" toml:"oldValue,omitempty" json:"oldValue,omitempty"`
+	NewValue  any                             `yaml:"newValue,omitempty" toml:"newValue,omitempty" json:"newValue,omitempty"`
+	OldSource any                             `yaml:"oldSource,omitempty" toml:"oldSource,omitempty" json:"oldSource,omitempty"`
+	NewSource any                             `yaml:"newSource,omitempty" toml:"newSource,omitempty" json:"newSource,omitempty"`
+}
+
+func NewApplicationInspectionDiffItem(kind ApplicationInspectionDiffKind, action ApplicationInspectionDiffAction, key string, oldValue, newValue, oldSource, newSource any) *ApplicationInspectionDiffItem {
+	return &ApplicationInspectionDiffItem{
+		Kind:      kind,
+		Action:    action,
+		Key:       key,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		OldSource: oldSource,
+		NewSource: newSource,
+	}
+}
+
+// endregion
+
+// region applicationInspectionDiffer
+
+type applicationInspectionDiffer struct {
+	items []*ApplicationInspectionDiffItem
+}
+
+type applicationInspectionDiffEntry struct {
+	value  any
+	source any
+}
+
+// compare adds the items of the entries added, removed or changed, an entry is changed if the value or the source is changed.
+func (d *applicationInspectionDiffer) compare(kind ApplicationInspectionDiffKind, olds, news map[string]*applicationInspectionDiffEntry) {
+	for key, old := range olds {
+		if n, exist := news[key]; !exist {
+			d.items = append(d.items, NewApplicationInspectionDiffItem(kind, ApplicationInspectionDiffActionRemoved, key, old.value, nil, old.source, nil))
+		} else if !isApplicationInspectionValueEqual(old.value, n.value) || !isApplicationInspectionValueEqual(old.source, n.source) {
+			d.items = append(d.items, NewApplicationInspectionDiffItem(kind, ApplicationInspectionDiffActionChanged, key, old.value, n.value, old.source, n.source))
+		}
+	}
+	for key, n := range news {
+		if _, exist := olds[key]; !exist {
+			d.items = append(d.items, NewApplicationInspectionDiffItem(kind, ApplicationInspectionDiffActionAdded, key, nil, n.value, nil, n.source))
+		}
+	}
+}
+
+func (d *applicationInspectionDiffer) diffOption(old, new *ApplicationOptionInspection) {
+	d.compare(ApplicationInspectionDiffKindOption, getApplicationInspectionOptionEntries(old), getApplicationInspectionOptionEntries(new))
+}
+
+func (d *applicationInspectionDiffer) diffConfig(old, new *ApplicationInspection) {
+	d.compare(ApplicationInspectionDiffKindConfig, getApplicationInspectionConfigEntries(old), getApplicationInspectionConfigEntries(new))
+}
+
+func (d *applicationInspectionDiffer) diffProject(old, new *ApplicationInspection) {
+	d.compare(ApplicationInspectionDiffKindProject, getApplicationInspectionProjectEntries(old), getApplicationInspectionProjectEntries(new))
+}
+
+func (d *applicationInspectionDiffer) diffGitRef(old, new *ApplicationInspection) {
+	d.compare(ApplicationInspectionDiffKindGitRef, getApplicationInspectionGitRefEntries(old), getApplicationInspectionGitRefEntries(new))
+}
+
+func (d *applicationInspectionDiffer) diffTarget(old, new *ApplicationInspection) {
+	d.compare(ApplicationInspectionDiffKindTarget, getApplicationInspectionTargetEntries(old), getApplicationInspectionTargetEntries(new))
+}
+
+func getApplicationInspectionOptionEntries(option *ApplicationOptionInspection) map[string]*applicationInspectionDiffEntry {
+	entries := map[string]*applicationInspectionDiffEntry{}
+	if option == nil || option.Result == nil {
+		return entries
+	}
+	for projectName, items := range option.Result.Items {
+		for optionName, item := range items {
+			entries[projectName+"."+optionName] = &applicationInspectionDiffEntry{value: item.Value, source: item.Source}
+		}
+	}
+	return entries
+}
+
+// getApplicationInspectionConfigEntries adds the leaf values of the config, the sources are relative to the project
+// dirs, so the same config loaded from another workspace or checkout is not changed.
+func getApplicationInspectionConfigEntries(inspection *ApplicationInspection) map[string]*applicationInspectionDiffEntry {
+	entries := map[string]*applicationInspectionDiffEntry{}
+	if inspection.Config == nil {
+		return entries
+	}
+	walkApplicationInspectionConfig("", inspection.Config.Value, inspection.Config.Trace, entries)
+	projects := getApplicationInspectionProjectsByDir(inspection)
+	for _, entry := range entries {
+		entry.source = relativizeApplicationInspectionConfigTrace(normalizeApplicationInspectionValue(entry.source), projects)
+	}
+	return entries
+}
+
+// getApplicationInspectionProjectsByDir returns the projects sorted by the dir length in descending order,
+// so a nested project is matched before its parent.
+func getApplicationInspectionProjectsByDir(inspection *ApplicationInspection) []*ProjectInspection {
+	projects := getApplicationInspectionProjects(inspection)
+	slices.SortStableFunc(projects, func(l, r *ProjectInspection) int {
+		return len(r.Dir) - len(l.Dir)
+	})
+	return projects
+}
+
+// relativizeApplicationInspectionConfigTrace replaces the project dirs in the trace labels with the project names,
+// such as `encrypted:/path/to/app/config.yml` to `encrypted:app:config.yml`.
+func relativizeApplicationInspectionConfigTrace(trace any, projects []*ProjectInspection) any {
+	switch value := trace.(type) {
+	case string:
+		labels := strings.Split(value, " > ")
+		for i := 0; i < len(labels); i++ {
+			labels[i] = relativizeApplicationInspectionPath(labels[i], projects)
+		}
+		return strings.Join(labels, " > ")
+	case []any:
+		result := make([]any, len(value))
+		for i := 0; i < len(value); i++ {
+			result[i] = relativizeApplicationInspectionConfigTrace(value[i], projects)
+		}
+		return result
+	case map[string]any:
+		result := make(map[string]any, len(value))
+		for k, v := range value {
+			result[k] = relativizeApplicationInspectionConfigTrace(v, projects)
+		}
+		return result
+	default:
+		return trace
+	}
+}
+
+// relativizeApplicationInspectionPath replaces the first project dir in the label with the project name.
+func relativizeApplicationInspectionPath(label string, projects []*ProjectInspection) string {
+	for i := 0; i < len(projects); i++ {
+		project := projects[i]
+		if project.Dir == "" {
+			continue
+		}
+		prefix := strings.TrimSuffix(project.Dir, string(filepath.Separator)) + string(filepath.Separator)
+		if index := strings.Index(label, prefix); index >= 0 {
+			return label[:index] + project.Name + ":" + filepath.ToSlash(label[index+len(prefix):])
+		}
+	}
+	return label
+}
+
+// walkApplicationInspectionConfig adds the leaf values of the config, the lists are compared as a whole,
+// and the trace of a value is the trace node at the same path, or the nearest label of its parents.
+func walkApplicationInspectionConfig(path string, value any, trace any, entries map[string]*applicationInspectionDiffEntry) {
+	if valueMap, ok := value.(map[string]any); ok && len(valueMap) > 0 {
+		for key, item := range valueMap {
+			childTrace := trace
+			if traceMap, ok := trace.(map[string]any); ok {
+				childTrace = traceMap[key]
+			}
+			walkApplicationInspectionConfig(joinApplicationInspectionConfigPath(path, key), item, childTrace, entries)
+		}
+		return
+	}
+	if path != "" {
+		entries[path] = &applicationInspectionDiffEntry{value: value, source: trace}
+	}
+}
+
+func joinApplicationInspectionConfigPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func getApplicationInspectionProjects(inspection *ApplicationInspection) []*ProjectInspection {
+	var projects []*ProjectInspection
+	if inspection.MainProject != nil {
+		projects = append(projects, inspection.MainProject)
+	}
+	projects = append(projects, inspection.AdditionProjects...)
+	projects = append(projects, inspection.DependencyProjects...)
+	return projects
+}
+
+func getApplicationInspectionProjectEntries(inspection *ApplicationInspection) map[string]*applicationInspectionDiffEntry {
+	entries := map[string]*applicationInspectionDiffEntry{}
+	for i := 0; i < len(inspection.AdditionProjects); i++ {
+		project := inspection.AdditionProjects[i]
+		entries[project.Name] = &applicationInspectionDiffEntry{value: project.Dir, source: "addition"}
+	}
+	for i := 0; i < len(inspection.DependencyProjects); i++ {
+		project := inspection.DependencyProjects[i]
+		entries[project.Name] = &applicationInspectionDiffEntry{value: project.Dir, source: "dependency"}
+	}
+	return entries
+}
+
+func getApplicationInspectionGitRefEntries(inspection *ApplicationInspection) map[string]*applicationInspectionDiffEntry {
+	entries := map[string]*applicationInspectionDiffEntry{}
+	projects := getApplicationInspectionProjects(inspection)
+	for i := 0; i < len(projects); i++ {
+		project := projects[i]
+		if project.Dependency == nil {
+			continue
+		}
+		for j := 0; j < len(project.Dependency.Items); j++ {
+			item := project.Dependency.Items[j]
+			if item.GitUrl == "" {
+				continue
+			}
+			value := item.GitRef
+			if item.GitCommit != "" {
+				value += "@" + item.GitCommit
+			}
+			entries[project.Name+":"+item.GitUrl] = &applicationInspectionDiffEntry{value: value, source: item.Link}
+		}
+	}
+	return entries
+}
+
+func getApplicationInspectionTargetEntries(inspection *ApplicationInspection) map[string]*applicationInspectionDiffEntry {
+	entries := map[string]*applicationInspectionDiffEntry{}
+	projects := getApplicationInspectionProjects(inspection)
+	projectsByDir := getApplicationInspectionProjectsByDir(inspection)
+	for i := 0; i < len(projects); i++ {
+		project := projects[i]
+		if project.Resource == nil {
+			continue
+		}
+		for j := 0; j < len(project.Resource.TemplateItems); j++ {
+			item := project.Resource.TemplateItems[j]
+			entries[item.Target] = &applicationInspectionDiffEntry{value: relativizeApplicationInspectionPath(item.File, projectsByDir), source: project.Name}
+		}
+		for j := 0; j < len(project.Resource.PlainItems); j++ {
+			item := project.Resource.PlainItems[j]
+			entries[item.Target] = &applicationInspectionDiffEntry{value: relativizeApplicationInspectionPath(item.File, projectsByDir), source: project.Name}
+		}
+	}
+	return entries
+}
+
+// isApplicationInspectionValueEqual compares the values after json normalization, such as the integers
+// loaded from the yaml and the float numbers from the json, and the masked secrets.
+func isApplicationInspectionValueEqual(l, r any) bool {
+	return reflect.DeepEqual(normalizeApplicationInspectionValue(l), normalizeApplicationInspectionValue(r))
+}
+
+func normalizeApplicationInspectionValue(value any) any {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	var result any
+	if err = json.Unmarshal(data, &result); err != nil {
+		return strconv.Quote(string(data))
+	}
+	return result
+}
+
+// endregion
@@ -0,0 +1,120 @@
+package inspection
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func newApplicationInspectionForDiff(root string, gitRef string, port any) *ApplicationInspection {
+	appDir := filepath.Join(root, "app")
+	libDir := filepath.Join(root, "workspace", "project", "lib")
+	config := NewApplicationConfigInspection(
+		map[string]any{
+			"db": map[string]any{"port": port, "password": "***"},
+		},
+		map[string]any{
+			"db": map[string]any{
+				"port":     filepath.Join(libDir, "config.yml") + " > " + filepath.Join(appDir, "config", "app.yml"),
+				"password": "encrypted:" + filepath.Join(appDir, "secret.yml"),
+			},
+		},
+	)
+	option := NewApplicationOptionInspection(nil, nil, nil, NewApplicationOptionResultInspection(map[string]map[string]*ApplicationOptionResultItemInspection{
+		"app": {"env": NewApplicationOptionResultItemInspection("dev", "assign")},
+	}))
+	dependency := NewProjectDependencyInspection([]*ProjectDependencyItemInspection{
+		NewProjectDependencyItemInspection("git:https://example.com/lib.git#ref="+gitRef, libDir, "https://example.com/lib.git", gitRef, "abc"),
+	})
+	resource := NewProjectResourceInspection(nil, []*ProjectResourceTemplateItemInspection{
+		NewProjectResourceTemplateItemInspection(filepath.Join(appDir, "app.sh.tpl"), "app.sh"),
+	}, nil, nil)
+	mainProject := NewProjectInspection("app", appDir, nil, dependency, resource, nil)
+	libProject := NewProjectInspection("lib", libDir, nil, nil, nil, nil)
+	return NewApplicationInspection(nil, nil, nil, nil, option, config, mainProject, nil, []*ProjectInspection{libProject})
+}
+
+func TestDiffApplicationInspection(t *testing.T) {
+	old := newApplicationInspectionForDiff("/root1", "main", 5432)
+	new := newApplicationInspectionForDiff("/root1", "main", 5432.0)
+	if diff := DiffApplicationInspection(old, new); !diff.IsEmpty() {
+		t.Fatal("json normalized values should be equal", diff.Items)
+	}
+
+	new = newApplicationInspectionForDiff("/root1", "dev", 5433)
+	new.Option.Result.Items["app"]["env"] = NewApplicationOptionResultItemInspection("prod", "assign")
+	new.MainProject.Resource.TemplateItems = nil
+	new.DependencyProjects = append(new.DependencyProjects, NewProjectInspection("extra", "/root1/extra", nil, nil, nil, nil))
+	diff := DiffApplicationInspection(old, new)
+
+	expects := []struct {
+		kind   ApplicationInspectionDiffKind
+		action ApplicationInspectionDiffAction
+		key    string
+	}{
+		{ApplicationInspectionDiffKindOption, ApplicationInspectionDiffActionChanged, "app.env"},
+		{ApplicationInspectionDiffKindConfig, ApplicationInspectionDiffActionChanged, "db.port"},
+		{ApplicationInspectionDiffKindProject, ApplicationInspectionDiffActionAdded, "extra"},
+		{ApplicationInspectionDiffKindGitRef, ApplicationInspectionDiffActionChanged, "app:https://example.com/lib.git"},
+		{ApplicationInspectionDiffKindTarget, ApplicationInspectionDiffActionRemoved, "app.sh"},
+	}
+	if len(diff.Items) != len(expects) {
+		t.Fatal("diff items", diff.Items)
+	}
+	for i, expect := range expects {
+		item := diff.Items[i]
+		if item.Kind != expect.kind || item.Action != expect.action || item.Key != expect.key {
+			t.Fatal("diff item", i, item)
+		}
+	}
+	if len(diff.GetItems(ApplicationInspectionDiffKindGitRef)) != 1 {
+		t.Fatal("git ref items", diff.Items)
+	}
+	if item := diff.GetItems(ApplicationInspectionDiffKindGitRef)[0]; item.OldValue != "main@abc" || item.NewValue != "dev@abc" {
+		t.Fatal("git ref item", item)
+	}
+}
+
+func TestDiffApplicationInspectionConfigSource(t *testing.T) {
+	old := newApplicationInspectionForDiff("/root1", "main", 5432)
+	new := newApplicationInspectionForDiff("/root2", "main", 5432)
+	new.MainProject.Dir = "/root2/app/"
+	diff := DiffApplicationInspection(old, new)
+	if items := diff.GetItems(ApplicationInspectionDiffKindConfig); len(items) != 0 {
+		t.Fatal("config sources should be relative to the project dirs", items)
+	}
+	if items := diff.GetItems(ApplicationInspectionDiffKindTarget); len(items) != 0 {
+		t.Fatal("target files should be relative to the project dirs", items)
+	}
+
+	entries := getApplicationInspectionConfigEntries(old)
+	if source := entries["db.port"].source; source != "lib:config.yml > app:config/app.yml" {
+		t.Fatal("port source", source)
+	}
+	if source := entries["db.password"].source; source != "encrypted:app:secret.yml" {
+		t.Fatal("password source", source)
+	}
+
+	new.Config.Trace["db"].(map[string]any)["port"] = "override:builder"
+	diff = DiffApplicationInspection(old, new)
+	items := diff.GetItems(ApplicationInspectionDiffKindConfig)
+	if len(items) != 1 || items[0].Key != "db.port" || items[0].NewSource != "override:builder" {
+		t.Fatal("config source change", items)
+	}
+}
+
+func TestRelativizeApplicationInspectionConfigTrace(t *testing.T) {
+	projects := []*ProjectInspection{
+		NewProjectInspection("nested", "/root/app/nested", nil, nil, nil, nil),
+		NewProjectInspection("app", "/root/app", nil, nil, nil, nil),
+	}
+	trace := relativizeApplicationInspectionConfigTrace([]any{
+		"delete:/root/app/a.yml > /root/app/nested/b.yml",
+		map[string]any{"key": "/root/other/c.yml"},
+	}, projects).([]any)
+	if trace[0] != "delete:app:a.yml > nested:b.yml" {
+		t.Fatal("list trace", trace[0])
+	}
+	if trace[1].(map[string]any)["key"] != "/root/other/c.yml" {
+		t.Fatal("map trace", trace[1])
+	}
+}
@@ -0,0 +1,168 @@
+package inspection
+
+import (
+	. "github.com/orz-dsh/dsh/utils"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// region base
+
+const applicationInspectionDirName = "@inspection"
+
+var applicationInspectionProjectFileRegex = regexp.MustCompile(`^project\.(main|[ad][0-9]+)\.(.+)$`)
+
+// endregion
+
+// region load
+
+// Load reconstructs the application inspection from the inspection files written by the application,
+// the dir is the output dir or the `@inspection` dir in it, and the format is detected by the file ext.
+func Load(dir string) (*ApplicationInspection, error) {
+	return LoadFS(OSFileSystem, dir)
+}
+
+// LoadFS is the same as Load, but the inspection files are read from the file system, such as the output in a MemoryFileSystem.
+func LoadFS(fsys FileSystem, dir string) (*ApplicationInspection, error) {
+	inspectionDir := dir
+	if IsDirExistsFS(fsys, filepath.Join(dir, applicationInspectionDirName)) {
+		inspectionDir = filepath.Join(dir, applicationInspectionDirName)
+	}
+	if !IsDirExistsFS(fsys, inspectionDir) {
+		return nil, ErrN("load inspection error",
+			Reason("dir not found"),
+			KV("dir", dir),
+		)
+	}
+
+	environment := &EnvironmentInspection{}
+	metadata, err := DeserializeDirFS(fsys, inspectionDir, []string{"environment"}, environment, true)
+	if err != nil {
+		return nil, ErrW(err, "load inspection error",
+			Reason("load environment inspection error"),
+			KV("dir", inspectionDir),
+		)
+	}
+	ext := strings.TrimPrefix(filepath.Base(metadata.File), "environment")
+
+	workspace := &WorkspaceInspection{}
+	if err = loadInspectionFile(fsys, inspectionDir, "workspace"+ext, metadata.Format, workspace); err != nil {
+		return nil, err
+	}
+	variable := &ApplicationVariableInspection{}
+	if err = loadInspectionFile(fsys, inspectionDir, "app.variable"+ext, metadata.Format, variable); err != nil {
+		return nil, err
+	}
+	setting := &ApplicationSettingInspection{}
+	if err = loadInspectionFile(fsys, inspectionDir, "app.setting"+ext, metadata.Format, setting); err != nil {
+		return nil, err
+	}
+	option := &ApplicationOptionInspection{}
+	if err = loadInspectionFile(fsys, inspectionDir, "app.option"+ext, metadata.Format, option); err != nil {
+		return nil, err
+	}
+	config := &ApplicationConfigInspection{}
+	if err = loadInspectionFile(fsys, inspectionDir, "app.config"+ext, metadata.Format, config); err != nil {
+		return nil, err
+	}
+
+	mainProject, additionProjects, dependencyProjects, err := loadInspectionProjects(fsys, inspectionDir, ext, metadata.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewApplicationInspection(environment, workspace, variable, setting, option, config, mainProject, additionProjects, dependencyProjects), nil
+}
+
+func loadInspectionFile(fsys FileSystem, dir, name string, format SerializationFormat, model any) error {
+	file := filepath.Join(dir, name)
+	if _, err := DeserializeFileFS(fsys, file, format, model); err != nil {
+		return ErrW(err, "load inspection error",
+			Reason("deserialize inspection file error"),
+			KV("file", file),
+		)
+	}
+	return nil
+}
+
+// loadInspectionProjects loads the project inspection files such as `project.main.app.yml`, `project.a001.extra.yml`
+// and `project.d001.lib.yml`, the addition and dependency projects are ordered by the number in the file names.
+func loadInspectionProjects(fsys FileSystem, dir, ext string, format SerializationFormat) (mainProject *ProjectInspection, additionProjects, dependencyProjects []*ProjectInspection, err error) {
+	entries, err := fsys.ReadDir(dir)
+	if err != nil {
+		return nil, nil, nil, ErrW(err, "load inspection error",
+			Reason("read dir error"),
+			KV("dir", dir),
+		)
+	}
+
+	var additionNames, dependencyNames []string
+	for i := 0; i < len(entries); i++ {
+		name := entries[i].Name()
+		if entries[i].IsDir() || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		match := applicationInspectionProjectFileRegex.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		switch {
+		case match[1] == "main":
+			if mainProject != nil {
+				return nil, nil, nil, ErrN("load inspection error",
+					Reason("main project duplicated"),
+					KV("dir", dir),
+					KV("file", name),
+				)
+			}
+			mainProject = &ProjectInspection{}
+			if err = loadInspectionFile(fsys, dir, name, format, mainProject); err != nil {
+				return nil, nil, nil, err
+			}
+		case match[1][0] == 'a':
+			additionNames = append(additionNames, name)
+		default:
+			dependencyNames = append(dependencyNames, name)
+		}
+	}
+	if mainProject == nil {
+		return nil, nil, nil, ErrN("load inspection error",
+			Reason("main project not found"),
+			KV("dir", dir),
+		)
+	}
+
+	if additionProjects, err = loadInspectionOrderedProjects(fsys, dir, additionNames, format); err != nil {
+		return nil, nil, nil, err
+	}
+	if dependencyProjects, err = loadInspectionOrderedProjects(fsys, dir, dependencyNames, format); err != nil {
+		return nil, nil, nil, err
+	}
+	return mainProject, additionProjects, dependencyProjects, nil
+}
+
+func loadInspectionOrderedProjects(fsys FileSystem, dir string, names []string, format SerializationFormat) ([]*ProjectInspection, error) {
+	slices.SortStableFunc(names, func(l, r string) int {
+		return getInspectionProjectFileOrder(l) - getInspectionProjectFileOrder(r)
+	})
+	var projects []*ProjectInspection
+	for i := 0; i < len(names); i++ {
+		project := &ProjectInspection{}
+		if err := loadInspectionFile(fsys, dir, names[i], format, project); err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
+
+func getInspectionProjectFileOrder(name string) int {
+	match := applicationInspectionProjectFileRegex.FindStringSubmatch(name)
+	order, _ := strconv.Atoi(match[1][1:])
+	return order
+}
+
+// endregion
@@ -8,15 +8,17 @@ type ApplicationSettingInspection struct {
 	Executor *ExecutorSettingInspection        `yaml:"executor,omitempty" toml:"executor,omitempty" json:"executor,omitempty"`
 	Registry *RegistrySettingInspection        `yaml:"registry,omitempty" toml:"registry,omitempty" json:"registry,omitempty"`
 	Redirect *RedirectSettingInspection        `yaml:"redirect,omitempty" toml:"redirect,omitempty" json:"redirect,omitempty"`
+	Config   *ConfigOverrideSettingInspection  `yaml:"config,omitempty" toml:"config,omitempty" json:"config,omitempty"`
 }
 
-func NewApplicationSettingInspection(argument *ProfileArgumentSettingInspection, addition *ProfileAdditionSettingInspection, executor *ExecutorSettingInspection, registry *RegistrySettingInspection, redirect *RedirectSettingInspection) *ApplicationSettingInspection {
+func NewApplicationSettingInspection(argument *ProfileArgumentSettingInspection, addition *ProfileAdditionSettingInspection, executor *ExecutorSettingInspection, registry *RegistrySettingInspection, redirect *RedirectSettingInspection, config *ConfigOverrideSettingInspection) *ApplicationSettingInspection {
 	return &ApplicationSettingInspection{
 		Argument: argument,
 		Addition: addition,
 		Executor: executor,
 		Registry: registry,
 		Redirect: redirect,
+		Config:   config,
 	}
 }
 
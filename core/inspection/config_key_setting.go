@@ -0,0 +1,15 @@
+package inspection
+
+// region ConfigKeySettingInspection
+
+type ConfigKeySettingInspection struct {
+	Keys []string `yaml:"keys,omitempty" toml:"keys,omitempty" json:"keys,omitempty"`
+}
+
+func NewConfigKeySettingInspection(keys []string) *ConfigKeySettingInspection {
+	return &ConfigKeySettingInspection{
+		Keys: keys,
+	}
+}
+
+// endregion
@@ -0,0 +1,33 @@
+package inspection
+
+// region ConfigOverrideSettingInspection
+
+type ConfigOverrideSettingInspection struct {
+	Items []*ConfigOverrideItemSettingInspection `yaml:"items,omitempty" toml:"items,omitempty" json:"items,omitempty"`
+}
+
+func NewConfigOverrideSettingInspection(items []*ConfigOverrideItemSettingInspection) *ConfigOverrideSettingInspection {
+	return &ConfigOverrideSettingInspection{
+		Items: items,
+	}
+}
+
+// endregion
+
+// region ConfigOverrideItemSettingInspection
+
+type ConfigOverrideItemSettingInspection struct {
+	Path   string `yaml:"path" toml:"path" json:"path"`
+	Value  any    `yaml:"value" toml:"value" json:"value"`
+	Source string `yaml:"source,omitempty" toml:"source,omitempty" json:"source,omitempty"`
+}
+
+func NewConfigOverrideItemSettingInspection(path string, value any, source string) *ConfigOverrideItemSettingInspection {
+	return &ConfigOverrideItemSettingInspection{
+		Path:   path,
+		Value:  value,
+		Source: source,
+	}
+}
+
+// endregion
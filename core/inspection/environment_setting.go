@@ -5,12 +5,17 @@ package inspection
 type EnvironmentSettingInspection struct {
 	Argument  *EnvironmentArgumentSettingInspection  `yaml:"argument,omitempty" toml:"argument,omitempty" json:"argument,omitempty"`
 	Workspace *EnvironmentWorkspaceSettingInspection `yaml:"workspace,omitempty" toml:"workspace,omitempty" json:"workspace,omitempty"`
+	Config    *ConfigOverrideSettingInspection       `yaml:"config,omitempty" toml:"config,omitempty" json:"config,omitempty"`
+	ConfigKey *ConfigKeySettingInspection            `yaml:"configKey,omitempty" toml:"configKey,omitempty" json:"configKey,omitempty"`
+	Log       *LogSettingInspection                  `yaml:"log,omitempty" toml:"log,omitempty" json:"log,omitempty"`
 }
 
-func NewEnvironmentSettingInspection(argument *EnvironmentArgumentSettingInspection, workspace *EnvironmentWorkspaceSettingInspection) *EnvironmentSettingInspection {
+func NewEnvironmentSettingInspection(argument *EnvironmentArgumentSettingInspection, workspace *EnvironmentWorkspaceSettingInspection, config *ConfigOverrideSettingInspection, configKey *ConfigKeySettingInspection) *EnvironmentSettingInspection {
 	return &EnvironmentSettingInspection{
 		Argument:  argument,
 		Workspace: workspace,
+		Config:    config,
+		ConfigKey: configKey,
 	}
 }
 
@@ -5,16 +5,18 @@ package inspection
 type EnvironmentWorkspaceSettingInspection struct {
 	Dir      string                             `yaml:"dir,omitempty" toml:"dir,omitempty" json:"dir,omitempty"`
 	Clean    *WorkspaceCleanSettingInspection   `yaml:"clean,omitempty" toml:"clean,omitempty" json:"clean,omitempty"`
+	Lock     *WorkspaceLockSettingInspection    `yaml:"lock,omitempty" toml:"lock,omitempty" json:"lock,omitempty"`
 	Profile  *WorkspaceProfileSettingInspection `yaml:"profile,omitempty" toml:"profile,omitempty" json:"profile,omitempty"`
 	Executor *ExecutorSettingInspection         `yaml:"executor,omitempty" toml:"executor,omitempty" json:"executor,omitempty"`
 	Registry *RegistrySettingInspection         `yaml:"registry,omitempty" toml:"registry,omitempty" json:"registry,omitempty"`
 	Redirect *RedirectSettingInspection         `yaml:"redirect,omitempty" toml:"redirect,omitempty" json:"redirect,omitempty"`
 }
 
-func NewEnvironmentWorkspaceSettingInspection(dir string, clean *WorkspaceCleanSettingInspection, profile *WorkspaceProfileSettingInspection, executor *ExecutorSettingInspection, registry *RegistrySettingInspection, redirect *RedirectSettingInspection) *EnvironmentWorkspaceSettingInspection {
+func NewEnvironmentWorkspaceSettingInspection(dir string, clean *WorkspaceCleanSettingInspection, lock *WorkspaceLockSettingInspection, profile *WorkspaceProfileSettingInspection, executor *ExecutorSettingInspection, registry *RegistrySettingInspection, redirect *RedirectSettingInspection) *EnvironmentWorkspaceSettingInspection {
 	return &EnvironmentWorkspaceSettingInspection{
 		Dir:      dir,
 		Clean:    clean,
+		Lock:     lock,
 		Profile:  profile,
 		Executor: executor,
 		Registry: registry,
@@ -0,0 +1,19 @@
+package inspection
+
+// region LogSettingInspection
+
+type LogSettingInspection struct {
+	Level  string            `yaml:"level,omitempty" toml:"level,omitempty" json:"level,omitempty"`
+	Levels map[string]string `yaml:"levels,omitempty" toml:"levels,omitempty" json:"levels,omitempty"`
+	Format string            `yaml:"format,omitempty" toml:"format,omitempty" json:"format,omitempty"`
+}
+
+func NewLogSettingInspection(level string, levels map[string]string, format string) *LogSettingInspection {
+	return &LogSettingInspection{
+		Level:  level,
+		Levels: levels,
+		Format: format,
+	}
+}
+
+// endregion
@@ -0,0 +1,37 @@
+package inspection
+
+// region ProjectConfigSettingInspection
+
+type ProjectConfigSettingInspection struct {
+	Items []*ProjectConfigItemSettingInspection `yaml:"items,omitempty" toml:"items,omitempty" json:"items,omitempty"`
+}
+
+func NewProjectConfigSettingInspection(items []*ProjectConfigItemSettingInspection) *ProjectConfigSettingInspection {
+	return &ProjectConfigSettingInspection{
+		Items: items,
+	}
+}
+
+// endregion
+
+// region ProjectConfigItemSettingInspection
+
+type ProjectConfigItemSettingInspection struct {
+	Path     string `yaml:"path" toml:"path" json:"path"`
+	Type     string `yaml:"type,omitempty" toml:"type,omitempty" json:"type,omitempty"`
+	Required bool   `yaml:"required,omitempty" toml:"required,omitempty" json:"required,omitempty"`
+	Pattern  string `yaml:"pattern,omitempty" toml:"pattern,omitempty" json:"pattern,omitempty"`
+	Enum     []any  `yaml:"enum,omitempty" toml:"enum,omitempty" json:"enum,omitempty"`
+}
+
+func NewProjectConfigItemSettingInspection(path, typ string, required bool, pattern string, enum []any) *ProjectConfigItemSettingInspection {
+	return &ProjectConfigItemSettingInspection{
+		Path:     path,
+		Type:     typ,
+		Required: required,
+		Pattern:  pattern,
+		Enum:     enum,
+	}
+}
+
+// endregion
@@ -17,18 +17,22 @@ func NewProjectDependencyInspection(items []*ProjectDependencyItemInspection) *P
 // region ProjectDependencyItemInspection
 
 type ProjectDependencyItemInspection struct {
-	Link   string `yaml:"link" toml:"link" json:"link"`
-	Dir    string `yaml:"dir" toml:"dir" json:"dir"`
-	GitUrl string `yaml:"gitUrl,omitempty" toml:"gitUrl,omitempty" json:"gitUrl,omitempty"`
-	GitRef string `yaml:"gitRef,omitempty" toml:"gitRef,omitempty" json:"gitRef,omitempty"`
+	Link      string `yaml:"link" toml:"link" json:"link"`
+	Dir       string `yaml:"dir" toml:"dir" json:"dir"`
+	GitUrl    string `yaml:"gitUrl,omitempty" toml:"gitUrl,omitempty" json:"gitUrl,omitempty"`
+	GitRef    string `yaml:"gitRef,omitempty" toml:"gitRef,omitempty" json:"gitRef,omitempty"`
+	GitCommit string `yaml:"gitCommit,omitempty" toml:"gitCommit,omitempty" json:"gitCommit,omitempty"`
+	OciRef    string `yaml:"ociRef,omitempty" toml:"ociRef,omitempty" json:"ociRef,omitempty"`
+	OciDigest string `yaml:"ociDigest,omitempty" toml:"ociDigest,omitempty" json:"ociDigest,omitempty"`
 }
 
-func NewProjectDependencyItemInspection(link, dir, gitUrl, gitRef string) *ProjectDependencyItemInspection {
+func NewProjectDependencyItemInspection(link, dir, gitUrl, gitRef, gitCommit string) *ProjectDependencyItemInspection {
 	return &ProjectDependencyItemInspection{
-		Link:   link,
-		Dir:    dir,
-		GitUrl: gitUrl,
-		GitRef: gitRef,
+		Link:      link,
+		Dir:       dir,
+		GitUrl:    gitUrl,
+		GitRef:    gitRef,
+		GitCommit: gitCommit,
 	}
 }
 
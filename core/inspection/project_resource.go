@@ -23,14 +23,16 @@ func NewProjectResourceInspection(configItems []*ProjectResourceConfigItemInspec
 // region ProjectResourceConfigItemInspection
 
 type ProjectResourceConfigItemInspection struct {
-	File   string `yaml:"file" toml:"file" json:"file"`
-	Format string `yaml:"format" toml:"format" json:"format"`
+	File      string `yaml:"file" toml:"file" json:"file"`
+	Format    string `yaml:"format" toml:"format" json:"format"`
+	Encrypted bool   `yaml:"encrypted,omitempty" toml:"encrypted,omitempty" json:"encrypted,omitempty"`
 }
 
-func NewProjectResourceConfigItemInspection(file, format string) *ProjectResourceConfigItemInspection {
+func NewProjectResourceConfigItemInspection(file, format string, encrypted bool) *ProjectResourceConfigItemInspection {
 	return &ProjectResourceConfigItemInspection{
-		File:   file,
-		Format: format,
+		File:      file,
+		Format:    format,
+		Encrypted: encrypted,
 	}
 }
 
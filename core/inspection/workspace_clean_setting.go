@@ -5,12 +5,14 @@ import "time"
 // region WorkspaceCleanSettingInspection
 
 type WorkspaceCleanSettingInspection struct {
-	Output *WorkspaceCleanOutputSettingInspection `yaml:"output,omitempty" toml:"output,omitempty" json:"output,omitempty"`
+	Output  *WorkspaceCleanOutputSettingInspection  `yaml:"output,omitempty" toml:"output,omitempty" json:"output,omitempty"`
+	Project *WorkspaceCleanProjectSettingInspection `yaml:"project,omitempty" toml:"project,omitempty" json:"project,omitempty"`
 }
 
-func NewWorkspaceCleanSettingInspection(output *WorkspaceCleanOutputSettingInspection) *WorkspaceCleanSettingInspection {
+func NewWorkspaceCleanSettingInspection(output *WorkspaceCleanOutputSettingInspection, project *WorkspaceCleanProjectSettingInspection) *WorkspaceCleanSettingInspection {
 	return &WorkspaceCleanSettingInspection{
-		Output: output,
+		Output:  output,
+		Project: project,
 	}
 }
 
@@ -19,15 +21,51 @@ func NewWorkspaceCleanSettingInspection(output *WorkspaceCleanOutputSettingInspe
 // region WorkspaceCleanOutputSettingInspection
 
 type WorkspaceCleanOutputSettingInspection struct {
+	Count   *int                                         `yaml:"count,omitempty" toml:"count,omitempty" json:"count,omitempty"`
+	Expires *time.Duration                               `yaml:"expires,omitempty" toml:"expires,omitempty" json:"expires,omitempty"`
+	Size    *int64                                       `yaml:"size,omitempty" toml:"size,omitempty" json:"size,omitempty"`
+	Items   []*WorkspaceCleanOutputItemSettingInspection `yaml:"items,omitempty" toml:"items,omitempty" json:"items,omitempty"`
+}
+
+func NewWorkspaceCleanOutputSettingInspection(count *int, expires *time.Duration, size *int64, items []*WorkspaceCleanOutputItemSettingInspection) *WorkspaceCleanOutputSettingInspection {
+	return &WorkspaceCleanOutputSettingInspection{
+		Count:   count,
+		Expires: expires,
+		Size:    size,
+		Items:   items,
+	}
+}
+
+// endregion
+
+// region WorkspaceCleanOutputItemSettingInspection
+
+type WorkspaceCleanOutputItemSettingInspection struct {
+	Name    string         `yaml:"name" toml:"name" json:"name"`
 	Count   *int           `yaml:"count,omitempty" toml:"count,omitempty" json:"count,omitempty"`
 	Expires *time.Duration `yaml:"expires,omitempty" toml:"expires,omitempty" json:"expires,omitempty"`
 }
 
-func NewWorkspaceCleanOutputSettingInspection(count *int, expires *time.Duration) *WorkspaceCleanOutputSettingInspection {
-	return &WorkspaceCleanOutputSettingInspection{
+func NewWorkspaceCleanOutputItemSettingInspection(name string, count *int, expires *time.Duration) *WorkspaceCleanOutputItemSettingInspection {
+	return &WorkspaceCleanOutputItemSettingInspection{
+		Name:    name,
 		Count:   count,
 		Expires: expires,
 	}
 }
 
 // endregion
+
+// region WorkspaceCleanProjectSettingInspection
+
+type WorkspaceCleanProjectSettingInspection struct {
+	Expires *time.Duration `yaml:"expires,omitempty" toml:"expires,omitempty" json:"expires,omitempty"`
+}
+
+func NewWorkspaceCleanProjectSettingInspection(expires *time.Duration) *WorkspaceCleanProjectSettingInspection {
+	return &WorkspaceCleanProjectSettingInspection{
+		Expires: expires,
+	}
+}
+
+// endregion
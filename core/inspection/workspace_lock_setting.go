@@ -0,0 +1,17 @@
+package inspection
+
+import "time"
+
+// region WorkspaceLockSettingInspection
+
+type WorkspaceLockSettingInspection struct {
+	Timeout *time.Duration `yaml:"timeout,omitempty" toml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+func NewWorkspaceLockSettingInspection(timeout *time.Duration) *WorkspaceLockSettingInspection {
+	return &WorkspaceLockSettingInspection{
+		Timeout: timeout,
+	}
+}
+
+// endregion
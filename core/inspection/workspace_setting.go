@@ -4,15 +4,17 @@ package inspection
 
 type WorkspaceSettingInspection struct {
 	Clean    *WorkspaceCleanSettingInspection   `yaml:"clean,omitempty" toml:"clean,omitempty" json:"clean,omitempty"`
+	Lock     *WorkspaceLockSettingInspection    `yaml:"lock,omitempty" toml:"lock,omitempty" json:"lock,omitempty"`
 	Profile  *WorkspaceProfileSettingInspection `yaml:"profile,omitempty" toml:"profile,omitempty" json:"profile,omitempty"`
 	Executor *ExecutorSettingInspection         `yaml:"executor,omitempty" toml:"executor,omitempty" json:"executor,omitempty"`
 	Registry *RegistrySettingInspection         `yaml:"registry,omitempty" toml:"registry,omitempty" json:"registry,omitempty"`
 	Redirect *RedirectSettingInspection         `yaml:"redirect,omitempty" toml:"redirect,omitempty" json:"redirect,omitempty"`
 }
 
-func NewWorkspaceSettingInspection(clean *WorkspaceCleanSettingInspection, profile *WorkspaceProfileSettingInspection, executor *ExecutorSettingInspection, registry *RegistrySettingInspection, redirect *RedirectSettingInspection) *WorkspaceSettingInspection {
+func NewWorkspaceSettingInspection(clean *WorkspaceCleanSettingInspection, lock *WorkspaceLockSettingInspection, profile *WorkspaceProfileSettingInspection, executor *ExecutorSettingInspection, registry *RegistrySettingInspection, redirect *RedirectSettingInspection) *WorkspaceSettingInspection {
 	return &WorkspaceSettingInspection{
 		Clean:    clean,
+		Lock:     lock,
 		Profile:  profile,
 		Executor: executor,
 		Registry: registry,
@@ -18,6 +18,7 @@ type ApplicationCore struct {
 	Setting                 *ApplicationSetting
 	Option                  *ApplicationOption
 	Config                  *ApplicationConfig
+	FileSystem              FileSystem
 	MainProjectSetting      *ProjectSetting
 	AdditionProjectSettings []*ProjectSetting
 	MainProject             *Project
@@ -25,9 +26,10 @@ type ApplicationCore struct {
 	DependencyProjects      []*Project
 	Projects                []*Project
 	projectsByName          map[string]*Project
+	providerExecCache       *projectProviderExecCache
 }
 
-func NewApplicationCore(workspace *WorkspaceCore, setting *ApplicationSetting, link string) (*ApplicationCore, error) {
+func NewApplicationCore(workspace *WorkspaceCore, setting *ApplicationSetting, link string, prompter *ApplicationOptionPrompter) (*ApplicationCore, error) {
 	mainProjectSetting, err := setting.GetProjectEntityByRawLink(link)
 	if err != nil {
 		return nil, err
@@ -48,7 +50,7 @@ func NewApplicationCore(workspace *WorkspaceCore, setting *ApplicationSetting, l
 		return nil, err
 	}
 
-	option := NewApplicationOption(mainProjectSetting.Name, workspace.Environment.System, evaluator, arguments)
+	option := NewApplicationOption(workspace.Logger.Named(LoggerNameOption), mainProjectSetting.Name, workspace.Environment.System, evaluator, arguments, prompter)
 	core := &ApplicationCore{
 		Logger:                  workspace.Logger,
 		Environment:             workspace.Environment,
@@ -56,9 +58,11 @@ func NewApplicationCore(workspace *WorkspaceCore, setting *ApplicationSetting, l
 		Evaluator:               evaluator,
 		Setting:                 setting,
 		Option:                  option,
+		FileSystem:              setting.FileSystem,
 		MainProjectSetting:      mainProjectSetting,
 		AdditionProjectSettings: additionProjectSettings,
 		projectsByName:          map[string]*Project{},
+		providerExecCache:       newProjectProviderExecCache(),
 	}
 	return core, nil
 }
@@ -67,13 +71,37 @@ func (a *ApplicationCore) loadProject(setting *ProjectSetting) (project *Project
 	if existProject, exist := a.projectsByName[setting.Name]; exist {
 		return existProject, nil
 	}
-	if project, err = NewProject(a, setting, nil); err != nil {
+	if project, err = a.newProject(setting, nil); err != nil {
 		return nil, err
 	}
 	a.projectsByName[setting.Name] = project
 	return project, nil
 }
 
+// newProject creates the project with the shared lock of its dir if it is downloaded to the workspace, so the resources and provider files
+// are not changed by pulling or cleaning of other processes while reading, and the used time of the project is touched for cleaning.
+func (a *ApplicationCore) newProject(setting *ProjectSetting, option *ProjectOption) (*Project, error) {
+	if a.Workspace.IsDownloadedProjectDir(setting.Dir) {
+		lock, err := a.Workspace.LockDir(setting.Dir, true)
+		if err != nil {
+			return nil, ErrW(err, "load project error",
+				Reason("lock project dir error"),
+				KV("projectName", setting.Name),
+				KV("projectPath", setting.Dir),
+			)
+		}
+		defer a.Workspace.UnlockDir(lock)
+		if err = a.Workspace.TouchDownloadedProject(setting.Dir); err != nil {
+			a.Logger.WarnDesc("touch project error",
+				KV("projectName", setting.Name),
+				KV("projectPath", setting.Dir),
+				KV("error", err),
+			)
+		}
+	}
+	return NewProject(a, setting, option)
+}
+
 func (a *ApplicationCore) loadProjectByTarget(target *ProjectLinkTarget) (project *Project, err error) {
 	setting, err := a.Setting.GetProjectSettingByLinkTarget(target)
 	if err != nil {
@@ -127,7 +155,7 @@ func (a *ApplicationCore) loadImportProjects(project *Project, projectsDict map[
 	return projects, nil
 }
 
-func (a *ApplicationCore) loadProjects() (err error) {
+func (a *ApplicationCore) LoadProjects() (err error) {
 	if a.MainProject != nil {
 		return nil
 	}
@@ -157,7 +185,7 @@ func (a *ApplicationCore) loadProjects() (err error) {
 		if existProject != nil {
 			option = existProject.option
 		}
-		extraProject, err := NewProject(a, a.AdditionProjectSettings[i], option)
+		extraProject, err := a.newProject(a.AdditionProjectSettings[i], option)
 		if err != nil {
 			return err
 		}
@@ -188,14 +216,21 @@ func (a *ApplicationCore) LoadConfig() error {
 	startTime := time.Now()
 	a.Logger.Info("make config start")
 
-	if err := a.loadProjects(); err != nil {
+	if err := a.LoadProjects(); err != nil {
 		return ErrW(err, "make config error",
 			Reason("load projects error"),
 			// TODO: error
 		)
 	}
 
-	config, err := NewApplicationConfig(a.Evaluator, a.Projects)
+	projectLocks, err := a.Workspace.LockDirs(a.Setting.GetDownloadedProjectDirs(), true)
+	if err != nil {
+		return ErrW(err, "make config error",
+			Reason("lock project dirs error"),
+		)
+	}
+	config, err := NewApplicationConfig(a.Evaluator, a.Projects, a.Setting.Config)
+	a.Workspace.UnlockDirs(projectLocks)
 	if err != nil {
 		return ErrW(err, "make config error",
 			Reason("make config error"),
@@ -213,10 +248,28 @@ func (a *ApplicationCore) MakeArtifact(options MakeArtifactOptions) (artifact *A
 		return nil, err
 	}
 
+	projectLocks, err := a.Workspace.LockDirs(a.Setting.GetDownloadedProjectDirs(), true)
+	if err != nil {
+		return nil, ErrW(err, "make scripts error",
+			Reason("lock project dirs error"),
+		)
+	}
+	defer a.Workspace.UnlockDirs(projectLocks)
+
 	startTime := time.Now()
 	a.Logger.Info("make artifact start")
 	outputDir := options.OutputDir
-	if outputDir == "" {
+	outputHash := ""
+	if outputDir == "" && options.OutputCache {
+		if outputHash, err = a.HashOutput(options); err != nil {
+			return nil, ErrW(err, "make scripts error",
+				Reason("hash output error"),
+			)
+		}
+	}
+	if outputHash != "" {
+		outputDir = a.Workspace.GetOutputCacheDir(a.MainProject.Name, outputHash)
+	} else if outputDir == "" {
 		outputDir, err = a.Workspace.MakeOutputDir(a.MainProject.Name)
 		if err != nil {
 			return nil, ErrW(err, "make scripts error",
@@ -232,17 +285,52 @@ func (a *ApplicationCore) MakeArtifact(options MakeArtifactOptions) (artifact *A
 			)
 		}
 		outputDir = absPath
-		if options.OutputDirClear {
-			if err = ClearDir(outputDir); err != nil {
-				return nil, ErrW(err, "make scripts error",
-					Reason("clear output dir error"),
-					KV("path", outputDir),
-				)
+	}
+
+	outputLock, err := a.Workspace.LockDir(outputDir, false)
+	if err != nil {
+		return nil, ErrW(err, "make scripts error",
+			Reason("lock output dir error"),
+			KV("path", outputDir),
+		)
+	}
+	defer a.Workspace.UnlockDir(outputLock)
+
+	if outputHash != "" {
+		cache, err := a.Workspace.LoadOutputCache(a.FileSystem, outputDir)
+		if err != nil {
+			return nil, ErrW(err, "make scripts error",
+				Reason("load output cache error"),
+				KV("path", outputDir),
+			)
+		}
+		if cache != nil {
+			targetNamesDict := map[string]bool{}
+			for i := 0; i < len(cache.TargetNames); i++ {
+				targetNamesDict[cache.TargetNames[i]] = true
 			}
+			a.Logger.InfoDesc("make artifact finish",
+				KV("elapsed", time.Since(startTime)),
+				KV("outputCache", outputDir),
+			)
+			return NewArtifactCore(a, outputDir, cache.TargetNames, targetNamesDict), nil
+		}
+		if err = RemakeDirFS(a.FileSystem, outputDir); err != nil {
+			return nil, ErrW(err, "make scripts error",
+				Reason("remake output cache dir error"),
+				KV("path", outputDir),
+			)
+		}
+	} else if options.OutputDir != "" && options.OutputDirClear {
+		if err = ClearDirFS(a.FileSystem, outputDir); err != nil {
+			return nil, ErrW(err, "make scripts error",
+				Reason("clear output dir error"),
+				KV("path", outputDir),
+			)
 		}
 	}
 
-	if err = a.loadProjects(); err != nil {
+	if err = a.LoadProjects(); err != nil {
 		return nil, ErrW(err, "make scripts error",
 			Reason("load projects error"),
 			// TODO: error
@@ -273,6 +361,16 @@ func (a *ApplicationCore) MakeArtifact(options MakeArtifactOptions) (artifact *A
 		}
 	}
 
+	if outputHash != "" {
+		cache := NewWorkspaceOutputCache(a.MainProject.Name, outputHash, targetNames)
+		if err = a.Workspace.SaveOutputCache(a.FileSystem, outputDir, cache); err != nil {
+			return nil, ErrW(err, "make scripts error",
+				Reason("save output cache error"),
+				KV("path", outputDir),
+			)
+		}
+	}
+
 	a.Logger.InfoDesc("make artifact finish", KV("elapsed", time.Since(startTime)))
 	return NewArtifactCore(a, outputDir, targetNames, targetNamesDict), nil
 }
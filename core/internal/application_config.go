@@ -1,18 +1,28 @@
 package internal
 
 import (
+	"fmt"
 	. "github.com/orz-dsh/dsh/core/inspection"
+	. "github.com/orz-dsh/dsh/core/internal/setting"
 	. "github.com/orz-dsh/dsh/utils"
+	"maps"
+	"math"
+	"reflect"
 	"slices"
+	"strings"
 )
 
+// region ApplicationConfig
+
 type ApplicationConfig struct {
 	Value     map[string]any
 	Trace     map[string]any
 	Evaluator *Evaluator
+	// SecretPaths are the paths of the overrides targeting the secret keys, the values are masked in the inspection.
+	SecretPaths map[string]bool
 }
 
-func NewApplicationConfig(evaluator *Evaluator, projects []*Project) (*ApplicationConfig, error) {
+func NewApplicationConfig(evaluator *Evaluator, projects []*Project, override *ConfigOverrideSetting) (*ApplicationConfig, error) {
 	var contents []*ProjectResourceConfigItemContent
 	for i := 0; i < len(projects); i++ {
 		iContents, err := projects[i].loadConfigContents()
@@ -49,14 +59,393 @@ func NewApplicationConfig(evaluator *Evaluator, projects []*Project) (*Applicati
 		}
 	}
 
+	// the overrides are merged last, the lists and maps at the override path are replaced instead of merged
+	secretPaths := map[string]bool{}
+	for i := 0; i < len(override.Items); i++ {
+		item := override.Items[i]
+		if isApplicationConfigSecretPath(value, strings.Split(item.Path, ".")) {
+			secretPaths[item.Path] = true
+		}
+		merge := map[string]MapMergeMode{item.Path: MapMergeModeReplace}
+		if _, _, err := MapMerge(value, item.GetValueMap(), merge, "override:"+item.Source, trace); err != nil {
+			return nil, ErrW(err, "make config error",
+				Reason("merge config override error"),
+				KV("path", item.Path),
+				KV("source", item.Source),
+			)
+		}
+	}
+
+	if err := validateApplicationConfig(projects, value, trace); err != nil {
+		return nil, err
+	}
+
+	// the secret references are replaced after the validation, they are masked in the inspection and resolved in the templates
+	parsedValue, err := ParseSecretValues(value)
+	if err != nil {
+		return nil, ErrW(err, "make config error",
+			Reason("parse config secrets error"),
+		)
+	}
+	value = parsedValue.(map[string]any)
+
 	config := &ApplicationConfig{
-		Value:     value,
-		Trace:     trace,
-		Evaluator: evaluator.SetData("config", value),
+		Value:       value,
+		Trace:       trace,
+		Evaluator:   evaluator.SetData("config", value),
+		SecretPaths: secretPaths,
 	}
 	return config, nil
 }
 
 func (c *ApplicationConfig) Inspect() *ApplicationConfigInspection {
-	return NewApplicationConfigInspection(c.Value, c.Trace)
+	value := maskApplicationConfigEncryptedValue(c.Value, c.Trace)
+	for path := range c.SecretPaths {
+		value = maskApplicationConfigValue(value, strings.Split(path, "."))
+	}
+	return NewApplicationConfigInspection(value.(map[string]any), c.Trace)
 }
+
+// IsSecretOverride reports whether the override path targets a secret key or is covered by an override targeting a secret key.
+func (c *ApplicationConfig) IsSecretOverride(path string) bool {
+	for secretPath := range c.SecretPaths {
+		if path == secretPath || strings.HasPrefix(path, secretPath+".") || strings.HasPrefix(secretPath, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// maskApplicationConfigEncryptedValue returns a copy of the value, the values merged from the encrypted config files
+// are masked by the trace, the maps and lists without such values are not copied.
+func maskApplicationConfigEncryptedValue(value any, trace any) any {
+	switch t := trace.(type) {
+	case string:
+		if isApplicationConfigEncryptedTrace(t) {
+			return maskApplicationConfigLeafValues(value)
+		}
+	case map[string]any:
+		if valueMap, ok := value.(map[string]any); ok {
+			var result map[string]any
+			for key, item := range valueMap {
+				if masked := maskApplicationConfigEncryptedValue(item, t[key]); !reflect.DeepEqual(masked, item) {
+					if result == nil {
+						result = maps.Clone(valueMap)
+					}
+					result[key] = masked
+				}
+			}
+			if result != nil {
+				return result
+			}
+		}
+	case []any:
+		if valueList, ok := value.([]any); ok {
+			var result []any
+			for i := 0; i < len(valueList) && i < len(t); i++ {
+				if masked := maskApplicationConfigEncryptedValue(valueList[i], t[i]); !reflect.DeepEqual(masked, valueList[i]) {
+					if result == nil {
+						result = slices.Clone(valueList)
+					}
+					result[i] = masked
+				}
+			}
+			if result != nil {
+				return result
+			}
+		}
+	}
+	return value
+}
+
+// isApplicationConfigEncryptedTrace reports whether the current source of the trace label is an encrypted config file,
+// the label may keep the delete trace before the current source, such as `delete:a > encrypted:b`.
+func isApplicationConfigEncryptedTrace(trace string) bool {
+	if index := strings.LastIndex(trace, " > "); index >= 0 {
+		trace = trace[index+3:]
+	}
+	return strings.HasPrefix(trace, projectResourceConfigEncryptedLabelPrefix)
+}
+
+func maskApplicationConfigLeafValues(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[key] = maskApplicationConfigLeafValues(item)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i := 0; i < len(v); i++ {
+			result[i] = maskApplicationConfigLeafValues(v[i])
+		}
+		return result
+	case nil:
+		return nil
+	default:
+		return SecretMask
+	}
+}
+
+// isApplicationConfigSecretPath reports whether the value at the path is or contains a secret reference,
+// or a parent of the path is a secret reference.
+func isApplicationConfigSecretPath(value any, keys []string) bool {
+	if len(keys) == 0 {
+		return isApplicationConfigSecret(value)
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		return isApplicationConfigSecretPath(v[keys[0]], keys[1:])
+	default:
+		return isApplicationConfigSecret(value)
+	}
+}
+
+func isApplicationConfigSecret(value any) bool {
+	switch v := value.(type) {
+	case string:
+		return IsSecretReference(v)
+	case *Secret:
+		return true
+	case map[string]any:
+		for _, item := range v {
+			if isApplicationConfigSecret(item) {
+				return true
+			}
+		}
+	case []any:
+		for i := 0; i < len(v); i++ {
+			if isApplicationConfigSecret(v[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// maskApplicationConfigValue returns the value with the value at the path masked, the maps on the path are cloned.
+func maskApplicationConfigValue(value any, keys []string) any {
+	if len(keys) == 0 {
+		return SecretMask
+	}
+	valueMap, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+	child, exist := valueMap[keys[0]]
+	if !exist {
+		return value
+	}
+	result := maps.Clone(valueMap)
+	result[keys[0]] = maskApplicationConfigValue(child, keys[1:])
+	return result
+}
+
+// validateApplicationConfig validates the merged config by the config schema declared in the projects,
+// all violations are reported together with the source of the bad value in the trace.
+func validateApplicationConfig(projects []*Project, value, trace map[string]any) error {
+	var violations []*ApplicationConfigViolation
+	for i := 0; i < len(projects); i++ {
+		project := projects[i]
+		for j := 0; j < len(project.config.Items); j++ {
+			item := project.config.Items[j]
+			walkApplicationConfig(strings.Split(item.Path, "."), "", value, trace, func(path string, value any, exist bool, source any) {
+				if reason := checkApplicationConfigValue(item, value, exist); reason != "" {
+					if label, ok := source.(string); ok && isApplicationConfigEncryptedTrace(label) {
+						value = SecretMask
+					}
+					violations = append(violations, &ApplicationConfigViolation{
+						Project: project.Name,
+						Path:    path,
+						Reason:  reason,
+						Value:   value,
+						Source:  source,
+					})
+				}
+			})
+		}
+	}
+	if len(violations) > 0 {
+		return ErrN("make config error",
+			Code(ErrorCodeConfigInvalid),
+			Reason("config validation error"),
+			KV("violations", violations),
+		)
+	}
+	return nil
+}
+
+func walkApplicationConfig(keys []string, path string, value any, trace any, visit func(path string, value any, exist bool, source any)) {
+	if len(keys) == 0 {
+		visit(path, value, true, trace)
+		return
+	}
+	key := keys[0]
+	switch value.(type) {
+	case map[string]any:
+		valueMap := value.(map[string]any)
+		if key == "*" {
+			childKeys := MapKeys(valueMap)
+			slices.Sort(childKeys)
+			for i := 0; i < len(childKeys); i++ {
+				walkApplicationConfig(keys[1:], joinApplicationConfigPath(path, childKeys[i]), valueMap[childKeys[i]], getApplicationConfigChildTrace(trace, childKeys[i]), visit)
+			}
+		} else if childValue, exist := valueMap[key]; exist {
+			walkApplicationConfig(keys[1:], joinApplicationConfigPath(path, key), childValue, getApplicationConfigChildTrace(trace, key), visit)
+		} else {
+			visit(joinApplicationConfigPath(path, strings.Join(keys, ".")), nil, false, getApplicationConfigChildTrace(trace, ""))
+		}
+	case []any:
+		if key == "*" {
+			valueList := value.([]any)
+			traceList, _ := trace.([]any)
+			for i := 0; i < len(valueList); i++ {
+				var childTrace any = trace
+				if traceList != nil {
+					childTrace = nil
+					if i < len(traceList) {
+						childTrace = traceList[i]
+					}
+				}
+				walkApplicationConfig(keys[1:], fmt.Sprintf("%s[%d]", path, i), valueList[i], childTrace, visit)
+			}
+		} else {
+			visit(joinApplicationConfigPath(path, strings.Join(keys, ".")), nil, false, getApplicationConfigChildTrace(trace, ""))
+		}
+	default:
+		if key != "*" {
+			visit(joinApplicationConfigPath(path, strings.Join(keys, ".")), nil, false, getApplicationConfigChildTrace(trace, ""))
+		}
+	}
+}
+
+func joinApplicationConfigPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+// getApplicationConfigChildTrace returns the trace of the child key, a label trace covers all children of the value.
+// The empty key is used for a missing child, only the label trace of the parent is returned.
+func getApplicationConfigChildTrace(trace any, key string) any {
+	switch trace.(type) {
+	case map[string]any:
+		if key == "" {
+			return nil
+		}
+		return trace.(map[string]any)[key]
+	case string:
+		return trace
+	default:
+		return nil
+	}
+}
+
+func checkApplicationConfigValue(item *ProjectConfigItemSetting, value any, exist bool) string {
+	if !exist || value == nil {
+		if item.Required {
+			return "value required"
+		}
+		return ""
+	}
+	if item.Type != "" && !checkApplicationConfigValueType(item.Type, value) {
+		return fmt.Sprintf("type not match, expected %s", item.Type)
+	}
+	if item.PatternRegex != nil && !item.PatternRegex.MatchString(value.(string)) {
+		return fmt.Sprintf("pattern not match, expected %s", item.Pattern)
+	}
+	if len(item.Enum) > 0 && !slices.ContainsFunc(item.Enum, func(e any) bool { return equalApplicationConfigValue(e, value) }) {
+		return fmt.Sprintf("value not in enum %v", item.Enum)
+	}
+	return ""
+}
+
+// equalApplicationConfigValue compares the values by type, the numbers are compared by value since the config files
+// of different formats decode the numbers to different types, but a number never equals a string.
+func equalApplicationConfigValue(l, r any) bool {
+	if lNumber, ok := getApplicationConfigNumber(l); ok {
+		rNumber, ok := getApplicationConfigNumber(r)
+		return ok && lNumber == rNumber
+	}
+	return reflect.DeepEqual(l, r)
+}
+
+func getApplicationConfigNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func checkApplicationConfigValueType(typ ProjectConfigValueType, value any) bool {
+	switch typ {
+	case CastTypeString:
+		_, ok := value.(string)
+		return ok
+	case CastTypeBool:
+		_, ok := value.(bool)
+		return ok
+	case CastTypeInteger:
+		switch value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			return true
+		case float64:
+			// the numbers of json config files are decoded as float64
+			return value.(float64) == math.Trunc(value.(float64))
+		}
+		return false
+	case CastTypeDecimal:
+		switch value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			return true
+		}
+		return false
+	case CastTypeObject:
+		_, ok := value.(map[string]any)
+		return ok
+	case CastTypeArray:
+		_, ok := value.([]any)
+		return ok
+	default:
+		return true
+	}
+}
+
+// endregion
+
+// region ApplicationConfigViolation
+
+type ApplicationConfigViolation struct {
+	Project string
+	Path    string
+	Reason  string
+	Value   any
+	Source  any
+}
+
+// endregion
@@ -0,0 +1,120 @@
+package internal
+
+import (
+	. "github.com/orz-dsh/dsh/core/internal/setting"
+	. "github.com/orz-dsh/dsh/utils"
+	"testing"
+)
+
+func TestApplicationConfigSecretOverride(t *testing.T) {
+	override := NewConfigOverrideSetting([]*ConfigOverrideItemSetting{
+		NewConfigOverrideItemSetting("db.password", "secret:env:DB_PASSWORD", "file"),
+		NewConfigOverrideItemSetting("db.host", "localhost", "file"),
+		NewConfigOverrideItemSetting("db.password", "plain", "builder"),
+	})
+	config, err := NewApplicationConfig(NewEvaluator(), nil, override)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Value["db"].(map[string]any)["password"] != "plain" {
+		t.Fatal("override value", config.Value)
+	}
+	if !config.IsSecretOverride("db.password") || !config.IsSecretOverride("db") || config.IsSecretOverride("db.host") {
+		t.Fatal("secret paths", config.SecretPaths)
+	}
+
+	inspection := config.Inspect()
+	db := inspection.Value["db"].(map[string]any)
+	if db["password"] != SecretMask || db["host"] != "localhost" {
+		t.Fatal("inspection value", inspection.Value)
+	}
+	if config.Value["db"].(map[string]any)["password"] != "plain" {
+		t.Fatal("inspection should not change the value", config.Value)
+	}
+}
+
+func TestApplicationConfigValidate(t *testing.T) {
+	value := map[string]any{
+		"servers": []any{
+			map[string]any{"name": "a", "port": 80},
+			map[string]any{"name": "b", "port": "443"},
+		},
+		"db": map[string]any{"level": 1.0},
+	}
+	trace := map[string]any{
+		"servers": []any{"file1", map[string]any{"name": "file1", "port": "file2"}},
+		"db":      "file3",
+	}
+	project := &Project{
+		Name: "app",
+		config: NewProjectConfigSetting([]*ProjectConfigItemSetting{
+			NewProjectConfigItemSetting("servers.*.port", CastTypeInteger, true, "", nil, nil),
+			NewProjectConfigItemSetting("servers.*.host", "", true, "", nil, nil),
+			NewProjectConfigItemSetting("db.level", "", false, "", []any{1, 2}, nil),
+		}),
+	}
+	err := validateApplicationConfig([]*Project{project}, value, trace)
+	if err == nil {
+		t.Fatal("validation should fail")
+	}
+	var violations []*ApplicationConfigViolation
+	for _, kv := range err.(*Error).Details[0].Values {
+		if kv.Key == "violations" {
+			violations = kv.Value.([]*ApplicationConfigViolation)
+		}
+	}
+	if len(violations) != 3 {
+		t.Fatal("violations", violations)
+	}
+	expected := []struct {
+		path   string
+		source any
+	}{
+		{"servers[1].port", "file2"},
+		{"servers[0].host", "file1"},
+		{"servers[1].host", nil},
+	}
+	for i := 0; i < len(expected); i++ {
+		if violations[i].Path != expected[i].path || violations[i].Source != expected[i].source {
+			t.Fatal("violation", i, violations[i])
+		}
+	}
+
+	value["db"] = map[string]any{"level": "1"}
+	value["servers"] = []any{}
+	if err = validateApplicationConfig([]*Project{project}, value, trace); err == nil {
+		t.Fatal("string value should not match the number enum")
+	}
+	value["db"] = map[string]any{"level": int64(2)}
+	if err = validateApplicationConfig([]*Project{project}, value, trace); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplicationConfigEncryptedInspect(t *testing.T) {
+	config := &ApplicationConfig{
+		Value: map[string]any{
+			"db":      map[string]any{"host": "localhost", "pass": "p@ss"},
+			"tokens":  []any{"a", "b"},
+			"secrets": map[string]any{"key": "k"},
+		},
+		Trace: map[string]any{
+			"db":      map[string]any{"host": "app.dcfg.yml", "pass": "delete:app.dcfg.yml > encrypted:app.dcfg.yml.enc"},
+			"tokens":  []any{"app.dcfg.yml", "encrypted:app.dcfg.yml.enc"},
+			"secrets": "encrypted:app.dcfg.yml.enc",
+		},
+	}
+	value := config.Inspect().Value
+	if db := value["db"].(map[string]any); db["host"] != "localhost" || db["pass"] != SecretMask {
+		t.Fatal("db", db)
+	}
+	if tokens := value["tokens"].([]any); tokens[0] != "a" || tokens[1] != SecretMask {
+		t.Fatal("tokens", tokens)
+	}
+	if secrets := value["secrets"].(map[string]any); secrets["key"] != SecretMask {
+		t.Fatal("secrets", secrets)
+	}
+	if config.Value["db"].(map[string]any)["pass"] != "p@ss" {
+		t.Fatal("inspection should not change the value", config.Value)
+	}
+}
@@ -0,0 +1,52 @@
+package internal
+
+import (
+	. "github.com/orz-dsh/dsh/core/common"
+	. "github.com/orz-dsh/dsh/utils"
+)
+
+// HashOutput returns the hash of the output cache over the full build inputs, the projects, the config and the eval data,
+// the hash is empty if the output can not be cached.
+func (a *ApplicationCore) HashOutput(options MakeArtifactOptions) (string, error) {
+	if err := a.LoadConfig(); err != nil {
+		return "", err
+	}
+	for i := 0; i < len(a.Projects); i++ {
+		if !a.Projects[i].provider.isCacheable() {
+			a.Logger.InfoDesc("output cache disabled",
+				Reason("exec provider declared"),
+				KV("projectName", a.Projects[i].Name),
+			)
+			return "", nil
+		}
+	}
+
+	hasher := NewHasher()
+	hasher.WriteString("runtime_version", string(GetRuntimeVersion()))
+	if options.InspectSerializer != nil {
+		hasher.WriteString("inspect_format", string(options.InspectSerializer.GetFormat()))
+	}
+	for i := 0; i < len(a.Projects); i++ {
+		if err := a.Projects[i].writeHash(hasher); err != nil {
+			return "", ErrW(err, "hash output error",
+				Reason("hash project error"),
+				KV("projectName", a.Projects[i].Name),
+			)
+		}
+	}
+	if err := hasher.WriteValue("config", a.Config.Value); err != nil {
+		return "", ErrW(err, "hash output error",
+			Reason("hash config error"),
+		)
+	}
+	// the templates can read the system and the arguments of the environment, the secrets are hashed by the digests
+	for _, name := range []string{"local", "global"} {
+		if err := hasher.WriteValue(name, a.Evaluator.GetData(name)); err != nil {
+			return "", ErrW(err, "hash output error",
+				Reason("hash eval data error"),
+				KV("name", name),
+			)
+		}
+	}
+	return hasher.Sum(), nil
+}
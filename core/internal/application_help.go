@@ -0,0 +1,118 @@
+package internal
+
+import (
+	. "github.com/orz-dsh/dsh/core/common"
+	. "github.com/orz-dsh/dsh/core/internal/setting"
+	. "github.com/orz-dsh/dsh/utils"
+	"path/filepath"
+	"time"
+)
+
+func (a *ApplicationCore) Help() (*ApplicationHelp, error) {
+	settings := append([]*ProjectSetting{a.MainProjectSetting}, a.AdditionProjectSettings...)
+	dirsDict := map[string]bool{}
+	var projects []*ApplicationHelpProject
+	for i := 0; i < len(settings); i++ {
+		setting := settings[i]
+		if dirsDict[setting.Dir] {
+			continue
+		}
+		dirsDict[setting.Dir] = true
+		projects = append(projects, newApplicationHelpProject(setting))
+
+		evaluator := a.newHelpEvaluator(setting)
+		for j := 0; j < len(setting.Dependency.Items); j++ {
+			item := setting.Dependency.Items[j]
+			// the dependency is listed if the match can not be evaluated without the computed values
+			if matched, err := evaluator.EvalBoolExpr(item.Match); err == nil && !matched {
+				continue
+			}
+			target, err := a.Setting.GetProjectLinkTarget(item.LinkObj)
+			if err != nil {
+				return nil, ErrW(err, "make help error",
+					Reason("resolve project link error"),
+					KV("projectName", setting.Name),
+					KV("link", item.Link),
+				)
+			}
+			if dirsDict[target.Dir] {
+				continue
+			}
+			dependency, err := a.Setting.GetProjectSettingByLinkTarget(target)
+			if err != nil {
+				return nil, ErrW(err, "make help error",
+					Reason("load project setting error"),
+					KV("projectName", setting.Name),
+					KV("link", item.Link),
+				)
+			}
+			settings = append(settings, dependency)
+		}
+	}
+	return NewApplicationHelp(projects), nil
+}
+
+// newHelpEvaluator creates the evaluator of the dependency match, the options are evaluated by the exported, assigned
+// and default values without computing or prompting, since the help is usually shown when the options are not assigned.
+func (a *ApplicationCore) newHelpEvaluator(setting *ProjectSetting) *Evaluator {
+	items := map[string]any{}
+	for i := 0; i < len(setting.Option.Items); i++ {
+		item := setting.Option.Items[i]
+		if value, err := a.Option.Export.GetValue(setting.Name, item); err == nil && value != nil {
+			items[item.Name] = value.Value
+		} else if value, err = a.Option.Assign.GetValue(setting.Name, item); err == nil && value != nil {
+			items[item.Name] = value.Value
+		} else if item.Default != nil {
+			items[item.Name] = item.Default
+		}
+	}
+	return a.Option.Common.NewEvaluator(items)
+}
+
+func newApplicationHelpProject(setting *ProjectSetting) *ApplicationHelpProject {
+	var options []*ApplicationHelpOption
+	for i := 0; i < len(setting.Option.Items); i++ {
+		item := setting.Option.Items[i]
+		if item.Hidden {
+			continue
+		}
+		var choices []*ApplicationHelpChoice
+		for j := 0; j < len(item.Choices); j++ {
+			choice := item.Choices[j]
+			choices = append(choices, NewApplicationHelpChoice(getApplicationHelpValue(item, choice.Value), choice.Usage))
+		}
+		required := !item.Optional && item.Default == nil && item.Compute == ""
+		options = append(options, NewApplicationHelpOption(item.Name, item.Export, string(item.Type), item.Usage, getApplicationHelpValue(item, item.Default), choices, required))
+	}
+	return NewApplicationHelpProject(setting.Name, setting.Dir, options)
+}
+
+func getApplicationHelpValue(setting *ProjectOptionItemSetting, value any) any {
+	if value == nil || setting.IsSecret() {
+		return setting.MaskValue(value)
+	}
+	switch v := value.(type) {
+	case []any:
+		result := make([]any, 0, len(v))
+		for i := 0; i < len(v); i++ {
+			result = append(result, getApplicationHelpItemValue(setting, v[i]))
+		}
+		return result
+	}
+	return getApplicationHelpItemValue(setting, value)
+}
+
+// getApplicationHelpItemValue gets the display value, the paths are shown relative to the project dir as they are written.
+func getApplicationHelpItemValue(setting *ProjectOptionItemSetting, value any) any {
+	switch v := value.(type) {
+	case time.Duration:
+		return v.String()
+	case string:
+		if (setting.Type == ProjectOptionValueTypePath || setting.ItemType == ProjectOptionValueTypePath) && setting.Dir != "" {
+			if rel, err := filepath.Rel(setting.Dir, v); err == nil {
+				return rel
+			}
+		}
+	}
+	return value
+}
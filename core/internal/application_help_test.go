@@ -0,0 +1,133 @@
+package internal
+
+import (
+	"encoding/json"
+	. "github.com/orz-dsh/dsh/core/common"
+	. "github.com/orz-dsh/dsh/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestHelpApplication(t *testing.T) *ApplicationCore {
+	appDir := t.TempDir()
+	devDir := t.TempDir()
+	prodDir := t.TempDir()
+	files := map[string]string{
+		filepath.Join(appDir, "project.yml"): "name: app\n" +
+			"option:\n  items:\n" +
+			"    - name: env\n      usage: the deploy env\n      default: dev\n      choices:\n        - dev\n        - value: prod\n          usage: the production | env\n" +
+			"    - name: conf_dir\n      type: path\n      default: conf\n" +
+			"    - name: timeout\n      type: duration\n      default: 30s\n" +
+			"    - name: token\n      type: secret\n      optional: true\n" +
+			"    - name: region\n      optional: true\n" +
+			"    - name: debug\n      type: bool\n      default: false\n      hidden: true\n" +
+			"dependency:\n  items:\n" +
+			"    - link: dir:" + filepath.ToSlash(devDir) + "\n      match: env == 'dev'\n" +
+			"    - link: dir:" + filepath.ToSlash(prodDir) + "\n      match: env == 'prod'\n",
+		filepath.Join(devDir, "project.yml"):  "name: dev\n",
+		filepath.Join(prodDir, "project.yml"): "name: prod\n",
+	}
+	for file, content := range files {
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(appDir, "conf"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	environment, err := NewEnvironmentCore(NewLogger(LogLevelError), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspace, err := NewWorkspaceCore(environment, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	app, err := NewApplicationCore(workspace, NewApplicationSetting(workspace, nil, nil, OSFileSystem), "dir:"+appDir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return app
+}
+
+func TestApplicationHelp(t *testing.T) {
+	app := newTestHelpApplication(t)
+	help, err := app.Help()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(help.Projects) != 2 || help.Projects[0].Name != "app" || help.Projects[1].Name != "dev" {
+		t.Fatal("the dependency not matched by the default values should not be listed", help.Projects)
+	}
+	options := map[string]*ApplicationHelpOption{}
+	for _, option := range help.Projects[0].Options {
+		options[option.Name] = option
+	}
+	if len(options) != 5 || options["debug"] != nil {
+		t.Fatal("the hidden option should not be listed", options)
+	}
+	if options["conf_dir"].Default != "conf" {
+		t.Fatal("the path default should be relative to the project dir", options["conf_dir"].Default)
+	}
+	if options["timeout"].Default != "30s" || options["token"].Default != nil || options["region"].Required {
+		t.Fatal("option defaults mismatch", options["timeout"], options["token"], options["region"])
+	}
+}
+
+func TestApplicationHelpRender(t *testing.T) {
+	app := newTestHelpApplication(t)
+	help, err := app.Help()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, err := help.Render(ApplicationHelpFormatText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"app options:\n  env (string, default: dev)\n    the deploy env\n    export: app.env\n    choices:\n      dev\n      prod - the production | env\n",
+		"  conf_dir (path, default: conf)\n",
+		"  timeout (duration, default: 30s)\n",
+		"  region (string, optional)\n",
+		"\ndev options:\n  (none)\n",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Fatal("text help mismatch", expected, text)
+		}
+	}
+
+	markdown, err := help.Render(ApplicationHelpFormatMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"## app\n\n| Name | Export | Type | Required | Default | Choices | Usage |\n| --- | --- | --- | --- | --- | --- | --- |\n",
+		"| `env` | `app.env` | string | no | `dev` | `dev`, `prod` (the production \\| env) | the deploy env |\n",
+		"| `conf_dir` | `app.conf_dir` | path | no | `conf` |  |  |\n",
+		"\n## dev\n\nNo options.\n",
+	} {
+		if !strings.Contains(markdown, expected) {
+			t.Fatal("markdown help mismatch", expected, markdown)
+		}
+	}
+
+	data, err := help.Render(ApplicationHelpFormatJson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := &ApplicationHelp{}
+	if err = json.Unmarshal([]byte(data), loaded); err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Projects) != 2 || loaded.Projects[0].Options[0].Name != "env" || loaded.Projects[0].Options[0].Choices[1].Usage != "the production | env" {
+		t.Fatal("json help mismatch", data)
+	}
+
+	if _, err = help.Render("html"); err == nil {
+		t.Fatal("unsupported format should fail")
+	}
+}
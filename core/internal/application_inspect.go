@@ -3,6 +3,7 @@ package internal
 import (
 	"fmt"
 	. "github.com/orz-dsh/dsh/core/inspection"
+	. "github.com/orz-dsh/dsh/core/internal/setting"
 	. "github.com/orz-dsh/dsh/utils"
 	"os"
 	"path/filepath"
@@ -13,7 +14,7 @@ func (a *ApplicationCore) Inspect() (*ApplicationInspection, error) {
 		// TODO: error
 		return nil, err
 	}
-	if err := a.loadProjects(); err != nil {
+	if err := a.LoadProjects(); err != nil {
 		return nil, ErrW(err, "inspect application error",
 			Reason("load projects error"),
 			// TODO: error
@@ -30,14 +31,27 @@ func (a *ApplicationCore) Inspect() (*ApplicationInspection, error) {
 		dependencyProjects = append(dependencyProjects, a.DependencyProjects[i].Inspect())
 	}
 
+	setting := a.Setting.Inspect()
+	for i := 0; i < len(setting.Argument.Items); i++ {
+		item := setting.Argument.Items[i]
+		if a.Option.Assign.IsSecret(item.Name) {
+			item.Value = ProjectOptionSecretMask
+		}
+	}
+
+	// the config overrides targeting the secret keys are masked like the config values
+	environment := a.Environment.Inspect()
+	a.maskConfigOverrideInspection(environment.Setting.Config)
+	a.maskConfigOverrideInspection(setting.Config)
+
 	inspection := NewApplicationInspection(
-		a.Environment.Inspect(),
+		environment,
 		a.Workspace.Inspect(),
 		NewApplicationVariableInspection(
 			a.Evaluator.GetData("local"),
 			a.Evaluator.GetData("global"),
 		),
-		a.Setting.Inspect(),
+		setting,
 		a.Option.Inspect(),
 		a.Config.Inspect(),
 		a.MainProject.Inspect(),
@@ -47,6 +61,17 @@ func (a *ApplicationCore) Inspect() (*ApplicationInspection, error) {
 	return inspection, nil
 }
 
+func (a *ApplicationCore) maskConfigOverrideInspection(inspection *ConfigOverrideSettingInspection) {
+	if inspection == nil {
+		return
+	}
+	for i := 0; i < len(inspection.Items); i++ {
+		if a.Config.IsSecretOverride(inspection.Items[i].Path) {
+			inspection.Items[i].Value = SecretMask
+		}
+	}
+}
+
 func (a *ApplicationCore) SaveInspection(serializer Serializer, outputDir string) (err error) {
 	inspection, err := a.Inspect()
 	if err != nil {
@@ -56,7 +81,7 @@ func (a *ApplicationCore) SaveInspection(serializer Serializer, outputDir string
 	}
 
 	inspectionPath := filepath.Join(outputDir, "@inspection")
-	if err = os.MkdirAll(inspectionPath, os.ModePerm); err != nil {
+	if err = a.FileSystem.MkdirAll(inspectionPath, os.ModePerm); err != nil {
 		return ErrW(err, "make scripts error",
 			Reason("make inspection dir error"),
 			KV("path", inspectionPath),
@@ -64,7 +89,7 @@ func (a *ApplicationCore) SaveInspection(serializer Serializer, outputDir string
 	}
 
 	environmentInspectionPath := filepath.Join(inspectionPath, "environment"+serializer.GetFileExt())
-	if err = serializer.SerializeFile(environmentInspectionPath, inspection.Environment); err != nil {
+	if err = SerializeFileFS(a.FileSystem, serializer, environmentInspectionPath, inspection.Environment); err != nil {
 		return ErrW(err, "make scripts error",
 			Reason("write environment inspection file error"),
 			KV("path", environmentInspectionPath),
@@ -72,7 +97,7 @@ func (a *ApplicationCore) SaveInspection(serializer Serializer, outputDir string
 	}
 
 	workspaceInspectionPath := filepath.Join(inspectionPath, "workspace"+serializer.GetFileExt())
-	if err = serializer.SerializeFile(workspaceInspectionPath, inspection.Workspace); err != nil {
+	if err = SerializeFileFS(a.FileSystem, serializer, workspaceInspectionPath, inspection.Workspace); err != nil {
 		return ErrW(err, "make scripts error",
 			Reason("write workspace inspection file error"),
 			KV("path", workspaceInspectionPath),
@@ -80,7 +105,7 @@ func (a *ApplicationCore) SaveInspection(serializer Serializer, outputDir string
 	}
 
 	variableInspectionPath := filepath.Join(inspectionPath, "app.variable"+serializer.GetFileExt())
-	if err = serializer.SerializeFile(variableInspectionPath, inspection.Variable); err != nil {
+	if err = SerializeFileFS(a.FileSystem, serializer, variableInspectionPath, inspection.Variable); err != nil {
 		return ErrW(err, "make scripts error",
 			Reason("write variable inspection file error"),
 			KV("path", variableInspectionPath),
@@ -88,7 +113,7 @@ func (a *ApplicationCore) SaveInspection(serializer Serializer, outputDir string
 	}
 
 	settingInspectionPath := filepath.Join(inspectionPath, "app.setting"+serializer.GetFileExt())
-	if err = serializer.SerializeFile(settingInspectionPath, inspection.Setting); err != nil {
+	if err = SerializeFileFS(a.FileSystem, serializer, settingInspectionPath, inspection.Setting); err != nil {
 		return ErrW(err, "make scripts error",
 			Reason("write setting inspection file error"),
 			KV("path", settingInspectionPath),
@@ -96,7 +121,7 @@ func (a *ApplicationCore) SaveInspection(serializer Serializer, outputDir string
 	}
 
 	optionInspectionPath := filepath.Join(inspectionPath, "app.option"+serializer.GetFileExt())
-	if err = serializer.SerializeFile(optionInspectionPath, inspection.Option); err != nil {
+	if err = SerializeFileFS(a.FileSystem, serializer, optionInspectionPath, inspection.Option); err != nil {
 		return ErrW(err, "make scripts error",
 			Reason("write option inspection file error"),
 			KV("path", optionInspectionPath),
@@ -104,7 +129,7 @@ func (a *ApplicationCore) SaveInspection(serializer Serializer, outputDir string
 	}
 
 	configInspectionPath := filepath.Join(inspectionPath, "app.config"+serializer.GetFileExt())
-	if err = serializer.SerializeFile(configInspectionPath, inspection.Config); err != nil {
+	if err = SerializeFileFS(a.FileSystem, serializer, configInspectionPath, inspection.Config); err != nil {
 		return ErrW(err, "make scripts error",
 			Reason("write config inspection file error"),
 			KV("path", configInspectionPath),
@@ -112,7 +137,7 @@ func (a *ApplicationCore) SaveInspection(serializer Serializer, outputDir string
 	}
 
 	mainProjectInspectionPath := filepath.Join(inspectionPath, fmt.Sprintf("project.main.%s%s", inspection.MainProject.Name, serializer.GetFileExt()))
-	if err = serializer.SerializeFile(mainProjectInspectionPath, inspection.MainProject); err != nil {
+	if err = SerializeFileFS(a.FileSystem, serializer, mainProjectInspectionPath, inspection.MainProject); err != nil {
 		return ErrW(err, "make scripts error",
 			Reason("write project inspection error"),
 			KV("project", inspection.MainProject.Name),
@@ -121,7 +146,7 @@ func (a *ApplicationCore) SaveInspection(serializer Serializer, outputDir string
 
 	for i := 0; i < len(inspection.AdditionProjects); i++ {
 		additionProjectInspectionPath := filepath.Join(inspectionPath, fmt.Sprintf("project.a%03d.%s%s", i+1, inspection.AdditionProjects[i].Name, serializer.GetFileExt()))
-		if err = serializer.SerializeFile(additionProjectInspectionPath, inspection.AdditionProjects[i]); err != nil {
+		if err = SerializeFileFS(a.FileSystem, serializer, additionProjectInspectionPath, inspection.AdditionProjects[i]); err != nil {
 			return ErrW(err, "make scripts error",
 				Reason("write project inspection error"),
 				KV("project", inspection.AdditionProjects[i].Name),
@@ -131,7 +156,7 @@ func (a *ApplicationCore) SaveInspection(serializer Serializer, outputDir string
 
 	for i := 0; i < len(inspection.DependencyProjects); i++ {
 		importProjectInspectionPath := filepath.Join(inspectionPath, fmt.Sprintf("project.d%03d.%s%s", i+1, inspection.DependencyProjects[i].Name, serializer.GetFileExt()))
-		if err = serializer.SerializeFile(importProjectInspectionPath, inspection.DependencyProjects[i]); err != nil {
+		if err = SerializeFileFS(a.FileSystem, serializer, importProjectInspectionPath, inspection.DependencyProjects[i]); err != nil {
 			return ErrW(err, "make scripts error",
 				Reason("write project inspection error"),
 				KV("project", inspection.DependencyProjects[i].Name),
@@ -7,6 +7,7 @@ import (
 	. "github.com/orz-dsh/dsh/utils"
 	"maps"
 	"strings"
+	"time"
 )
 
 // region base
@@ -19,6 +20,7 @@ const (
 	ApplicationOptionResultSourceAssign  ApplicationOptionResultSource = "assign"
 	ApplicationOptionResultSourceCompute ApplicationOptionResultSource = "compute"
 	ApplicationOptionResultSourceDefault ApplicationOptionResultSource = "default"
+	ApplicationOptionResultSourcePrompt  ApplicationOptionResultSource = "prompt"
 )
 
 type ApplicationOptionExportSource string
@@ -27,31 +29,55 @@ const (
 	ApplicationOptionExportSourceAssign  ApplicationOptionExportSource = "assign"
 	ApplicationOptionExportSourceCompute ApplicationOptionExportSource = "compute"
 	ApplicationOptionExportSourceDefault ApplicationOptionExportSource = "default"
+	ApplicationOptionExportSourcePrompt  ApplicationOptionExportSource = "prompt"
 )
 
+// inspectApplicationOptionValue returns the value in the inspection, the secret values are masked, and the durations
+// are formatted like `30s`, so the inspection files of all formats can be loaded back with the same values.
+func inspectApplicationOptionValue(value any, secret bool) any {
+	if secret && value != nil {
+		return ProjectOptionSecretMask
+	}
+	switch v := value.(type) {
+	case time.Duration:
+		return v.String()
+	case []any:
+		result := make([]any, 0, len(v))
+		for i := 0; i < len(v); i++ {
+			result = append(result, inspectApplicationOptionValue(v[i], false))
+		}
+		return result
+	}
+	return value
+}
+
 // endregion
 
 // region ApplicationOption
 
 type ApplicationOption struct {
-	Assign *ApplicationOptionAssign
-	Common *ApplicationOptionCommon
-	Export *ApplicationOptionExport
-	Result *ApplicationOptionResult
+	Assign   *ApplicationOptionAssign
+	Common   *ApplicationOptionCommon
+	Export   *ApplicationOptionExport
+	Result   *ApplicationOptionResult
+	logger   *Logger
+	prompter *ApplicationOptionPrompter
 }
 
-func NewApplicationOption(projectName string, system *System, evaluator *Evaluator, assigns map[string]string) *ApplicationOption {
+func NewApplicationOption(logger *Logger, projectName string, system *System, evaluator *Evaluator, assigns map[string]string, prompter *ApplicationOptionPrompter) *ApplicationOption {
 	assign := NewApplicationOptionAssign(projectName, assigns)
 	common := NewApplicationOptionCommon(system, evaluator, assign)
 	return &ApplicationOption{
-		Assign: assign,
-		Common: common,
-		Export: NewApplicationOptionExport(assign),
-		Result: NewApplicationOptionResult(common),
+		Assign:   assign,
+		Common:   common,
+		Export:   NewApplicationOptionExport(assign),
+		Result:   NewApplicationOptionResult(common),
+		logger:   logger,
+		prompter: prompter,
 	}
 }
 
-func (o *ApplicationOption) findResult(projectName string, setting *ProjectOptionItemSetting) (result *ApplicationOptionResultItem, err error) {
+func (o *ApplicationOption) findResult(projectName string, dataset EvalDataset, setting *ProjectOptionItemSetting) (result *ApplicationOptionResultItem, err error) {
 	exportValue, err := o.Export.GetValue(projectName, setting)
 	if err != nil {
 		return nil, err
@@ -62,7 +88,7 @@ func (o *ApplicationOption) findResult(projectName string, setting *ProjectOptio
 		return nil, err
 	}
 
-	computeValue, err := o.Result.GetComputeValue(projectName, setting)
+	computeValue, err := o.Result.GetComputeValue(projectName, dataset, setting)
 	if err != nil {
 		return nil, err
 	}
@@ -70,20 +96,22 @@ func (o *ApplicationOption) findResult(projectName string, setting *ProjectOptio
 	if exportValue != nil {
 		if assignValue != nil && !exportValue.DeepEqual(assignValue) {
 			return nil, ErrN("find option result error",
+				Code(ErrorCodeOptionValueConflict),
 				Reason("export value conflict"),
 				KV("projectName", projectName),
 				KV("optionName", setting.Name),
-				KV("exportValue", exportValue.Value),
-				KV("assignValue", assignValue.Value),
+				KV("exportValue", setting.MaskValue(exportValue.Value)),
+				KV("assignValue", setting.MaskValue(assignValue.Value)),
 			)
 		}
 		if computeValue != nil && !exportValue.DeepEqual(computeValue) {
 			return nil, ErrN("find option result error",
+				Code(ErrorCodeOptionValueConflict),
 				Reason("export value conflict"),
 				KV("projectName", projectName),
 				KV("optionName", setting.Name),
-				KV("exportValue", exportValue.Value),
-				KV("computeValue", computeValue.Value),
+				KV("exportValue", setting.MaskValue(exportValue.Value)),
+				KV("computeValue", setting.MaskValue(computeValue.Value)),
 			)
 		}
 	}
@@ -91,11 +119,12 @@ func (o *ApplicationOption) findResult(projectName string, setting *ProjectOptio
 	if assignValue != nil {
 		if computeValue != nil && !assignValue.DeepEqual(computeValue) {
 			return nil, ErrN("find option result error",
+				Code(ErrorCodeOptionValueConflict),
 				Reason("assign value conflict"),
 				KV("projectName", projectName),
 				KV("optionName", setting.Name),
-				KV("assignValue", assignValue.Value),
-				KV("computeValue", computeValue.Value),
+				KV("assignValue", setting.MaskValue(assignValue.Value)),
+				KV("computeValue", setting.MaskValue(computeValue.Value)),
 			)
 		}
 	}
@@ -114,10 +143,38 @@ func (o *ApplicationOption) findResult(projectName string, setting *ProjectOptio
 	} else if setting.Default != nil {
 		value = setting.Default
 		source = ApplicationOptionResultSourceDefault
+		if err = setting.CheckValue(value); err != nil {
+			return nil, ErrW(err, "find option result error",
+				Reason("check default value error"),
+				KV("projectName", projectName),
+				KV("optionName", setting.Name),
+			)
+		}
+	}
+
+	if setting.IsSecret() {
+		o.Assign.addSecret(projectName, setting)
+	}
+
+	if !setting.Optional && value == nil && o.prompter != nil && o.prompter.IsEnabled() {
+		argumentName := setting.Export
+		if _, exist := o.Assign.Project[projectName]; exist {
+			argumentName = setting.Name
+		}
+		if value, err = o.prompter.Prompt(projectName, argumentName, setting); err != nil {
+			return nil, ErrW(err, "find option result error",
+				Code(ErrorCodeOptionPromptFailed),
+				Reason("prompt option value error"),
+				KV("projectName", projectName),
+				KV("optionName", setting.Name),
+			)
+		}
+		source = ApplicationOptionResultSourcePrompt
 	}
 
 	if !setting.Optional && value == nil {
 		return nil, ErrN("find option result error",
+			Code(ErrorCodeOptionValueEmpty),
 			Reason("option value empty"),
 			KV("projectName", projectName),
 			KV("optionName", setting.Name),
@@ -125,7 +182,14 @@ func (o *ApplicationOption) findResult(projectName string, setting *ProjectOptio
 		)
 	}
 
-	result = NewApplicationOptionResultItem(value, source)
+	o.logger.DebugDesc("find option result",
+		KV("projectName", projectName),
+		KV("optionName", setting.Name),
+		KV("value", setting.MaskValue(value)),
+		KV("source", source),
+	)
+
+	result = NewApplicationOptionResultItem(value, source, setting.IsSecret())
 	if err = o.Result.AddItem(projectName, setting.Name, result); err != nil {
 		return nil, ErrW(err, "find option result error",
 			Reason("add option result error"),
@@ -152,9 +216,12 @@ func (o *ApplicationOption) Inspect() *ApplicationOptionInspection {
 // region ApplicationOptionAssign
 
 type ApplicationOptionAssign struct {
-	Common  map[string]string
-	Export  map[string]string
-	Project map[string]map[string]string
+	Common         map[string]string
+	Export         map[string]string
+	Project        map[string]map[string]string
+	projectName    string
+	exportSecrets  map[string]bool
+	projectSecrets map[string]map[string]bool
 }
 
 func NewApplicationOptionAssign(projectName string, assigns map[string]string) *ApplicationOptionAssign {
@@ -176,9 +243,42 @@ func NewApplicationOptionAssign(projectName string, assigns map[string]string) *
 		Project: map[string]map[string]string{
 			projectName: project,
 		},
+		projectName:    projectName,
+		exportSecrets:  map[string]bool{},
+		projectSecrets: map[string]map[string]bool{},
 	}
 }
 
+// addSecret marks the export name and the option name of the project secret, the option names are scoped by the project,
+// so the same option name of other projects is not masked.
+func (a *ApplicationOptionAssign) addSecret(projectName string, setting *ProjectOptionItemSetting) {
+	a.exportSecrets[setting.Export] = true
+	if a.projectSecrets[projectName] == nil {
+		a.projectSecrets[projectName] = map[string]bool{}
+	}
+	a.projectSecrets[projectName][setting.Name] = true
+}
+
+// IsSecret checks the argument name, the names without dot are the option names of the main project.
+func (a *ApplicationOptionAssign) IsSecret(key string) bool {
+	if strings.Contains(key, ".") {
+		return a.exportSecrets[key]
+	}
+	return a.projectSecrets[a.projectName][key]
+}
+
+func (a *ApplicationOptionAssign) maskItems(items map[string]string, secrets map[string]bool) map[string]string {
+	result := make(map[string]string, len(items))
+	for k, v := range items {
+		if secrets[k] {
+			result[k] = ProjectOptionSecretMask
+		} else {
+			result[k] = v
+		}
+	}
+	return result
+}
+
 func (a *ApplicationOptionAssign) GetValue(projectName string, setting *ProjectOptionItemSetting) (*Value, error) {
 	var value *Value
 	if items, exist1 := a.Project[projectName]; exist1 {
@@ -186,10 +286,11 @@ func (a *ApplicationOptionAssign) GetValue(projectName string, setting *ProjectO
 			assignValue, err := setting.ParseValue(item)
 			if err != nil {
 				return nil, ErrW(err, "find option result error",
+					Code(ErrorCodeOptionValueInvalid),
 					Reason("parse argument value error"),
 					KV("projectName", projectName),
 					KV("optionName", setting.Name),
-					KV("optionValue", assignValue),
+					KV("optionValue", setting.MaskValue(item)),
 				)
 			}
 			value = WrapValue(assignValue)
@@ -222,7 +323,11 @@ func (a *ApplicationOptionAssign) GetProjectItem(projectName, optionName string)
 }
 
 func (a *ApplicationOptionAssign) Inspect() *ApplicationOptionAssignInspection {
-	return NewApplicationOptionAssignInspection(a.Common, a.Export, a.Project)
+	project := make(map[string]map[string]string, len(a.Project))
+	for k, v := range a.Project {
+		project[k] = a.maskItems(v, a.projectSecrets[k])
+	}
+	return NewApplicationOptionAssignInspection(a.Common, a.maskItems(a.Export, a.exportSecrets), project)
 }
 
 // endregion
@@ -320,7 +425,7 @@ func (e *ApplicationOptionExport) GetValue(projectName string, setting *ProjectO
 				KV("projectName", projectName),
 				KV("optionName", setting.Name),
 				KV("optionType", setting.Type),
-				KV("exportValue", export.Value),
+				KV("exportValue", setting.MaskValue(export.Value)),
 				KV("exportType", export.Type),
 			)
 		}
@@ -332,9 +437,10 @@ func (e *ApplicationOptionExport) GetValue(projectName string, setting *ProjectO
 			assignValue, err := setting.ParseValue(assign)
 			if err != nil {
 				return nil, ErrW(err, "find option result error",
+					Code(ErrorCodeOptionValueInvalid),
 					Reason("parse argument value error"),
 					KV("optionName", setting.Name),
-					KV("optionValue", assign),
+					KV("optionValue", setting.MaskValue(assign)),
 				)
 			}
 			export := NewApplicationOptionExportItem(assignValue, setting.Type, ApplicationOptionExportSourceAssign)
@@ -378,7 +484,8 @@ func (ei *ApplicationOptionExportItem) Inspect() *ApplicationOptionExportItemIns
 	for i := 0; i < len(ei.Links); i++ {
 		links = append(links, ei.Links[i].Inspect())
 	}
-	return NewApplicationOptionExportItemInspection(ei.Value, string(ei.Type), string(ei.Source), links)
+	value := inspectApplicationOptionValue(ei.Value, ei.Type == ProjectOptionValueTypeSecret)
+	return NewApplicationOptionExportItemInspection(value, string(ei.Type), string(ei.Source), links)
 }
 
 // endregion
@@ -417,7 +524,7 @@ func NewApplicationOptionResult(common *ApplicationOptionCommon) *ApplicationOpt
 	}
 }
 
-func (r *ApplicationOptionResult) GetComputeValue(projectName string, setting *ProjectOptionItemSetting) (*Value, error) {
+func (r *ApplicationOptionResult) GetComputeValue(projectName string, dataset EvalDataset, setting *ProjectOptionItemSetting) (*Value, error) {
 	if setting.Compute == "" {
 		return nil, nil
 	}
@@ -425,7 +532,7 @@ func (r *ApplicationOptionResult) GetComputeValue(projectName string, setting *P
 	for k, v := range r.Items[projectName] {
 		data[k] = v.Value
 	}
-	value, err := setting.ComputeValue(r.Common.NewEvaluator(data))
+	value, err := setting.ComputeValue(r.Common.NewEvaluator(data).MergeDataset(dataset))
 	if err != nil {
 		return nil, ErrW(err, "get compute value error",
 			KV("projectName", projectName),
@@ -473,17 +580,19 @@ func (r *ApplicationOptionResult) Inspect() *ApplicationOptionResultInspection {
 type ApplicationOptionResultItem struct {
 	Value  any
 	Source ApplicationOptionResultSource
+	Secret bool
 }
 
-func NewApplicationOptionResultItem(value any, source ApplicationOptionResultSource) *ApplicationOptionResultItem {
+func NewApplicationOptionResultItem(value any, source ApplicationOptionResultSource, secret bool) *ApplicationOptionResultItem {
 	return &ApplicationOptionResultItem{
 		Value:  value,
 		Source: source,
+		Secret: secret,
 	}
 }
 
 func (i *ApplicationOptionResultItem) Inspect() *ApplicationOptionResultItemInspection {
-	return NewApplicationOptionResultItemInspection(i.Value, string(i.Source))
+	return NewApplicationOptionResultItemInspection(inspectApplicationOptionValue(i.Value, i.Secret), string(i.Source))
 }
 
 // endregion
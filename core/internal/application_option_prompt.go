@@ -0,0 +1,375 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	. "github.com/orz-dsh/dsh/core/internal/setting"
+	. "github.com/orz-dsh/dsh/utils"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// region base
+
+var applicationOptionPromptAttempts = 3
+
+// endregion
+
+// region ApplicationOptionPrompter
+
+type ApplicationOptionPrompter struct {
+	logger   *Logger
+	terminal *Terminal
+	saveFile string
+}
+
+func NewApplicationOptionPrompter(logger *Logger, terminal *Terminal, saveFile string) (*ApplicationOptionPrompter, error) {
+	if saveFile != "" {
+		path, err := filepath.Abs(saveFile)
+		if err != nil {
+			return nil, ErrW(err, "new option prompter error",
+				Reason("get abs-path error"),
+				KV("saveFile", saveFile),
+			)
+		}
+		saveFile = path
+	}
+	prompter := &ApplicationOptionPrompter{
+		logger:   logger,
+		terminal: terminal,
+		saveFile: saveFile,
+	}
+	return prompter, nil
+}
+
+func (p *ApplicationOptionPrompter) IsEnabled() bool {
+	return p.terminal.IsTerminal()
+}
+
+func (p *ApplicationOptionPrompter) Prompt(projectName, argumentName string, setting *ProjectOptionItemSetting) (any, error) {
+	p.terminal.Print("option %s.%s (%s) is required\n", projectName, setting.Name, setting.Type)
+	if setting.Usage != "" {
+		p.terminal.Print("  %s\n", setting.Usage)
+	}
+	indexPrefix := getApplicationOptionPromptIndexPrefix(setting)
+	for i := 0; i < len(setting.Choices); i++ {
+		choice := setting.Choices[i]
+		if choice.Usage != "" {
+			p.terminal.Print("  %s%d) %v - %s\n", indexPrefix, i+1, choice.Value, choice.Usage)
+		} else {
+			p.terminal.Print("  %s%d) %v\n", indexPrefix, i+1, choice.Value)
+		}
+	}
+
+	for attempt := 1; attempt <= applicationOptionPromptAttempts; attempt++ {
+		raw, err := p.read(setting)
+		if err != nil {
+			return nil, ErrW(err, "prompt option error",
+				Reason("read input error"),
+				KV("projectName", projectName),
+				KV("optionName", setting.Name),
+			)
+		}
+		if raw == "" {
+			p.terminal.Print("value is required\n")
+			continue
+		}
+		value, err := setting.ParseValue(raw)
+		if err != nil {
+			var err_ *Error
+			if errors.As(err, &err_) && len(err_.Details) > 0 {
+				p.terminal.Print("invalid value: %s", err_.Details[0].ToString("", "  "))
+			} else {
+				p.terminal.Print("invalid value: %s\n", err)
+			}
+			continue
+		}
+		failures, err := setting.Rule.Check(nil, setting.Name, value)
+		if err != nil {
+			return nil, ErrW(err, "prompt option error",
+				Reason("check option rule error"),
+				KV("projectName", projectName),
+				KV("optionName", setting.Name),
+			)
+		}
+		if len(failures) > 0 {
+			for i := 0; i < len(failures); i++ {
+				p.terminal.Print("invalid value: %s\n", failures[i].Message)
+			}
+			continue
+		}
+		if err = p.save(argumentName, raw, setting); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+	return nil, ErrN("prompt option error",
+		Reason("too many invalid inputs"),
+		KV("projectName", projectName),
+		KV("optionName", setting.Name),
+		KV("attempts", applicationOptionPromptAttempts),
+	)
+}
+
+func (p *ApplicationOptionPrompter) read(setting *ProjectOptionItemSetting) (string, error) {
+	if setting.IsSecret() {
+		p.terminal.Print("enter value (hidden): ")
+		return p.terminal.ReadSecret()
+	}
+	indexPrefix := getApplicationOptionPromptIndexPrefix(setting)
+	if len(setting.Choices) > 0 {
+		p.terminal.Print("select [%s1-%s%d] or enter value: ", indexPrefix, indexPrefix, len(setting.Choices))
+	} else {
+		p.terminal.Print("enter value: ")
+	}
+	line, err := p.terminal.ReadLine()
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimSpace(line)
+	if str, matched := strings.CutPrefix(line, indexPrefix); matched {
+		if index, err := strconv.Atoi(str); err == nil && index >= 1 && index <= len(setting.Choices) {
+			choice, err := CastToString(setting.Choices[index-1].Value)
+			if err != nil {
+				return "", err
+			}
+			return *choice, nil
+		}
+	}
+	return line, nil
+}
+
+// getApplicationOptionPromptIndexPrefix gets the prefix of the choice index, the index is prefixed by `#` if any choice is a number,
+// so the numeric input is taken as the value instead of the index, such as `2` of the choices `[2, 4, 8]`.
+func getApplicationOptionPromptIndexPrefix(setting *ProjectOptionItemSetting) string {
+	for i := 0; i < len(setting.Choices); i++ {
+		choice, err := CastToString(setting.Choices[i].Value)
+		if err != nil {
+			continue
+		}
+		if _, err = strconv.ParseFloat(*choice, 64); err == nil {
+			return "#"
+		}
+	}
+	return ""
+}
+
+func (p *ApplicationOptionPrompter) save(argumentName, value string, setting *ProjectOptionItemSetting) error {
+	if p.saveFile == "" {
+		return nil
+	}
+	if setting.IsSecret() {
+		p.logger.WarnDesc("prompt answer not saved",
+			Reason("secret option"),
+			KV("optionName", setting.Name),
+			KV("saveFile", p.saveFile),
+		)
+		return nil
+	}
+
+	format := SerializationFormatYaml
+	var data []byte
+	if IsFileExists(p.saveFile) {
+		if format = GetSerializationFormatByFile(p.saveFile); format == "" {
+			return ErrN("save prompt answer error",
+				Reason("file type not supported"),
+				KV("saveFile", p.saveFile),
+			)
+		}
+		var err error
+		if data, err = os.ReadFile(p.saveFile); err != nil {
+			return ErrW(err, "save prompt answer error",
+				Reason("read profile error"),
+				KV("saveFile", p.saveFile),
+			)
+		}
+	} else if fileFormat := GetSerializationFormatByFile(p.saveFile); fileFormat != "" {
+		format = fileFormat
+	}
+
+	// only the argument item is patched, so the comments of yaml and the other fields of the profile are kept
+	var err error
+	if format == SerializationFormatYaml {
+		data, err = setProfileArgumentYaml(data, argumentName, value)
+	} else {
+		data, err = setProfileArgumentMap(data, format, argumentName, value)
+	}
+	if err != nil {
+		return ErrW(err, "save prompt answer error",
+			Reason("patch profile error"),
+			KV("saveFile", p.saveFile),
+		)
+	}
+	if err = os.WriteFile(p.saveFile, data, 0644); err != nil {
+		return ErrW(err, "save prompt answer error",
+			Reason("write profile error"),
+			KV("saveFile", p.saveFile),
+		)
+	}
+	return nil
+}
+
+// setProfileArgumentYaml sets the value of the argument item without match in the yaml node tree, or appends the item.
+func setProfileArgumentYaml(data []byte, name, value string) ([]byte, error) {
+	document := &yaml.Node{}
+	if err := yaml.Unmarshal(data, document); err != nil {
+		return nil, ErrW(err, "set profile argument error",
+			Reason("unmarshal yaml error"),
+		)
+	}
+	if document.Kind == 0 {
+		document.Kind = yaml.DocumentNode
+		document.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, ErrN("set profile argument error",
+			Reason("profile is not a mapping"),
+		)
+	}
+	argument, err := getYamlMappingChild(root, "argument", yaml.MappingNode)
+	if err != nil {
+		return nil, err
+	}
+	items, err := getYamlMappingChild(argument, "items", yaml.SequenceNode)
+	if err != nil {
+		return nil, err
+	}
+	replaced := false
+	for i := 0; i < len(items.Content); i++ {
+		item := items.Content[i]
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		nameNode := findYamlMappingValue(item, "name")
+		matchNode := findYamlMappingValue(item, "match")
+		if nameNode == nil || nameNode.Value != name || (matchNode != nil && matchNode.Value != "") {
+			continue
+		}
+		if valueNode := findYamlMappingValue(item, "value"); valueNode != nil {
+			valueNode.SetString(value)
+		} else {
+			item.Content = append(item.Content, newYamlStringNode("value"), newYamlStringNode(value))
+		}
+		replaced = true
+	}
+	if !replaced {
+		items.Content = append(items.Content, &yaml.Node{
+			Kind: yaml.MappingNode,
+			Tag:  "!!map",
+			Content: []*yaml.Node{
+				newYamlStringNode("name"), newYamlStringNode(name),
+				newYamlStringNode("value"), newYamlStringNode(value),
+			},
+		})
+	}
+
+	buffer := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(YamlSerializerDefault.Indent)
+	if err = encoder.Encode(document); err != nil {
+		return nil, ErrW(err, "set profile argument error",
+			Reason("encode yaml error"),
+		)
+	}
+	return buffer.Bytes(), nil
+}
+
+func findYamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// getYamlMappingChild gets the child node of the key, it is added if not exists or null.
+func getYamlMappingChild(node *yaml.Node, key string, kind yaml.Kind) (*yaml.Node, error) {
+	child := findYamlMappingValue(node, key)
+	if child == nil {
+		child = &yaml.Node{}
+		node.Content = append(node.Content, newYamlStringNode(key), child)
+	}
+	if child.Kind == yaml.ScalarNode && child.Tag == "!!null" {
+		*child = yaml.Node{}
+	}
+	if child.Kind == 0 {
+		child.Kind = kind
+		if kind == yaml.MappingNode {
+			child.Tag = "!!map"
+		} else {
+			child.Tag = "!!seq"
+		}
+	}
+	if child.Kind != kind {
+		return nil, ErrN("set profile argument error",
+			Reason("field type invalid"),
+			KV("field", key),
+		)
+	}
+	return child, nil
+}
+
+func newYamlStringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// setProfileArgumentMap sets the value of the argument item without match in the generic map of toml or json,
+// so the unknown fields are kept, but the comments of toml are not.
+func setProfileArgumentMap(data []byte, format SerializationFormat, name, value string) ([]byte, error) {
+	profile := map[string]any{}
+	if len(data) > 0 {
+		var err error
+		if format == SerializationFormatToml {
+			err = toml.Unmarshal(data, &profile)
+		} else {
+			err = json.Unmarshal(data, &profile)
+		}
+		if err != nil {
+			return nil, ErrW(err, "set profile argument error",
+				Reason("unmarshal error"),
+				KV("format", format),
+			)
+		}
+	}
+	argument, ok := profile["argument"].(map[string]any)
+	if !ok {
+		if profile["argument"] != nil {
+			return nil, ErrN("set profile argument error",
+				Reason("field type invalid"),
+				KV("field", "argument"),
+			)
+		}
+		argument = map[string]any{}
+		profile["argument"] = argument
+	}
+	items, ok := argument["items"].([]any)
+	if !ok && argument["items"] != nil {
+		return nil, ErrN("set profile argument error",
+			Reason("field type invalid"),
+			KV("field", "argument.items"),
+		)
+	}
+	replaced := false
+	for i := 0; i < len(items); i++ {
+		item, ok := items[i].(map[string]any)
+		if !ok || item["name"] != name || (item["match"] != nil && item["match"] != "") {
+			continue
+		}
+		item["value"] = value
+		replaced = true
+	}
+	if !replaced {
+		items = append(items, map[string]any{"name": name, "value": value})
+	}
+	argument["items"] = items
+	return GetSerializer(format).Serialize(profile)
+}
+
+// endregion
@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"encoding/json"
+	. "github.com/orz-dsh/dsh/core/internal/setting"
+	. "github.com/orz-dsh/dsh/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetProfileArgumentYaml(t *testing.T) {
+	data := `# profile of the team
+argument:
+  items:
+    # the region of the deployment
+    - name: region
+      value: us
+    - name: region
+      value: eu
+      match: _os == "linux"
+unknown: kept
+`
+	result, err := setProfileArgumentYaml([]byte(data), "region", "ap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `# profile of the team
+argument:
+  items:
+    # the region of the deployment
+    - name: region
+      value: ap
+    - name: region
+      value: eu
+      match: _os == "linux"
+unknown: kept
+`
+	if string(result) != expected {
+		t.Fatal("replace item\n" + string(result))
+	}
+
+	result, err = setProfileArgumentYaml(result, "zone", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(result), "    - name: zone\n      value: a\nunknown: kept\n") || !strings.HasPrefix(string(result), "# profile of the team\n") {
+		t.Fatal("append item\n" + string(result))
+	}
+
+	result, err = setProfileArgumentYaml(nil, "zone", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(result) != "argument:\n  items:\n    - name: zone\n      value: a\n" {
+		t.Fatal("empty profile\n" + string(result))
+	}
+
+	if _, err = setProfileArgumentYaml([]byte("argument: []\n"), "zone", "a"); err == nil {
+		t.Fatal("invalid argument field should fail")
+	}
+}
+
+func TestSetProfileArgumentMap(t *testing.T) {
+	data := `{"argument":{"items":[{"name":"region","value":"us"}]},"unknown":{"key":"kept"}}`
+	result, err := setProfileArgumentMap([]byte(data), SerializationFormatJson, "region", "ap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile := map[string]any{}
+	if err = json.Unmarshal(result, &profile); err != nil {
+		t.Fatal(err)
+	}
+	items := profile["argument"].(map[string]any)["items"].([]any)
+	if len(items) != 1 || items[0].(map[string]any)["value"] != "ap" {
+		t.Fatal("replace item", string(result))
+	}
+	if profile["unknown"].(map[string]any)["key"] != "kept" {
+		t.Fatal("unknown field", string(result))
+	}
+
+	data = "unknown = \"kept\"\n\n[[argument.items]]\nname = \"region\"\nvalue = \"us\"\n"
+	result, err = setProfileArgumentMap([]byte(data), SerializationFormatToml, "zone", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(result), "unknown = 'kept'") || !strings.Contains(string(result), "name = 'zone'") || !strings.Contains(string(result), "value = 'us'") {
+		t.Fatal("append item\n" + string(result))
+	}
+}
+
+func newTestOptionPrompter(t *testing.T, input string) *ApplicationOptionPrompter {
+	file := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(file, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+	reader, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = reader.Close() })
+	prompter, err := NewApplicationOptionPrompter(NewLogger(LogLevelError), NewTerminal(reader, &strings.Builder{}), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return prompter
+}
+
+func TestApplicationOptionPromptChoices(t *testing.T) {
+	setting := NewProjectOptionItemSetting("size", CastTypeInteger, "", "", "", false, "", false, false, nil)
+	setting.Choices = []*ProjectOptionChoiceSetting{
+		NewProjectOptionChoiceSetting(2, ""),
+		NewProjectOptionChoiceSetting(4, ""),
+		NewProjectOptionChoiceSetting(8, ""),
+	}
+	// the numeric input is the value of the numeric choices, and the index is prefixed by `#`
+	prompter := newTestOptionPrompter(t, "2\n#3\n")
+	for _, expect := range []string{"2", "8"} {
+		if value, err := prompter.read(setting); err != nil || value != expect {
+			t.Fatal("numeric choice", value, expect, err)
+		}
+	}
+
+	setting = NewProjectOptionItemSetting("env", CastTypeString, "", "", "", false, "", false, false, nil)
+	setting.Choices = []*ProjectOptionChoiceSetting{
+		NewProjectOptionChoiceSetting("dev", ""),
+		NewProjectOptionChoiceSetting("prod", ""),
+	}
+	prompter = newTestOptionPrompter(t, "2\nstaging\n")
+	for _, expect := range []string{"prod", "staging"} {
+		if value, err := prompter.read(setting); err != nil || value != expect {
+			t.Fatal("string choice", value, expect, err)
+		}
+	}
+}
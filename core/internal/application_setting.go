@@ -19,11 +19,13 @@ type ApplicationSetting struct {
 	Executor       *ExecutorSetting
 	Registry       *RegistrySetting
 	Redirect       *RedirectSetting
+	Config         *ConfigOverrideSetting
+	FileSystem     FileSystem
 	projectsByPath map[string]*ProjectSetting
 	projectsByName map[string]*ProjectSetting
 }
 
-func NewApplicationSetting(workspace *WorkspaceCore, profiles []*ProfileSetting) *ApplicationSetting {
+func NewApplicationSetting(workspace *WorkspaceCore, profiles []*ProfileSetting, config *ConfigOverrideSetting, fileSystem FileSystem) *ApplicationSetting {
 	argument := NewProfileArgumentSetting(nil)
 	addition := NewProfileAdditionSetting(nil)
 	executor := NewExecutorSetting(nil)
@@ -40,6 +42,12 @@ func NewApplicationSetting(workspace *WorkspaceCore, profiles []*ProfileSetting)
 	executor.Merge(workspace.Setting.Executor)
 	registry.Merge(workspace.Setting.Registry)
 	redirect.Merge(workspace.Setting.Redirect)
+	// the overrides of the builder are merged after the overrides of the environment, so they take precedence
+	configOverride := NewConfigOverrideSetting(nil)
+	configOverride.Merge(workspace.Environment.Setting.Config)
+	if config != nil {
+		configOverride.Merge(config)
+	}
 
 	profile := &ApplicationSetting{
 		Logger:         workspace.Logger,
@@ -49,6 +57,8 @@ func NewApplicationSetting(workspace *WorkspaceCore, profiles []*ProfileSetting)
 		Executor:       executor,
 		Registry:       registry,
 		Redirect:       redirect,
+		Config:         configOverride,
+		FileSystem:     fileSystem,
 		projectsByPath: map[string]*ProjectSetting{},
 		projectsByName: map[string]*ProjectSetting{},
 	}
@@ -91,23 +101,26 @@ func (s *ApplicationSetting) GetProjectLinkTarget(link *ProjectLink) (target *Pr
 		}
 		if registryLink == nil {
 			return nil, ErrN("resolve project link error",
+				Code(ErrorCodeLinkRegistryNotFound),
 				Reason("registry not found"),
 				KV("link", link),
 			)
 		}
 		finalLink = registryLink
 	}
+	// the tag of the oci link is resolved by the registry, and the local archive without the sha256 is hashed,
+	// so they are resolved after redirecting to support the pinned redirects offline and the redirects of missing archives
 	path := ""
-	if finalLink.Dir != nil {
-		path = finalLink.Dir.Path
-	} else if finalLink.Git != nil {
-		path = s.Workspace.GetGitProjectDir(finalLink.Git.ParsedUrl, finalLink.Git.ParsedRef)
-	} else {
-		Impossible()
+	resources := []string{finalLink.Normalized}
+	if finalLink != link {
+		// the original link is matched before the registry link, so the registry dependencies can be redirected by their own links
+		resources = []string{link.Normalized, finalLink.Normalized}
 	}
-	resources := []string{
-		finalLink.Normalized,
-		"dir:" + path,
+	if (finalLink.Oci == nil || finalLink.Oci.ParsedRef.Digest != "") && (finalLink.Archive == nil || finalLink.Archive.Sha256 != "") {
+		if path, err = s.getProjectLinkDir(finalLink); err != nil {
+			return nil, err
+		}
+		resources = append(resources, "dir:"+path)
 	}
 	redirectLink, _, err := s.GetRedirectLink(resources)
 	if err != nil {
@@ -115,22 +128,44 @@ func (s *ApplicationSetting) GetProjectLinkTarget(link *ProjectLink) (target *Pr
 	}
 	if redirectLink != nil {
 		finalLink = redirectLink
-		if finalLink.Dir != nil {
-			path = finalLink.Dir.Path
-		} else if finalLink.Git != nil {
-			path = s.Workspace.GetGitProjectDir(finalLink.Git.ParsedUrl, finalLink.Git.ParsedRef)
-		} else {
-			Impossible()
+		path = ""
+	}
+	oci := finalLink.Oci
+	if oci != nil {
+		if oci, err = s.Workspace.ResolveOciProject(oci); err != nil {
+			return nil, err
+		}
+		path = s.Workspace.GetOciProjectDir(oci.ParsedRef.Digest)
+	} else if path == "" {
+		if path, err = s.getProjectLinkDir(finalLink); err != nil {
+			return nil, err
 		}
 	}
 	target = &ProjectLinkTarget{
-		Link: link,
-		Dir:  path,
-		Git:  finalLink.Git,
+		Link:    link,
+		Dir:     path,
+		Git:     finalLink.Git,
+		Archive: finalLink.Archive,
+		Oci:     oci,
 	}
 	return target, nil
 }
 
+func (s *ApplicationSetting) getProjectLinkDir(link *ProjectLink) (string, error) {
+	if link.Dir != nil {
+		return link.Dir.Path, nil
+	} else if link.Git != nil {
+		return s.Workspace.GetGitProjectDir(link.Git.ParsedUrl, link.Git.ParsedRef), nil
+	} else if link.Archive != nil {
+		return s.Workspace.GetArchiveProjectDir(link.Archive)
+	} else if link.Oci != nil {
+		return s.Workspace.GetOciProjectDir(link.Oci.ParsedRef.Digest), nil
+	} else {
+		Impossible()
+	}
+	return "", nil
+}
+
 func (s *ApplicationSetting) GetProjectEntityByRawLink(rawLink string) (*ProjectSetting, error) {
 	link, err := ParseProjectLink(rawLink)
 	if err != nil {
@@ -146,14 +181,19 @@ func (s *ApplicationSetting) GetProjectEntityByRawLink(rawLink string) (*Project
 func (s *ApplicationSetting) GetProjectSettingByLinkTarget(target *ProjectLinkTarget) (*ProjectSetting, error) {
 	if target.Git != nil {
 		return s.getProjectEntityByGit(target.Dir, target.Git.Url, target.Git.ParsedUrl, target.Git.Ref, target.Git.ParsedRef)
+	} else if target.Archive != nil {
+		return s.getProjectEntityByArchive(target.Dir, target.Archive)
+	} else if target.Oci != nil {
+		return s.getProjectEntityByOci(target.Dir, target.Oci)
 	} else {
 		return s.getProjectEntityByDir(target.Dir)
 	}
 }
 
 func (s *ApplicationSetting) getProjectEntityByDir(path string) (*ProjectSetting, error) {
-	if !IsDirExists(path) {
+	if !IsDirExistsFS(s.FileSystem, path) {
 		return nil, ErrN("load project setting error",
+			Code(ErrorCodeProjectNotFound),
 			Reason("project dir not exists"),
 			KV("path", path),
 		)
@@ -172,7 +212,7 @@ func (s *ApplicationSetting) getProjectEntityByDir(path string) (*ProjectSetting
 
 	s.Workspace.Logger.DebugDesc("load project setting", KV("path", path))
 	var setting *ProjectSetting
-	if setting, err = LoadProjectSetting(s.Workspace.Logger, path); err != nil {
+	if setting, err = LoadProjectSetting(s.Workspace.Logger, s.FileSystem, path); err != nil {
 		return nil, err
 	}
 	if existSetting, exist := s.projectsByName[setting.Name]; exist {
@@ -212,13 +252,27 @@ func (s *ApplicationSetting) getProjectEntityByGit(path string, rawUrl string, p
 	if path == "" {
 		path = s.Workspace.GetGitProjectDir(parsedUrl, parsedRef)
 	}
+	if setting, exist := s.projectsByPath[path]; exist {
+		// the project is downloaded and loaded by another link of this application
+		return setting, nil
+	}
 	if err = s.Workspace.DownloadGitProject(path, rawUrl, parsedUrl, rawRef, parsedRef); err != nil {
 		return nil, ErrW(err, "load project manifest error",
+			Code(ErrorCodeGitDownloadFailed),
 			Reason("download project error"),
 			KV("url", rawUrl),
 			KV("ref", rawRef),
 		)
 	}
+	lock, err := s.Workspace.LockDir(path, true)
+	if err != nil {
+		return nil, ErrW(err, "load project manifest error",
+			Reason("lock project dir error"),
+			KV("url", rawUrl),
+			KV("ref", rawRef),
+		)
+	}
+	defer s.Workspace.UnlockDir(lock)
 	entity, err = s.getProjectEntityByDir(path)
 	if err != nil {
 		return nil, ErrW(err, "load project manifest error",
@@ -230,6 +284,51 @@ func (s *ApplicationSetting) getProjectEntityByGit(path string, rawUrl string, p
 	return entity, nil
 }
 
+func (s *ApplicationSetting) getProjectEntityByArchive(path string, archive *ProjectLinkArchive) (*ProjectSetting, error) {
+	if err := s.Workspace.DownloadArchiveProject(path, archive); err != nil {
+		return nil, ErrW(err, "load project manifest error",
+			Reason("download project error"),
+			KV("url", archive.Url),
+			KV("file", archive.Path),
+		)
+	}
+	entity, err := s.getProjectEntityByDir(path)
+	if err != nil {
+		return nil, ErrW(err, "load project manifest error",
+			Reason("load manifest error"),
+			KV("url", archive.Url),
+			KV("file", archive.Path),
+		)
+	}
+	return entity, nil
+}
+
+func (s *ApplicationSetting) getProjectEntityByOci(path string, oci *ProjectLinkOci) (*ProjectSetting, error) {
+	if err := s.Workspace.DownloadOciProject(path, oci); err != nil {
+		return nil, ErrW(err, "load project manifest error",
+			Reason("download project error"),
+			KV("ref", oci.Ref),
+		)
+	}
+	entity, err := s.getProjectEntityByDir(path)
+	if err != nil {
+		return nil, ErrW(err, "load project manifest error",
+			Reason("load manifest error"),
+			KV("ref", oci.Ref),
+		)
+	}
+	return entity, nil
+}
+
+func (s *ApplicationSetting) GetDownloadedProjectDirs() (dirs []string) {
+	for path := range s.projectsByPath {
+		if s.Workspace.IsDownloadedProjectDir(path) {
+			dirs = append(dirs, path)
+		}
+	}
+	return dirs
+}
+
 func (s *ApplicationSetting) Inspect() *ApplicationSettingInspection {
 	return NewApplicationSettingInspection(
 		s.Argument.Inspect(),
@@ -237,6 +336,7 @@ func (s *ApplicationSetting) Inspect() *ApplicationSettingInspection {
 		s.Executor.Inspect(),
 		s.Registry.Inspect(),
 		s.Redirect.Inspect(),
+		s.Config.Inspect(),
 	)
 }
 
@@ -33,6 +33,14 @@ func (a *ArtifactCore) ExecuteInChildProcess(targetGlob string) (exitCode int, e
 			KV("targetGlob", targetGlob),
 		)
 	}
+	lock, err := a.Application.Workspace.LockDir(a.OutputDir, true)
+	if err != nil {
+		return -1, ErrW(err, "execute artifact in child process error",
+			Reason("lock output dir error"),
+			KV("targetGlob", targetGlob),
+		)
+	}
+	defer a.Application.Workspace.UnlockDir(lock)
 	exitCode, err = executor.ExecuteInChildProcess()
 	if err != nil {
 		return -1, err
@@ -48,8 +56,24 @@ func (a *ArtifactCore) ExecuteInThisProcess(targetGlob string) (err error) {
 			KV("targetGlob", targetGlob),
 		)
 	}
+	lock, err := a.Application.Workspace.LockDir(a.OutputDir, true)
+	if err != nil {
+		return ErrW(err, "execute artifact in this process error",
+			Reason("lock output dir error"),
+			KV("targetGlob", targetGlob),
+		)
+	}
+	// the lock is held by the replaced process until it exits
+	if err = lock.Inherit(); err != nil {
+		a.Application.Workspace.UnlockDir(lock)
+		return ErrW(err, "execute artifact in this process error",
+			Reason("inherit output dir lock error"),
+			KV("targetGlob", targetGlob),
+		)
+	}
 	err = executor.ExecuteInThisProcess()
 	if err != nil {
+		a.Application.Workspace.UnlockDir(lock)
 		return err
 	}
 	return nil
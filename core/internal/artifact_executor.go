@@ -34,10 +34,11 @@ func NewArtifactExecutor(application *ApplicationCore, name, file string, args [
 }
 
 func (e *ArtifactExecutor) ExecuteInChildProcess() (exitCode int, err error) {
+	logger := e.Application.Logger.Named(LoggerNameExecutor)
 	startTime := time.Now()
 	cmd := exec.Command(e.File, e.Args...)
-	cmd.Stdout = e.Application.Logger.GetInfoWriter()
-	cmd.Stderr = e.Application.Logger.GetErrorWriter()
+	cmd.Stdout = logger.GetInfoWriter()
+	cmd.Stderr = logger.GetErrorWriter()
 	err = cmd.Start()
 	if err != nil {
 		return -1, ErrW(err, "execute artifact in child process error",
@@ -46,7 +47,7 @@ func (e *ArtifactExecutor) ExecuteInChildProcess() (exitCode int, err error) {
 		)
 	}
 	pid := cmd.Process.Pid
-	e.Application.Logger.InfoDesc("execute artifact in child process start",
+	logger.InfoDesc("execute artifact in child process start",
 		KV("executor", e),
 		KV("pid", pid),
 	)
@@ -64,7 +65,7 @@ func (e *ArtifactExecutor) ExecuteInChildProcess() (exitCode int, err error) {
 			)
 		}
 	}
-	e.Application.Logger.InfoDesc("execute artifact in child process finish",
+	logger.InfoDesc("execute artifact in child process finish",
 		KV("elapsed", time.Since(startTime)),
 		KV("exitCode", exitCode),
 	)
@@ -72,8 +73,9 @@ func (e *ArtifactExecutor) ExecuteInChildProcess() (exitCode int, err error) {
 }
 
 func (e *ArtifactExecutor) ExecuteInThisProcess() (err error) {
+	logger := e.Application.Logger.Named(LoggerNameExecutor)
 	execArgs := append([]string{e.Name}, e.Args...)
-	e.Application.Logger.InfoDesc("execute artifact in this process start",
+	logger.InfoDesc("execute artifact in this process start",
 		KV("executor", e),
 		KV("execArgs", execArgs),
 	)
@@ -5,7 +5,9 @@ import (
 	. "github.com/orz-dsh/dsh/core/inspection"
 	. "github.com/orz-dsh/dsh/core/internal/setting"
 	. "github.com/orz-dsh/dsh/utils"
+	"maps"
 	"path/filepath"
+	"strings"
 )
 
 // region EnvironmentCore
@@ -38,6 +40,14 @@ func NewEnvironmentCore(logger *Logger, assigns map[string]string) (*Environment
 		)
 	}
 	core.Setting = setting
+	// the log setting is applied first, so that the later logs of CI can be in json format
+	setting.Log.Apply(logger)
+	global, err := ParseSecretValues(MapAnyByStr(setting.Argument.GetMap()))
+	if err != nil {
+		return nil, ErrW(err, "new environment error",
+			Reason("parse argument secrets error"),
+		)
+	}
 	core.Evaluator = NewEvaluator().
 		SetData("local", map[string]any{
 			"os":                   system.Os,
@@ -49,7 +59,7 @@ func NewEnvironmentCore(logger *Logger, assigns map[string]string) (*Environment
 			"runtime_version":      GetRuntimeVersion(),
 			"runtime_version_code": GetRuntimeVersionCode(),
 		}).
-		SetData("global", MapAnyByStr(setting.Argument.GetMap()))
+		SetData("global", global.(map[string]any))
 
 	return core, nil
 }
@@ -60,10 +70,13 @@ func (e *EnvironmentCore) BuildSetting() (*EnvironmentSetting, error) {
 	})
 	argumentBuilder := builder.SetArgumentSetting()
 	workspaceBuilder := builder.SetWorkspaceSetting()
+	configKeyBuilder := builder.SetConfigKeySetting()
+	logBuilder := builder.SetLogSetting()
 	workspaceProfileItems := EnvironmentVariableParsedItemSlice[*WorkspaceProfileItemSettingModel]{}
 	workspaceExecutorItems := EnvironmentVariableParsedItemSlice[*ExecutorItemSettingModel]{}
 	workspaceRegistryItems := EnvironmentVariableParsedItemSlice[*RegistryItemSettingModel]{}
 	workspaceRedirectItems := EnvironmentVariableParsedItemSlice[*RedirectItemSettingModel]{}
+	configItems := EnvironmentVariableParsedItemSlice[*ConfigOverrideItemSettingModel]{}
 
 	for i := 0; i < len(e.Variable.Items); i++ {
 		item := e.Variable.Items[i]
@@ -77,7 +90,13 @@ func (e *EnvironmentCore) BuildSetting() (*EnvironmentSetting, error) {
 			if err != nil {
 				return nil, err
 			}
-			workspaceBuilder.SetCleanSetting().SetOutputModel(parsed.Value.Output).CommitCleanSetting()
+			workspaceBuilder.SetCleanSetting().SetOutputModel(parsed.Value.Output).SetProjectModel(parsed.Value.Project).CommitCleanSetting()
+		case EnvironmentVariableKindWorkspaceLock:
+			parsed, err := NewEnvironmentVariableParsedItem(item, &WorkspaceLockSettingModel{})
+			if err != nil {
+				return nil, err
+			}
+			workspaceBuilder.SetLockSetting().SetTimeout(parsed.Value.Timeout).CommitLockSetting()
 		case EnvironmentVariableKindWorkspaceProfile:
 			parsed, err := NewEnvironmentVariableParsedItem(item, &WorkspaceProfileItemSettingModel{})
 			if err != nil {
@@ -102,6 +121,26 @@ func (e *EnvironmentCore) BuildSetting() (*EnvironmentSetting, error) {
 				return nil, err
 			}
 			workspaceRedirectItems = append(workspaceRedirectItems, parsed)
+		case EnvironmentVariableKindConfigItem:
+			model, err := ParseConfigOverrideItemSettingModel(item.Value, "environment:"+item.Key)
+			if err != nil {
+				return nil, ErrW(err, "parse environment variable error",
+					Reason("parse config override error"),
+					KV("item", item),
+				)
+			}
+			configItems = append(configItems, &EnvironmentVariableParsedItem[*ConfigOverrideItemSettingModel]{
+				Name:  item.Name,
+				Value: model,
+			})
+		case EnvironmentVariableKindConfigKey:
+			configKeyBuilder.AddKeys(strings.FieldsFunc(item.Value, func(r rune) bool { return r == ',' })...)
+		case EnvironmentVariableKindConfigKeyFile:
+			configKeyBuilder.AddFiles(filepath.SplitList(item.Value)...)
+		case EnvironmentVariableKindLogLevel:
+			logBuilder.SetLevel(item.Value)
+		case EnvironmentVariableKindLogFormat:
+			logBuilder.SetFormat(item.Value)
 		default:
 			e.Logger.WarnDesc("environment variable unknown", KV("item", item))
 		}
@@ -114,6 +153,9 @@ func (e *EnvironmentCore) BuildSetting() (*EnvironmentSetting, error) {
 		SetRegistrySetting().SetItems(workspaceRegistryItems.Sort().GetValues()).CommitRegistrySetting().
 		SetRedirectSetting().SetItems(workspaceRedirectItems.Sort().GetValues()).CommitRedirectSetting().
 		CommitWorkspaceSetting()
+	builder.SetConfigSetting().SetItems(configItems.Sort().GetValues()).CommitConfigSetting()
+	configKeyBuilder.CommitConfigKeySetting()
+	logBuilder.CommitLogSetting()
 
 	model := builder.CommitEnvironmentSetting()
 	setting, err := model.Convert(NewModelHelper(nil, "environment setting", "environment"))
@@ -139,11 +181,22 @@ func (e *EnvironmentCore) Inspect() *EnvironmentInspection {
 			e.System.Username,
 			e.System.HomeDir,
 			e.System.CurrentDir,
-			e.System.Variables,
+			e.getInspectionVariables(),
 		),
 		e.Variable.Inspect(),
 		e.Setting.Inspect(),
 	)
 }
 
+// getInspectionVariables returns the system variables with the config keys and the variables referenced by the env secrets masked.
+func (e *EnvironmentCore) getInspectionVariables() map[string]string {
+	variables := maps.Clone(e.System.Variables)
+	for name := range variables {
+		if name == "DSH_CONFIG_KEY" || IsSecretReferenceKey("env", name) {
+			variables[name] = SecretMask
+		}
+	}
+	return variables
+}
+
 // endregion
@@ -23,10 +23,16 @@ const (
 	EnvironmentVariableKindArgumentItem      EnvironmentVariableKind = "argument_item"
 	EnvironmentVariableKindWorkspaceDir      EnvironmentVariableKind = "workspace_dir"
 	EnvironmentVariableKindWorkspaceClean    EnvironmentVariableKind = "workspace_clean"
+	EnvironmentVariableKindWorkspaceLock     EnvironmentVariableKind = "workspace_lock"
 	EnvironmentVariableKindWorkspaceProfile  EnvironmentVariableKind = "workspace_profile_item"
 	EnvironmentVariableKindWorkspaceExecutor EnvironmentVariableKind = "workspace_executor_item"
 	EnvironmentVariableKindWorkspaceRegistry EnvironmentVariableKind = "workspace_registry_item"
 	EnvironmentVariableKindWorkspaceRedirect EnvironmentVariableKind = "workspace_redirect_item"
+	EnvironmentVariableKindConfigItem        EnvironmentVariableKind = "config_item"
+	EnvironmentVariableKindConfigKey         EnvironmentVariableKind = "config_key"
+	EnvironmentVariableKindConfigKeyFile     EnvironmentVariableKind = "config_key_file"
+	EnvironmentVariableKindLogLevel          EnvironmentVariableKind = "log_level"
+	EnvironmentVariableKindLogFormat         EnvironmentVariableKind = "log_format"
 	EnvironmentVariableKindUnknown           EnvironmentVariableKind = "unknown"
 )
 
@@ -89,6 +95,16 @@ func NewEnvironmentVariableItem(key, value string, source EnvironmentVariableSou
 		kind = EnvironmentVariableKindWorkspaceDir
 	} else if key == "workspace_clean" {
 		kind = EnvironmentVariableKindWorkspaceClean
+	} else if key == "workspace_lock" {
+		kind = EnvironmentVariableKindWorkspaceLock
+	} else if key == "config_key" {
+		kind = EnvironmentVariableKindConfigKey
+	} else if key == "config_key_file" {
+		kind = EnvironmentVariableKindConfigKeyFile
+	} else if key == "log_level" {
+		kind = EnvironmentVariableKindLogLevel
+	} else if key == "log_format" {
+		kind = EnvironmentVariableKindLogFormat
 	} else if str, matched := strings.CutPrefix(key, "argument_item_"); matched {
 		name = str
 		kind = EnvironmentVariableKindArgumentItem
@@ -104,6 +120,9 @@ func NewEnvironmentVariableItem(key, value string, source EnvironmentVariableSou
 	} else if str, matched = strings.CutPrefix(key, "workspace_redirect_item_"); matched {
 		name = str
 		kind = EnvironmentVariableKindWorkspaceRedirect
+	} else if str, matched = strings.CutPrefix(key, "config_item_"); matched {
+		name = str
+		kind = EnvironmentVariableKindConfigItem
 	}
 	return &EnvironmentVariableItem{
 		Key:    key,
@@ -115,7 +134,14 @@ func NewEnvironmentVariableItem(key, value string, source EnvironmentVariableSou
 }
 
 func (i *EnvironmentVariableItem) Inspect() *EnvironmentVariableItemInspection {
-	return NewEnvironmentVariableItemInspection(i.Key, i.Name, i.Value, string(i.Source), string(i.Kind))
+	value := i.Value
+	if i.Kind == EnvironmentVariableKindConfigKey {
+		value = SecretMask
+	} else if i.Source == EnvironmentVariableSourceSystem && IsSecretReferenceKey("env", "DSH_"+strings.ToUpper(i.Key)) {
+		// the system variable is referenced by an env secret, such as `secret:env:DSH_DB_PASS`
+		value = SecretMask
+	}
+	return NewEnvironmentVariableItemInspection(i.Key, i.Name, value, string(i.Source), string(i.Kind))
 }
 
 // endregion
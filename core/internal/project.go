@@ -15,12 +15,16 @@ type Project struct {
 	option     *ProjectOption
 	dependency *ProjectDependency
 	resource   *ProjectResource
+	config     *ProjectConfigSetting
+	provider   *ProjectProvider
+	files      []string
 }
 
 func NewProject(context *ApplicationCore, setting *ProjectSetting, option *ProjectOption) (_ *Project, err error) {
 	context.Logger.InfoDesc("load project", KV("name", setting.Name))
+	provider := NewProjectProvider(context, setting)
 	if option == nil {
-		option, err = NewProjectOption(context, setting)
+		option, err = NewProjectOption(context, setting, provider)
 		if err != nil {
 			return nil, ErrW(err, "load project error",
 				Reason("new project option error"),
@@ -52,10 +56,25 @@ func NewProject(context *ApplicationCore, setting *ProjectSetting, option *Proje
 		option:     option,
 		dependency: dependency,
 		resource:   resource,
+		config:     setting.Config,
+		provider:   provider,
+		files:      setting.Files,
 	}
 	return project, nil
 }
 
+func (e *Project) GetOption() *ProjectOption {
+	return e.option
+}
+
+func (e *Project) GetDependency() *ProjectDependency {
+	return e.dependency
+}
+
+func (e *Project) GetResource() *ProjectResource {
+	return e.resource
+}
+
 func (e *Project) loadImports() error {
 	return e.dependency.load()
 }
@@ -65,7 +84,7 @@ func (e *Project) loadConfigContents() ([]*ProjectResourceConfigItemContent, err
 }
 
 func (e *Project) makeScripts(evaluator *Evaluator, outputPath string, useHardLink bool) ([]string, error) {
-	evaluator = evaluator.SetData("option", e.option.Items)
+	evaluator = evaluator.SetData("option", e.option.Items).MergeDataset(e.provider.Dataset)
 	targetNames, err := e.resource.makeTargetFiles(evaluator, outputPath, useHardLink)
 	if err != nil {
 		return nil, ErrW(err, "make scripts error",
@@ -76,8 +95,30 @@ func (e *Project) makeScripts(evaluator *Evaluator, outputPath string, useHardLi
 	return targetNames, nil
 }
 
+func (e *Project) writeHash(hasher *Hasher) error {
+	hasher.WriteString("project_name", e.Name)
+	hasher.WriteString("project_dir", e.Dir)
+	commit, err := e.context.Workspace.GetGitProjectCommit(e.Dir)
+	if err != nil {
+		return err
+	}
+	hasher.WriteString("project_commit", commit)
+	for i := 0; i < len(e.files); i++ {
+		if err = hasher.WriteFileFS("project_setting", e.context.FileSystem, e.files[i]); err != nil {
+			return err
+		}
+	}
+	if err = hasher.WriteValue("project_option", e.option.Items); err != nil {
+		return err
+	}
+	if err = e.provider.writeHash(hasher); err != nil {
+		return err
+	}
+	return e.resource.writeHash(hasher)
+}
+
 func (e *Project) Inspect() *ProjectInspection {
-	return NewProjectInspection(e.Name, e.Dir, e.option.Inspect(), e.dependency.Inspect(), e.resource.inspect())
+	return NewProjectInspection(e.Name, e.Dir, e.option.Inspect(), e.dependency.Inspect(), e.resource.inspect(), e.config.Inspect())
 }
 
 // endregion
@@ -95,6 +95,11 @@ func NewProjectDependencyItem(context *ApplicationCore, target *common.ProjectLi
 	}
 }
 
+// GetProject returns the dependency project, it is nil before the dependency items are loaded.
+func (e *ProjectDependencyItem) GetProject() *Project {
+	return e.project
+}
+
 func (e *ProjectDependencyItem) load() error {
 	if e.project == nil {
 		if project, err := e.context.loadProjectByTarget(e.Target); err != nil {
@@ -107,12 +112,24 @@ func (e *ProjectDependencyItem) load() error {
 }
 
 func (e *ProjectDependencyItem) Inspect() *ProjectDependencyItemInspection {
-	var gitUrl, gitRef string
+	var gitUrl, gitRef, gitCommit string
 	if e.Target.Git != nil {
 		gitUrl = e.Target.Git.Url
 		gitRef = e.Target.Git.Ref
+		// the commit is recorded to check whether the build can be reproduced, it is empty if the dir is not a repository
+		if commit, err := e.context.Workspace.GetGitProjectCommit(e.Target.Dir); err == nil {
+			gitCommit = commit
+		} else {
+			e.context.Logger.WarnDesc("get git project commit error", KV("dir", e.Target.Dir), KV("error", err))
+		}
+	}
+	inspection := NewProjectDependencyItemInspection(e.Target.Link.Normalized, e.Target.Dir, gitUrl, gitRef, gitCommit)
+	if e.Target.Oci != nil {
+		// the ref is pinned to the digest, so the build can be reproduced even if the tag has moved
+		inspection.OciRef = e.Target.Oci.Ref
+		inspection.OciDigest = e.Target.Oci.ParsedRef.Digest
 	}
-	return NewProjectDependencyItemInspection(e.Target.Link.Normalized, e.Target.Dir, gitUrl, gitRef)
+	return inspection
 }
 
 // endregion
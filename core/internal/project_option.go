@@ -10,14 +10,16 @@ import (
 
 type ProjectOption struct {
 	Items     map[string]any
+	secrets   map[string]bool
 	evaluator *Evaluator
 }
 
-func NewProjectOption(core *ApplicationCore, setting *ProjectSetting) (*ProjectOption, error) {
+func NewProjectOption(core *ApplicationCore, setting *ProjectSetting, provider *ProjectProvider) (*ProjectOption, error) {
 	items := core.Option.Common.copy()
+	secrets := map[string]bool{}
 	for i := 0; i < len(setting.Option.Items); i++ {
 		item := setting.Option.Items[i]
-		result, err := core.Option.findResult(setting.Name, item)
+		result, err := core.Option.findResult(setting.Name, provider.Dataset, item)
 		if err != nil {
 			return nil, ErrW(err, "load project options error",
 				Reason("find option result error"),
@@ -27,28 +29,28 @@ func NewProjectOption(core *ApplicationCore, setting *ProjectSetting) (*ProjectO
 			)
 		}
 		items[item.Name] = result.Value
+		if item.IsSecret() {
+			secrets[item.Name] = true
+		}
 	}
 
-	evaluator := core.Evaluator.SetRootData("option", items)
-	for i := 0; i < len(setting.Option.Checks); i++ {
-		check := setting.Option.Checks[i]
-		result, err := evaluator.EvalBoolExpr(check.Expr)
-		if err != nil {
-			return nil, ErrW(err, "load project options error",
-				Reason("eval check error"),
-				KV("projectName", setting.Name),
-				KV("projectPath", setting.Dir),
-				KV("check", check),
-			)
-		}
-		if !result {
-			return nil, ErrN("load project options error",
-				Reason("check options error"),
-				KV("projectName", setting.Name),
-				KV("projectPath", setting.Dir),
-				KV("check", check),
-			)
-		}
+	evaluator := core.Evaluator.SetRootData("option", items).MergeDataset(provider.Dataset)
+	failures, err := setting.Option.Check(evaluator, items)
+	if err != nil {
+		return nil, ErrW(err, "load project options error",
+			Reason("check options error"),
+			KV("projectName", setting.Name),
+			KV("projectPath", setting.Dir),
+		)
+	}
+	if len(failures) > 0 {
+		return nil, ErrN("load project options error",
+			Code(ErrorCodeOptionCheckFailed),
+			Reason("check options failed"),
+			KV("projectName", setting.Name),
+			KV("projectPath", setting.Dir),
+			KV("failures", failures),
+		)
 	}
 
 	//for i := 0; i < len(setting.Option.Items); i++ {
@@ -69,13 +71,18 @@ func NewProjectOption(core *ApplicationCore, setting *ProjectSetting) (*ProjectO
 
 	option := &ProjectOption{
 		Items:     items,
+		secrets:   secrets,
 		evaluator: evaluator,
 	}
 	return option, nil
 }
 
 func (e *ProjectOption) Inspect() *ProjectOptionInspection {
-	return NewProjectOptionInspection(e.Items)
+	items := make(map[string]any, len(e.Items))
+	for k, v := range e.Items {
+		items[k] = inspectApplicationOptionValue(v, e.secrets[k])
+	}
+	return NewProjectOptionInspection(items)
 }
 
 // endregion
@@ -0,0 +1,26 @@
+package internal
+
+import (
+	. "github.com/orz-dsh/dsh/core/internal/setting"
+	"testing"
+	"time"
+)
+
+func TestProjectOptionInspect(t *testing.T) {
+	option := &ProjectOption{
+		Items: map[string]any{
+			"timeout":  30 * time.Second,
+			"timeouts": []any{time.Second, time.Minute},
+			"password": "plain",
+			"port":     8080,
+		},
+		secrets: map[string]bool{"password": true},
+	}
+	items := option.Inspect().Items
+	if items["timeout"] != "30s" || items["password"] != ProjectOptionSecretMask || items["port"] != 8080 {
+		t.Fatal("inspect items", items)
+	}
+	if timeouts := items["timeouts"].([]any); timeouts[0] != "1s" || timeouts[1] != "1m0s" {
+		t.Fatal("inspect duration array", timeouts)
+	}
+}
@@ -0,0 +1,265 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	. "github.com/orz-dsh/dsh/core/internal/setting"
+	. "github.com/orz-dsh/dsh/utils"
+	"gopkg.in/yaml.v3"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// region ProjectProvider
+
+// ProjectProvider provides the data of the providers declared by the project to the evaluator:
+//   - env: the declared environment variables, such as `env.CI_COMMIT_SHA`;
+//   - file: the content of the included files in the project dir, such as `file.text('VERSION')`;
+//   - exec: the trimmed stdout of the allowed commands run in the project dir, such as `exec.output('git', 'describe')`.
+type ProjectProvider struct {
+	context *ApplicationCore
+	name    string
+	dir     string
+	setting *ProjectProviderSetting
+	Dataset EvalDataset
+}
+
+func NewProjectProvider(context *ApplicationCore, setting *ProjectSetting) *ProjectProvider {
+	provider := &ProjectProvider{
+		context: context,
+		name:    setting.Name,
+		dir:     setting.Dir,
+		setting: setting.Provider,
+		Dataset: EvalDataset{},
+	}
+	if setting.Provider.Env != nil {
+		env := map[string]any{}
+		for i := 0; i < len(setting.Provider.Env.Names); i++ {
+			name := setting.Provider.Env.Names[i]
+			env[name] = context.Environment.System.Variables[name]
+		}
+		provider.Dataset["env"] = env
+	}
+	if setting.Provider.File != nil {
+		provider.Dataset["file"] = map[string]any{
+			"text": provider.readText,
+			"json": provider.readJson,
+			"yaml": provider.readYaml,
+		}
+	}
+	if setting.Provider.Exec != nil {
+		provider.Dataset["exec"] = map[string]any{
+			"output": provider.execOutput,
+		}
+	}
+	return provider
+}
+
+func (p *ProjectProvider) readFile(file string) ([]byte, error) {
+	if filepath.IsAbs(file) {
+		return nil, ErrN("read provider file error",
+			Reason("file must be relative to project dir"),
+			KV("projectName", p.name),
+			KV("file", file),
+		)
+	}
+	rel := filepath.Clean(file)
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, ErrN("read provider file error",
+			Reason("file out of project dir"),
+			KV("projectName", p.name),
+			KV("file", file),
+		)
+	}
+	if !p.setting.File.IsIncluded(rel) {
+		return nil, ErrN("read provider file error",
+			Reason("file not included"),
+			KV("projectName", p.name),
+			KV("file", file),
+			KV("includes", p.setting.File.Includes),
+		)
+	}
+	data, err := p.context.FileSystem.ReadFile(filepath.Join(p.dir, rel))
+	if err != nil {
+		return nil, ErrW(err, "read provider file error",
+			Reason("read file error"),
+			KV("projectName", p.name),
+			KV("file", file),
+		)
+	}
+	return data, nil
+}
+
+func (p *ProjectProvider) readText(file string) (string, error) {
+	data, err := p.readFile(file)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (p *ProjectProvider) readJson(file string) (any, error) {
+	data, err := p.readFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if err = json.Unmarshal(data, &value); err != nil {
+		return nil, ErrW(err, "read provider file error",
+			Reason("unmarshal json error"),
+			KV("projectName", p.name),
+			KV("file", file),
+		)
+	}
+	return value, nil
+}
+
+func (p *ProjectProvider) readYaml(file string) (any, error) {
+	data, err := p.readFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if err = yaml.Unmarshal(data, &value); err != nil {
+		return nil, ErrW(err, "read provider file error",
+			Reason("unmarshal yaml error"),
+			KV("projectName", p.name),
+			KV("file", file),
+		)
+	}
+	return value, nil
+}
+
+// execOutput runs the allowed command in the project dir with the timeout, only the `PATH` and `HOME`
+// environment variables are passed, and the output is cached by the dir and the command line in the application.
+func (p *ProjectProvider) execOutput(name string, args ...string) (string, error) {
+	if !p.setting.Exec.IsAllowed(name) {
+		return "", ErrN("exec provider command error",
+			Reason("command not allowed"),
+			KV("projectName", p.name),
+			KV("command", name),
+			KV("commands", p.setting.Exec.Commands),
+		)
+	}
+	key := strings.Join(append([]string{p.dir, name}, args...), "\x00")
+	return p.context.providerExecCache.get(key, func() (string, error) {
+		if !IsOSFileSystemPath(p.context.FileSystem, p.dir) {
+			return "", ErrN("exec provider command error",
+				Reason("project dir not in os file system"),
+				KV("projectName", p.name),
+				KV("command", name),
+				KV("dir", p.dir),
+			)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), p.setting.Exec.Timeout)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Dir = p.dir
+		cmd.Env = []string{
+			"PATH=" + p.context.Environment.System.Variables["PATH"],
+			"HOME=" + p.context.Environment.System.HomeDir,
+		}
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return "", ErrW(err, "exec provider command error",
+				Reason(ValT(ctx.Err() != nil, "command timeout", "run command error")),
+				KV("projectName", p.name),
+				KV("command", name),
+				KV("args", args),
+				KV("timeout", p.setting.Exec.Timeout),
+				KV("stderr", strings.TrimSpace(stderr.String())),
+			)
+		}
+		p.context.Logger.DebugDesc("exec provider command",
+			KV("projectName", p.name),
+			KV("command", name),
+			KV("args", args),
+		)
+		return strings.TrimSpace(stdout.String()), nil
+	})
+}
+
+// isCacheable checks whether the output can be cached, the outputs of the exec provider are not predictable before making,
+// so the output is not cached if the exec provider is declared.
+func (p *ProjectProvider) isCacheable() bool {
+	return p.setting.Exec == nil
+}
+
+// writeHash writes the env values and the content of all files included by the file provider, since the files read by
+// the templates are not known before making.
+func (p *ProjectProvider) writeHash(hasher *Hasher) error {
+	if env, exist := p.Dataset["env"]; exist {
+		if err := hasher.WriteValue("project_provider_env", env); err != nil {
+			return err
+		}
+	}
+	if p.setting.File != nil {
+		return p.writeFileHash(hasher, "")
+	}
+	return nil
+}
+
+func (p *ProjectProvider) writeFileHash(hasher *Hasher, rel string) error {
+	entries, err := p.context.FileSystem.ReadDir(filepath.Join(p.dir, rel))
+	if err != nil {
+		return ErrW(err, "hash provider files error",
+			Reason("read dir error"),
+			KV("projectName", p.name),
+			KV("dir", rel),
+		)
+	}
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		entryRel := filepath.Join(rel, entry.Name())
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				continue
+			}
+			if err = p.writeFileHash(hasher, entryRel); err != nil {
+				return err
+			}
+		} else if p.setting.File.IsIncluded(entryRel) {
+			hasher.WriteString("project_provider_file", filepath.ToSlash(entryRel))
+			if err = hasher.WriteFileFS("project_provider_file", p.context.FileSystem, filepath.Join(p.dir, entryRel)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// endregion
+
+// region projectProviderExecCache
+
+type projectProviderExecCache struct {
+	lock    sync.Mutex
+	outputs map[string]string
+}
+
+func newProjectProviderExecCache() *projectProviderExecCache {
+	return &projectProviderExecCache{
+		outputs: map[string]string{},
+	}
+}
+
+func (c *projectProviderExecCache) get(key string, run func() (string, error)) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if output, exist := c.outputs[key]; exist {
+		return output, nil
+	}
+	output, err := run()
+	if err != nil {
+		return "", err
+	}
+	c.outputs[key] = output
+	return output, nil
+}
+
+// endregion
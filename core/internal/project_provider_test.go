@@ -0,0 +1,98 @@
+package internal
+
+import (
+	. "github.com/orz-dsh/dsh/core/internal/setting"
+	. "github.com/orz-dsh/dsh/utils"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestProjectProvider(t *testing.T, fsys FileSystem, dir string, setting *ProjectProviderSetting) *ProjectProvider {
+	system, err := GetSystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	context := &ApplicationCore{
+		Logger:            NewLogger(LogLevelError),
+		Environment:       &EnvironmentCore{System: system},
+		FileSystem:        fsys,
+		providerExecCache: newProjectProviderExecCache(),
+	}
+	return NewProjectProvider(context, &ProjectSetting{Name: "app", Dir: dir, Provider: setting})
+}
+
+func TestProjectProviderFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "VERSION"), []byte("1.0.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	provider := newTestProjectProvider(t, OSFileSystem, dir, NewProjectProviderSetting(nil, NewProjectProviderFileSetting([]string{"VERSION"}), nil))
+
+	if text, err := provider.readText("VERSION"); err != nil || text != "1.0.0" {
+		t.Fatal(text, err)
+	}
+	invalids := []string{"secret.txt", "../VERSION", filepath.Join(dir, "VERSION"), "sub/../../VERSION"}
+	for i := 0; i < len(invalids); i++ {
+		if _, err := provider.readText(invalids[i]); err == nil {
+			t.Fatal("file should not be read", invalids[i])
+		}
+	}
+
+	hash1 := NewHasher()
+	if err := provider.writeHash(hash1); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "secret.txt"), []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	hash2 := NewHasher()
+	if err := provider.writeHash(hash2); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "VERSION"), []byte("2.0.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	hash3 := NewHasher()
+	if err := provider.writeHash(hash3); err != nil {
+		t.Fatal(err)
+	}
+	if sum1, sum2, sum3 := hash1.Sum(), hash2.Sum(), hash3.Sum(); sum1 != sum2 || sum2 == sum3 {
+		t.Fatal("hash should only change with the included files", sum1, sum2, sum3)
+	}
+}
+
+func TestProjectProviderExec(t *testing.T) {
+	dir := t.TempDir()
+	provider := newTestProjectProvider(t, OSFileSystem, dir, NewProjectProviderSetting(nil, nil, NewProjectProviderExecSetting([]string{"pwd", "sleep"}, 100*time.Millisecond)))
+	if provider.isCacheable() {
+		t.Fatal("exec provider should disable the output cache")
+	}
+
+	output, err := provider.execOutput("pwd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if real, _ := filepath.EvalSymlinks(dir); output != dir && output != real {
+		t.Fatal("command should run in the project dir", output)
+	}
+	if _, err = provider.execOutput("ls"); err == nil {
+		t.Fatal("command not allowed should fail")
+	}
+	if _, err = provider.execOutput("sleep", "5"); err == nil {
+		t.Fatal("command timeout should fail")
+	}
+
+	fsys := NewMountFileSystem(nil)
+	if err = fsys.Mount(dir, NewMemoryFileSystem()); err != nil {
+		t.Fatal(err)
+	}
+	provider = newTestProjectProvider(t, fsys, dir, NewProjectProviderSetting(nil, nil, NewProjectProviderExecSetting([]string{"pwd"}, time.Second)))
+	if _, err = provider.execOutput("pwd"); err == nil {
+		t.Fatal("command should not run in the dir not in os file system")
+	}
+}
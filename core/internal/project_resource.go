@@ -16,6 +16,9 @@ type projectResourceConfigFormat = SerializationFormat
 
 type projectResourceConfigMergeMode = MapMergeMode
 
+// projectResourceConfigEncryptedLabelPrefix marks the values from the encrypted config files in the config trace.
+const projectResourceConfigEncryptedLabelPrefix = "encrypted:"
+
 // endregion
 
 // region ProjectResource
@@ -51,10 +54,11 @@ func NewProjectResource(context *ApplicationCore, setting *ProjectSetting, optio
 }
 
 func (e *ProjectResource) scan(projectName, dir string, includes, excludes []string, configItemsDict, templateLibItemsDict map[string]bool, filesByTarget map[string]string) error {
-	files, err := ScanFiles(dir, includes, excludes, []FileType{
+	files, err := ScanFilesFS(e.context.FileSystem, dir, includes, excludes, []FileType{
 		FileTypeConfigYaml,
 		FileTypeConfigToml,
 		FileTypeConfigJson,
+		FileTypeConfigEnc,
 		FileTypeTemplate,
 		FileTypeTemplateLib,
 		FileTypePlain,
@@ -75,6 +79,17 @@ func (e *ProjectResource) scan(projectName, dir string, includes, excludes []str
 				configItemsDict[file.Path] = true
 			}
 			continue
+		case FileTypeConfigEnc:
+			if !configItemsDict[file.Path] {
+				configItem := &ProjectResourceConfigItem{
+					File:      file.Path,
+					Format:    GetSerializationFormatByFile(strings.TrimSuffix(file.Path, EncryptedFileExt)),
+					Encrypted: true,
+				}
+				e.ConfigItems = append(e.ConfigItems, configItem)
+				configItemsDict[file.Path] = true
+			}
+			continue
 		case FileTypeTemplateLib:
 			if !templateLibItemsDict[file.Path] {
 				templateLibItem := &ProjectResourceTemplateLibItem{
@@ -125,7 +140,7 @@ func (e *ProjectResource) scan(projectName, dir string, includes, excludes []str
 func (e *ProjectResource) loadConfigFiles() (contents []*ProjectResourceConfigItemContent, err error) {
 	for i := 0; i < len(e.ConfigItems); i++ {
 		config := e.ConfigItems[i]
-		err = config.load()
+		err = config.load(e.context.FileSystem, e.context.Environment.Setting.ConfigKey.Keys)
 		if err != nil {
 			return nil, err
 		}
@@ -135,17 +150,18 @@ func (e *ProjectResource) loadConfigFiles() (contents []*ProjectResourceConfigIt
 }
 
 func (e *ProjectResource) makeTargetFiles(evaluator *Evaluator, outputPath string, useHardLink bool) (targetNames []string, err error) {
+	logger := e.context.Logger.Named(LoggerNameResource)
 	for i := 0; i < len(e.PlainItems); i++ {
 		startTime := time.Now()
 		item := e.PlainItems[i]
 		targetFile := filepath.Join(outputPath, item.Target)
-		e.context.Logger.InfoDesc("make script sources start",
+		logger.InfoDesc("make script sources start",
 			KV("sourceType", FileTypePlain),
 			KV("sourceFile", item.File),
 			KV("targetFile", targetFile),
 		)
 		if useHardLink {
-			err = LinkOrCopyFile(item.File, targetFile)
+			err = LinkOrCopyFileFS(e.context.FileSystem, item.File, targetFile)
 			if err != nil {
 				return nil, ErrW(err, "make script sources error",
 					Reason("link or copy file error"),
@@ -155,7 +171,7 @@ func (e *ProjectResource) makeTargetFiles(evaluator *Evaluator, outputPath strin
 				)
 			}
 		} else {
-			err = CopyFile(item.File, targetFile)
+			err = CopyFileFS(e.context.FileSystem, item.File, targetFile)
 			if err != nil {
 				return nil, ErrW(err, "make script sources error",
 					Reason("copy file error"),
@@ -166,7 +182,7 @@ func (e *ProjectResource) makeTargetFiles(evaluator *Evaluator, outputPath strin
 			}
 		}
 		targetNames = append(targetNames, strings.ReplaceAll(item.Target, "\\", "/"))
-		e.context.Logger.InfoDesc("make script sources finish",
+		logger.InfoDesc("make script sources finish",
 			KV("elapsed", time.Since(startTime)),
 		)
 	}
@@ -178,12 +194,12 @@ func (e *ProjectResource) makeTargetFiles(evaluator *Evaluator, outputPath strin
 		startTime := time.Now()
 		item := e.TemplateItems[i]
 		targetFile := filepath.Join(outputPath, item.Target)
-		e.context.Logger.InfoDesc("make script sources start",
+		logger.InfoDesc("make script sources start",
 			KV("sourceType", FileTypeTemplate),
 			KV("sourceFile", item.File),
 			KV("targetFile", targetFile),
 		)
-		if err = evaluator.EvalFileTemplate(item.File, templateLibFiles, targetFile); err != nil {
+		if err = evaluator.EvalFileTemplateFS(e.context.FileSystem, item.File, templateLibFiles, targetFile); err != nil {
 			return nil, ErrW(err, "make script sources error",
 				Reason("make template error"),
 				KV("sourceType", FileTypeTemplate),
@@ -192,13 +208,39 @@ func (e *ProjectResource) makeTargetFiles(evaluator *Evaluator, outputPath strin
 			)
 		}
 		targetNames = append(targetNames, strings.ReplaceAll(item.Target, "\\", "/"))
-		e.context.Logger.InfoDesc("make script sources finish",
+		logger.InfoDesc("make script sources finish",
 			KV("elapsed", time.Since(startTime)),
 		)
 	}
 	return targetNames, nil
 }
 
+func (e *ProjectResource) writeHash(hasher *Hasher) error {
+	for i := 0; i < len(e.ConfigItems); i++ {
+		if err := hasher.WriteFileFS("config_file", e.context.FileSystem, e.ConfigItems[i].File); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < len(e.TemplateItems); i++ {
+		hasher.WriteString("template_target", e.TemplateItems[i].Target)
+		if err := hasher.WriteFileFS("template_file", e.context.FileSystem, e.TemplateItems[i].File); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < len(e.TemplateLibItems); i++ {
+		if err := hasher.WriteFileFS("template_lib_file", e.context.FileSystem, e.TemplateLibItems[i].File); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < len(e.PlainItems); i++ {
+		hasher.WriteString("plain_target", e.PlainItems[i].Target)
+		if err := hasher.WriteFileFS("plain_file", e.context.FileSystem, e.PlainItems[i].File); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (e *ProjectResource) inspect() *ProjectResourceInspection {
 	var configItems []*ProjectResourceConfigItemInspection
 	for i := 0; i < len(e.ConfigItems); i++ {
@@ -224,14 +266,15 @@ func (e *ProjectResource) inspect() *ProjectResourceInspection {
 // region ProjectResourceConfigItem
 
 type ProjectResourceConfigItem struct {
-	File    string
-	Format  projectResourceConfigFormat
-	content *ProjectResourceConfigItemContent
+	File      string
+	Format    projectResourceConfigFormat
+	Encrypted bool
+	content   *ProjectResourceConfigItemContent
 }
 
-func (e *ProjectResourceConfigItem) load() error {
+func (e *ProjectResourceConfigItem) load(fsys FileSystem, keys []*EncryptionKey) error {
 	if e.content == nil {
-		if content, err := newProjectResourceConfigItemContentEntity(e.File, e.Format); err != nil {
+		if content, err := newProjectResourceConfigItemContentEntity(fsys, e.File, e.Format, e.Encrypted, keys); err != nil {
 			return err
 		} else {
 			e.content = content
@@ -241,7 +284,7 @@ func (e *ProjectResourceConfigItem) load() error {
 }
 
 func (e *ProjectResourceConfigItem) inspect() *ProjectResourceConfigItemInspection {
-	return NewProjectResourceConfigItemInspection(e.File, string(e.Format))
+	return NewProjectResourceConfigItemInspection(e.File, string(e.Format), e.Encrypted)
 }
 
 // endregion
@@ -253,15 +296,35 @@ type ProjectResourceConfigItemContent struct {
 	Merge map[string]projectResourceConfigMergeMode `yaml:"merge,omitempty" toml:"merge,omitempty" json:"merge,omitempty"`
 	Value map[string]any                            `yaml:"value,omitempty" toml:"value,omitempty" json:"value,omitempty"`
 	file  string
+	label string
+}
+
+func (e *ProjectResourceConfigItemContent) DescribeJsonSchema(schema *JsonSchema) {
+	schema.SetDescription("config resource file, such as app.dcfg.yml")
+	schema.Required = nil
+	schema.GetProperty("order").SetDescription("merge order, the content with smaller order is merged first")
+	schema.GetProperty("merge").SetDescription("merge mode of the value fields, the key is the field path or `$root`")
+	schema.GetProperty("merge").AdditionalProperties = NewJsonSchema("string").SetPattern(MapMergeModeCheckRegex.String()).
+		SetDescription("replace, insert, prepend, append, unique, delete, or key:<field> to merge the list items by the key field")
+	schema.GetProperty("value").SetDescription("config value")
 }
 
-func newProjectResourceConfigItemContentEntity(file string, format projectResourceConfigFormat) (*ProjectResourceConfigItemContent, error) {
+func newProjectResourceConfigItemContentEntity(fsys FileSystem, file string, format projectResourceConfigFormat, encrypted bool, keys []*EncryptionKey) (*ProjectResourceConfigItemContent, error) {
 	content := &ProjectResourceConfigItemContent{
 		Merge: map[string]projectResourceConfigMergeMode{},
 		Value: map[string]any{},
 		file:  file,
+		label: file,
+	}
+	var err error
+	if encrypted {
+		// the values from the encrypted files are marked in the config trace
+		content.label = projectResourceConfigEncryptedLabelPrefix + file
+		_, err = DeserializeEncryptedFileFS(fsys, file, format, keys, content)
+	} else {
+		_, err = DeserializeFileFS(fsys, file, format, content)
 	}
-	if _, err := DeserializeFile(file, format, content); err != nil {
+	if err != nil {
 		return nil, ErrW(err, "load config sources error",
 			Reason("deserialize error"),
 			KV("file", file),
@@ -269,10 +332,7 @@ func newProjectResourceConfigItemContentEntity(file string, format projectResour
 		)
 	}
 	for k, v := range content.Merge {
-		switch v {
-		case MapMergeModeReplace:
-		case MapMergeModeInsert:
-		default:
+		if !v.IsValid() {
 			return nil, ErrN("load config sources error",
 				Reason("merge mode invalid"),
 				KV("file", file),
@@ -285,7 +345,7 @@ func newProjectResourceConfigItemContentEntity(file string, format projectResour
 }
 
 func (e *ProjectResourceConfigItemContent) merge(configValue map[string]any, configTraces map[string]any) error {
-	if _, _, err := MapMerge(configValue, e.Value, e.Merge, e.file, configTraces); err != nil {
+	if _, _, err := MapMerge(configValue, e.Value, e.Merge, e.label, configTraces); err != nil {
 		return ErrW(err, "merge config content error",
 			KV("file", e.file),
 		)
@@ -0,0 +1,81 @@
+package setting
+
+import (
+	. "github.com/orz-dsh/dsh/core/inspection"
+	. "github.com/orz-dsh/dsh/utils"
+)
+
+// region ConfigKeySetting
+
+// ConfigKeySetting contains the keys to decrypt the encrypted config files, such as `app.dcfg.yml.enc`,
+// the key of an encrypted file is matched by the key id, so the old keys can be kept while rotating keys.
+type ConfigKeySetting struct {
+	Keys []*EncryptionKey
+}
+
+func NewConfigKeySetting(keys []*EncryptionKey) *ConfigKeySetting {
+	return &ConfigKeySetting{
+		Keys: keys,
+	}
+}
+
+func (s *ConfigKeySetting) Merge(other *ConfigKeySetting) {
+	s.Keys = append(s.Keys, other.Keys...)
+}
+
+func (s *ConfigKeySetting) Inspect() *ConfigKeySettingInspection {
+	var keys []string
+	for i := 0; i < len(s.Keys); i++ {
+		keys = append(keys, s.Keys[i].Id)
+	}
+	return NewConfigKeySettingInspection(keys)
+}
+
+// endregion
+
+// region ConfigKeySettingModel
+
+type ConfigKeySettingModel struct {
+	Keys  []string `yaml:"keys,omitempty" toml:"keys,omitempty" json:"keys,omitempty"`
+	Files []string `yaml:"files,omitempty" toml:"files,omitempty" json:"files,omitempty"`
+}
+
+func NewConfigKeySettingModel(keys, files []string) *ConfigKeySettingModel {
+	return &ConfigKeySettingModel{
+		Keys:  keys,
+		Files: files,
+	}
+}
+
+func (m *ConfigKeySettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("keys").SetDescription("base64 encoded keys of 32 bytes")
+	schema.GetProperty("files").SetDescription("files of the base64 encoded keys, one key per line")
+}
+
+func (m *ConfigKeySettingModel) Convert(helper *ModelHelper) (*ConfigKeySetting, error) {
+	var keys []*EncryptionKey
+	for i := 0; i < len(m.Keys); i++ {
+		if m.Keys[i] == "" {
+			return nil, helper.ChildItem("keys", i).NewValueEmptyError()
+		}
+		key, err := ParseEncryptionKey(m.Keys[i])
+		if err != nil {
+			// the key is masked to avoid leaking it in the error
+			return nil, helper.ChildItem("keys", i).WrapValueInvalidError(err, SecretMask)
+		}
+		keys = append(keys, key)
+	}
+	for i := 0; i < len(m.Files); i++ {
+		if m.Files[i] == "" {
+			return nil, helper.ChildItem("files", i).NewValueEmptyError()
+		}
+		fileKeys, err := ReadEncryptionKeyFile(m.Files[i])
+		if err != nil {
+			return nil, helper.ChildItem("files", i).WrapValueInvalidError(err, m.Files[i])
+		}
+		keys = append(keys, fileKeys...)
+	}
+	return NewConfigKeySetting(keys), nil
+}
+
+// endregion
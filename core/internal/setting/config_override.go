@@ -0,0 +1,158 @@
+package setting
+
+import (
+	. "github.com/orz-dsh/dsh/core/inspection"
+	. "github.com/orz-dsh/dsh/utils"
+	"gopkg.in/yaml.v3"
+	"regexp"
+	"strings"
+)
+
+// region base
+
+var configOverridePathCheckRegex = regexp.MustCompile("^[A-Za-z0-9_-]+(\\.[A-Za-z0-9_-]+)*$")
+
+const configOverridePathPrefix = "config."
+
+// endregion
+
+// region ConfigOverrideSetting
+
+type ConfigOverrideSetting struct {
+	Items []*ConfigOverrideItemSetting
+}
+
+func NewConfigOverrideSetting(items []*ConfigOverrideItemSetting) *ConfigOverrideSetting {
+	return &ConfigOverrideSetting{
+		Items: items,
+	}
+}
+
+func (s *ConfigOverrideSetting) Merge(other *ConfigOverrideSetting) {
+	s.Items = append(s.Items, other.Items...)
+}
+
+func (s *ConfigOverrideSetting) Inspect() *ConfigOverrideSettingInspection {
+	var items []*ConfigOverrideItemSettingInspection
+	for i := 0; i < len(s.Items); i++ {
+		items = append(items, s.Items[i].Inspect())
+	}
+	return NewConfigOverrideSettingInspection(items)
+}
+
+// endregion
+
+// region ConfigOverrideItemSetting
+
+type ConfigOverrideItemSetting struct {
+	Path   string
+	Value  any
+	Source string
+}
+
+func NewConfigOverrideItemSetting(path string, value any, source string) *ConfigOverrideItemSetting {
+	return &ConfigOverrideItemSetting{
+		Path:   path,
+		Value:  value,
+		Source: source,
+	}
+}
+
+// GetValueMap returns the value nested by the path, such as `{"db": {"port": 5433}}` for path `db.port`.
+func (s *ConfigOverrideItemSetting) GetValueMap() map[string]any {
+	keys := strings.Split(s.Path, ".")
+	value := map[string]any{keys[len(keys)-1]: s.Value}
+	for i := len(keys) - 2; i >= 0; i-- {
+		value = map[string]any{keys[i]: value}
+	}
+	return value
+}
+
+func (s *ConfigOverrideItemSetting) Inspect() *ConfigOverrideItemSettingInspection {
+	return NewConfigOverrideItemSettingInspection(s.Path, s.Value, s.Source)
+}
+
+// endregion
+
+// region ConfigOverrideSettingModel
+
+type ConfigOverrideSettingModel struct {
+	Items []*ConfigOverrideItemSettingModel `yaml:"items,omitempty" toml:"items,omitempty" json:"items,omitempty"`
+}
+
+func NewConfigOverrideSettingModel(items []*ConfigOverrideItemSettingModel) *ConfigOverrideSettingModel {
+	return &ConfigOverrideSettingModel{
+		Items: items,
+	}
+}
+
+func (m *ConfigOverrideSettingModel) Convert(helper *ModelHelper) (*ConfigOverrideSetting, error) {
+	items, err := ConvertChildModels(helper, "items", m.Items)
+	if err != nil {
+		return nil, err
+	}
+	return NewConfigOverrideSetting(items), nil
+}
+
+// endregion
+
+// region ConfigOverrideItemSettingModel
+
+type ConfigOverrideItemSettingModel struct {
+	Path   string `yaml:"path" toml:"path" json:"path"`
+	Value  string `yaml:"value" toml:"value" json:"value"`
+	Source string `yaml:"source,omitempty" toml:"source,omitempty" json:"source,omitempty"`
+}
+
+func NewConfigOverrideItemSettingModel(path, value, source string) *ConfigOverrideItemSettingModel {
+	return &ConfigOverrideItemSettingModel{
+		Path:   path,
+		Value:  value,
+		Source: source,
+	}
+}
+
+// ParseConfigOverrideItemSettingModel parses the expression like `config.db.port=5433`, the `config.` prefix of the path is optional.
+func ParseConfigOverrideItemSettingModel(expr, source string) (*ConfigOverrideItemSettingModel, error) {
+	path, value, found := strings.Cut(expr, "=")
+	if !found {
+		return nil, ErrN("parse config override error",
+			Reason("assignment not found"),
+			KV("expr", expr),
+			KV("source", source),
+		)
+	}
+	return NewConfigOverrideItemSettingModel(strings.TrimSpace(path), value, source), nil
+}
+
+func (m *ConfigOverrideItemSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("path").SetPattern(configOverridePathCheckRegex.String()).SetDescription("config path, such as `db.port`")
+	schema.GetProperty("value").SetDescription("config value, parsed as a yaml value, such as `5433`, `true` or `[a, b]`")
+	schema.GetProperty("source").SetDescription("source recorded in the config trace")
+}
+
+func (m *ConfigOverrideItemSettingModel) Convert(helper *ModelHelper) (*ConfigOverrideItemSetting, error) {
+	path := strings.TrimPrefix(m.Path, configOverridePathPrefix)
+	if path == "" {
+		return nil, helper.Child("path").NewValueEmptyError()
+	}
+	if !configOverridePathCheckRegex.MatchString(path) {
+		return nil, helper.Child("path").NewValueInvalidError(m.Path)
+	}
+
+	// the value is typed by the yaml parsing, an empty value is an empty string
+	var value any = ""
+	if strings.TrimSpace(m.Value) != "" {
+		if err := yaml.Unmarshal([]byte(m.Value), &value); err != nil {
+			return nil, helper.Child("value").WrapValueInvalidError(err, m.Value)
+		}
+	}
+
+	source := m.Source
+	if source == "" {
+		source = helper.Source
+	}
+	return NewConfigOverrideItemSetting(path, value, source), nil
+}
+
+// endregion
@@ -10,23 +10,40 @@ import (
 type EnvironmentSetting struct {
 	Argument  *EnvironmentArgumentSetting
 	Workspace *EnvironmentWorkspaceSetting
+	Config    *ConfigOverrideSetting
+	ConfigKey *ConfigKeySetting
+	Log       *LogSetting
 }
 
-func NewEnvironmentSetting(argument *EnvironmentArgumentSetting, workspace *EnvironmentWorkspaceSetting) *EnvironmentSetting {
+func NewEnvironmentSetting(argument *EnvironmentArgumentSetting, workspace *EnvironmentWorkspaceSetting, config *ConfigOverrideSetting, configKey *ConfigKeySetting, log *LogSetting) *EnvironmentSetting {
 	if argument == nil {
 		argument = NewEnvironmentArgumentSetting(nil)
 	}
 	if workspace == nil {
-		workspace = NewEnvironmentWorkspaceSetting("", nil, nil, nil, nil, nil)
+		workspace = NewEnvironmentWorkspaceSetting("", nil, nil, nil, nil, nil, nil)
+	}
+	if config == nil {
+		config = NewConfigOverrideSetting(nil)
+	}
+	if configKey == nil {
+		configKey = NewConfigKeySetting(nil)
+	}
+	if log == nil {
+		log = NewLogSetting(nil, nil, "")
 	}
 	return &EnvironmentSetting{
 		Argument:  argument,
 		Workspace: workspace,
+		Config:    config,
+		ConfigKey: configKey,
+		Log:       log,
 	}
 }
 
 func (s *EnvironmentSetting) Inspect() *EnvironmentSettingInspection {
-	return NewEnvironmentSettingInspection(s.Argument.Inspect(), s.Workspace.Inspect())
+	inspection := NewEnvironmentSettingInspection(s.Argument.Inspect(), s.Workspace.Inspect(), s.Config.Inspect(), s.ConfigKey.Inspect())
+	inspection.Log = s.Log.Inspect()
+	return inspection
 }
 
 // endregion
@@ -36,12 +53,18 @@ func (s *EnvironmentSetting) Inspect() *EnvironmentSettingInspection {
 type EnvironmentSettingModel struct {
 	Argument  *EnvironmentArgumentSettingModel  `yaml:"argument,omitempty" toml:"argument,omitempty" json:"argument,omitempty"`
 	Workspace *EnvironmentWorkspaceSettingModel `yaml:"workspace,omitempty" toml:"workspace,omitempty" json:"workspace,omitempty"`
+	Config    *ConfigOverrideSettingModel       `yaml:"config,omitempty" toml:"config,omitempty" json:"config,omitempty"`
+	ConfigKey *ConfigKeySettingModel            `yaml:"configKey,omitempty" toml:"configKey,omitempty" json:"configKey,omitempty"`
+	Log       *LogSettingModel                  `yaml:"log,omitempty" toml:"log,omitempty" json:"log,omitempty"`
 }
 
-func NewEnvironmentSettingModel(argument *EnvironmentArgumentSettingModel, workspace *EnvironmentWorkspaceSettingModel) *EnvironmentSettingModel {
+func NewEnvironmentSettingModel(argument *EnvironmentArgumentSettingModel, workspace *EnvironmentWorkspaceSettingModel, config *ConfigOverrideSettingModel, configKey *ConfigKeySettingModel, log *LogSettingModel) *EnvironmentSettingModel {
 	return &EnvironmentSettingModel{
 		Argument:  argument,
 		Workspace: workspace,
+		Config:    config,
+		ConfigKey: configKey,
+		Log:       log,
 	}
 }
 
@@ -60,7 +83,28 @@ func (m *EnvironmentSettingModel) Convert(helper *ModelHelper) (_ *EnvironmentSe
 		}
 	}
 
-	return NewEnvironmentSetting(argument, workspace), nil
+	var config *ConfigOverrideSetting
+	if m.Config != nil {
+		if config, err = m.Config.Convert(helper.Child("config")); err != nil {
+			return nil, err
+		}
+	}
+
+	var configKey *ConfigKeySetting
+	if m.ConfigKey != nil {
+		if configKey, err = m.ConfigKey.Convert(helper.Child("configKey")); err != nil {
+			return nil, err
+		}
+	}
+
+	var log *LogSetting
+	if m.Log != nil {
+		if log, err = m.Log.Convert(helper.Child("log")); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewEnvironmentSetting(argument, workspace, config, configKey, log), nil
 }
 
 // endregion
@@ -10,15 +10,19 @@ import (
 type EnvironmentWorkspaceSetting struct {
 	Dir      string
 	Clean    *WorkspaceCleanSetting
+	Lock     *WorkspaceLockSetting
 	Profile  *WorkspaceProfileSetting
 	Executor *ExecutorSetting
 	Registry *RegistrySetting
 	Redirect *RedirectSetting
 }
 
-func NewEnvironmentWorkspaceSetting(dir string, clean *WorkspaceCleanSetting, profile *WorkspaceProfileSetting, executor *ExecutorSetting, registry *RegistrySetting, redirect *RedirectSetting) *EnvironmentWorkspaceSetting {
+func NewEnvironmentWorkspaceSetting(dir string, clean *WorkspaceCleanSetting, lock *WorkspaceLockSetting, profile *WorkspaceProfileSetting, executor *ExecutorSetting, registry *RegistrySetting, redirect *RedirectSetting) *EnvironmentWorkspaceSetting {
 	if clean == nil {
-		clean = NewWorkspaceCleanSetting(nil)
+		clean = NewWorkspaceCleanSetting(nil, nil)
+	}
+	if lock == nil {
+		lock = NewWorkspaceLockSetting(nil)
 	}
 	if profile == nil {
 		profile = NewWorkspaceProfileSetting(nil)
@@ -35,6 +39,7 @@ func NewEnvironmentWorkspaceSetting(dir string, clean *WorkspaceCleanSetting, pr
 	return &EnvironmentWorkspaceSetting{
 		Dir:      dir,
 		Clean:    clean,
+		Lock:     lock,
 		Profile:  profile,
 		Executor: executor,
 		Registry: registry,
@@ -43,13 +48,14 @@ func NewEnvironmentWorkspaceSetting(dir string, clean *WorkspaceCleanSetting, pr
 }
 
 func (s *EnvironmentWorkspaceSetting) GetWorkspaceSetting() *WorkspaceSetting {
-	return NewWorkspaceSetting(s.Clean, s.Profile, s.Executor, s.Registry, s.Redirect)
+	return NewWorkspaceSetting(s.Clean, s.Lock, s.Profile, s.Executor, s.Registry, s.Redirect)
 }
 
 func (s *EnvironmentWorkspaceSetting) Inspect() *EnvironmentWorkspaceSettingInspection {
 	return NewEnvironmentWorkspaceSettingInspection(
 		s.Dir,
 		s.Clean.Inspect(),
+		s.Lock.Inspect(),
 		s.Profile.Inspect(),
 		s.Executor.Inspect(),
 		s.Registry.Inspect(),
@@ -64,16 +70,18 @@ func (s *EnvironmentWorkspaceSetting) Inspect() *EnvironmentWorkspaceSettingInsp
 type EnvironmentWorkspaceSettingModel struct {
 	Dir      string                        `yaml:"dir,omitempty" toml:"dir,omitempty" json:"dir,omitempty"`
 	Clean    *WorkspaceCleanSettingModel   `yaml:"clean,omitempty" toml:"clean,omitempty" json:"clean,omitempty"`
+	Lock     *WorkspaceLockSettingModel    `yaml:"lock,omitempty" toml:"lock,omitempty" json:"lock,omitempty"`
 	Profile  *WorkspaceProfileSettingModel `yaml:"profile,omitempty" toml:"profile,omitempty" json:"profile,omitempty"`
 	Executor *ExecutorSettingModel         `yaml:"executor,omitempty" toml:"executor,omitempty" json:"executor,omitempty"`
 	Registry *RegistrySettingModel         `yaml:"registry,omitempty" toml:"registry,omitempty" json:"registry,omitempty"`
 	Redirect *RedirectSettingModel         `yaml:"redirect,omitempty" toml:"redirect,omitempty" json:"redirect,omitempty"`
 }
 
-func NewEnvironmentWorkspaceSettingModel(dir string, clean *WorkspaceCleanSettingModel, profile *WorkspaceProfileSettingModel, executor *ExecutorSettingModel, registry *RegistrySettingModel, redirect *RedirectSettingModel) *EnvironmentWorkspaceSettingModel {
+func NewEnvironmentWorkspaceSettingModel(dir string, clean *WorkspaceCleanSettingModel, lock *WorkspaceLockSettingModel, profile *WorkspaceProfileSettingModel, executor *ExecutorSettingModel, registry *RegistrySettingModel, redirect *RedirectSettingModel) *EnvironmentWorkspaceSettingModel {
 	return &EnvironmentWorkspaceSettingModel{
 		Dir:      dir,
 		Clean:    clean,
+		Lock:     lock,
 		Profile:  profile,
 		Executor: executor,
 		Registry: registry,
@@ -89,6 +97,13 @@ func (m *EnvironmentWorkspaceSettingModel) Convert(helper *ModelHelper) (_ *Envi
 		}
 	}
 
+	var lock *WorkspaceLockSetting
+	if m.Lock != nil {
+		if lock, err = m.Lock.Convert(helper.Child("lock")); err != nil {
+			return nil, err
+		}
+	}
+
 	var profile *WorkspaceProfileSetting
 	if m.Profile != nil {
 		if profile, err = m.Profile.Convert(helper.Child("profile")); err != nil {
@@ -117,7 +132,7 @@ func (m *EnvironmentWorkspaceSettingModel) Convert(helper *ModelHelper) (_ *Envi
 		}
 	}
 
-	return NewEnvironmentWorkspaceSetting(m.Dir, clean, profile, executor, registry, redirect), nil
+	return NewEnvironmentWorkspaceSetting(m.Dir, clean, lock, profile, executor, registry, redirect), nil
 }
 
 // endregion
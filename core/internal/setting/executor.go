@@ -201,6 +201,14 @@ type ExecutorItemSettingModel struct {
 	Match string   `yaml:"match,omitempty" toml:"match,omitempty" json:"match,omitempty"`
 }
 
+func (m *ExecutorItemSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("name").SetMinLength(1).SetDescription("executor name")
+	schema.GetProperty("file").SetDescription("executable file")
+	schema.GetProperty("exts").SetDescription("script file extensions")
+	schema.GetProperty("args").SetDescription("executor arguments")
+	schema.GetProperty("match").SetDescription("expression to match, the item is used only when it evaluates to true")
+}
+
 func NewExecutorItemSettingModel(name, file string, exts, args []string, match string) *ExecutorItemSettingModel {
 	return &ExecutorItemSettingModel{
 		Name:  name,
@@ -0,0 +1,92 @@
+package setting
+
+import (
+	. "github.com/orz-dsh/dsh/core/inspection"
+	. "github.com/orz-dsh/dsh/utils"
+)
+
+// region LogSetting
+
+// LogSetting contains the levels and format of the logger, it is usually set by the environment variables
+// DSH_LOG_LEVEL such as `info,git=debug` and DSH_LOG_FORMAT such as `json`.
+type LogSetting struct {
+	Level  *LogLevel
+	Levels map[string]LogLevel
+	Format LogFormat
+}
+
+func NewLogSetting(level *LogLevel, levels map[string]LogLevel, format LogFormat) *LogSetting {
+	if levels == nil {
+		levels = map[string]LogLevel{}
+	}
+	return &LogSetting{
+		Level:  level,
+		Levels: levels,
+		Format: format,
+	}
+}
+
+// Apply sets the levels and format to the logger, the unset fields are not changed.
+func (s *LogSetting) Apply(logger *Logger) {
+	if s.Level != nil {
+		logger.Level = *s.Level
+	}
+	for name, level := range s.Levels {
+		logger.SetNamedLevel(name, level)
+	}
+	if s.Format != "" {
+		logger.SetFormat(s.Format)
+	}
+}
+
+func (s *LogSetting) Inspect() *LogSettingInspection {
+	level := ""
+	if s.Level != nil {
+		level = s.Level.String()
+	}
+	var levels map[string]string
+	if len(s.Levels) > 0 {
+		levels = make(map[string]string, len(s.Levels))
+		for name, l := range s.Levels {
+			levels[name] = l.String()
+		}
+	}
+	return NewLogSettingInspection(level, levels, string(s.Format))
+}
+
+// endregion
+
+// region LogSettingModel
+
+type LogSettingModel struct {
+	Level  string `yaml:"level,omitempty" toml:"level,omitempty" json:"level,omitempty"`
+	Format string `yaml:"format,omitempty" toml:"format,omitempty" json:"format,omitempty"`
+}
+
+func NewLogSettingModel(level, format string) *LogSettingModel {
+	return &LogSettingModel{
+		Level:  level,
+		Format: format,
+	}
+}
+
+func (m *LogSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("level").SetDescription("log levels such as `info,git=debug`, the item without name is the root level")
+	schema.GetProperty("format").SetEnum(string(LogFormatDesc), string(LogFormatText), string(LogFormatJson)).SetDescription("log format")
+}
+
+func (m *LogSettingModel) Convert(helper *ModelHelper) (*LogSetting, error) {
+	level, levels, err := ParseLogLevels(m.Level)
+	if err != nil {
+		return nil, helper.Child("level").WrapValueInvalidError(err, m.Level)
+	}
+	var format LogFormat
+	if m.Format != "" {
+		if format, err = ParseLogFormat(m.Format); err != nil {
+			return nil, helper.Child("format").WrapValueInvalidError(err, m.Format)
+		}
+	}
+	return NewLogSetting(level, levels, format), nil
+}
+
+// endregion
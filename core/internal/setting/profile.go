@@ -46,7 +46,7 @@ func LoadProfileSetting(logger *Logger, file string) (setting *ProfileSetting, e
 			KV("file", file),
 		)
 	}
-	if setting, err = model.Convert(NewModelHelper(logger, "profile setting", metadata.File)); err != nil {
+	if setting, err = model.Convert(NewModelHelper(logger, "profile setting", metadata.File).SetPositions(metadata.Positions)); err != nil {
 		return nil, err
 	}
 	return setting, nil
@@ -64,6 +64,15 @@ type ProfileSettingModel struct {
 	Redirect *RedirectSettingModel        `yaml:"redirect,omitempty" toml:"redirect,omitempty" json:"redirect,omitempty"`
 }
 
+func (m *ProfileSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.SetDescription("profile setting file")
+	schema.GetProperty("argument").SetDescription("arguments assigned to options")
+	schema.GetProperty("addition").SetDescription("additional projects")
+	schema.GetProperty("executor").SetDescription("script executors")
+	schema.GetProperty("registry").SetDescription("project registries")
+	schema.GetProperty("redirect").SetDescription("project link redirects")
+}
+
 func NewProfileSettingModel(argument *ProfileArgumentSettingModel, addition *ProfileAdditionSettingModel, executor *ExecutorSettingModel, registry *RegistrySettingModel, redirect *RedirectSettingModel) *ProfileSettingModel {
 	return &ProfileSettingModel{
 		Argument: argument,
@@ -53,7 +53,7 @@ func (s *ProfileAdditionSetting) GetProjectSettings(evaluator *Evaluator) ([]*Pr
 			)
 		}
 
-		result = append(result, NewProjectSetting(item.Name, path, nil, nil, item.Dependency, item.Resource))
+		result = append(result, NewProjectSetting(item.Name, path, nil, nil, item.Dependency, item.Resource, nil, nil))
 	}
 	return result, nil
 }
@@ -132,6 +132,12 @@ type ProfileAdditionItemSettingModel struct {
 	Resource   *ProjectResourceSettingModel   `yaml:"resource,omitempty" toml:"resource,omitempty" json:"resource,omitempty"`
 }
 
+func (m *ProfileAdditionItemSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("name").SetPattern(projectNameCheckRegex.String()).SetDescription("project name")
+	schema.GetProperty("dir").SetMinLength(1).SetDescription("project dir")
+	schema.GetProperty("match").SetDescription("expression to match, the item is used only when it evaluates to true")
+}
+
 func NewProfileAdditionItemSettingModel(name, dir, match string, dependency *ProjectDependencySettingModel, resource *ProjectResourceSettingModel) *ProfileAdditionItemSettingModel {
 	return &ProfileAdditionItemSettingModel{
 		Name:       name,
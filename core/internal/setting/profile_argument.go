@@ -112,6 +112,13 @@ type ProfileArgumentItemSettingModel struct {
 	Match string `yaml:"match,omitempty" toml:"match,omitempty" json:"match,omitempty"`
 }
 
+func (m *ProfileArgumentItemSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("name").SetPattern(profileArgumentNameCheckRegex1.String() + "|" + profileArgumentNameCheckRegex2.String()).SetDescription("option name or export name")
+	schema.GetProperty("value").SetDescription("argument value in string form")
+	schema.GetProperty("value").Type = []string{"string", "number", "boolean"}
+	schema.GetProperty("match").SetDescription("expression to match, the item is used only when it evaluates to true")
+}
+
 func NewProfileArgumentItemSettingModel(name, value, match string) *ProfileArgumentItemSettingModel {
 	return &ProfileArgumentItemSettingModel{
 		Name:  name,
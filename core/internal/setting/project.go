@@ -20,9 +20,12 @@ type ProjectSetting struct {
 	Option     *ProjectOptionSetting
 	Dependency *ProjectDependencySetting
 	Resource   *ProjectResourceSetting
+	Config     *ProjectConfigSetting
+	Provider   *ProjectProviderSetting
+	Files      []string
 }
 
-func NewProjectSetting(name, dir string, runtime *ProjectRuntimeSetting, option *ProjectOptionSetting, dependency *ProjectDependencySetting, resource *ProjectResourceSetting) *ProjectSetting {
+func NewProjectSetting(name, dir string, runtime *ProjectRuntimeSetting, option *ProjectOptionSetting, dependency *ProjectDependencySetting, resource *ProjectResourceSetting, config *ProjectConfigSetting, provider *ProjectProviderSetting) *ProjectSetting {
 	if runtime == nil {
 		runtime = NewProjectRuntimeSetting("", "")
 	}
@@ -35,6 +38,12 @@ func NewProjectSetting(name, dir string, runtime *ProjectRuntimeSetting, option
 	if resource == nil {
 		resource = NewProjectResourceSetting(nil)
 	}
+	if config == nil {
+		config = NewProjectConfigSetting(nil)
+	}
+	if provider == nil {
+		provider = NewProjectProviderSetting(nil, nil, nil)
+	}
 	return &ProjectSetting{
 		Name:       name,
 		Dir:        dir,
@@ -42,19 +51,22 @@ func NewProjectSetting(name, dir string, runtime *ProjectRuntimeSetting, option
 		Option:     option,
 		Dependency: dependency,
 		Resource:   resource,
+		Config:     config,
+		Provider:   provider,
 	}
 }
 
-func LoadProjectSetting(logger *Logger, dir string) (setting *ProjectSetting, err error) {
+func LoadProjectSetting(logger *Logger, fsys FileSystem, dir string) (setting *ProjectSetting, err error) {
 	model := &ProjectSettingModel{}
-	metadata, err := DeserializeDir(dir, []string{"project"}, model, true)
+	metadata, err := DeserializeDirFS(fsys, dir, []string{"project"}, model, true)
 	if err != nil {
 		return nil, ErrW(err, "load project setting error",
+			Code(ErrorCodeProjectSettingFailed),
 			Reason("deserialize error"),
 			KV("dir", dir),
 		)
 	}
-	if setting, err = model.convert(NewModelHelper(logger, "project setting", metadata.File), dir); err != nil {
+	if setting, err = model.convert(NewModelHelper(logger, "project setting", metadata.File).SetPositions(metadata.Positions), fsys, dir); err != nil {
 		return nil, err
 	}
 	return setting, nil
@@ -66,13 +78,28 @@ func LoadProjectSetting(logger *Logger, dir string) (setting *ProjectSetting, er
 
 type ProjectSettingModel struct {
 	Name       string                         `yaml:"name" toml:"name" json:"name"`
+	Includes   []string                       `yaml:"includes,omitempty" toml:"includes,omitempty" json:"includes,omitempty"`
 	Runtime    *ProjectRuntimeSettingModel    `yaml:"runtime,omitempty" toml:"runtime,omitempty" json:"runtime,omitempty"`
 	Option     *ProjectOptionSettingModel     `yaml:"option,omitempty" toml:"option,omitempty" json:"option,omitempty"`
 	Dependency *ProjectDependencySettingModel `yaml:"dependency,omitempty" toml:"dependency,omitempty" json:"dependency,omitempty"`
 	Resource   *ProjectResourceSettingModel   `yaml:"resource,omitempty" toml:"resource,omitempty" json:"resource,omitempty"`
+	Config     *ProjectConfigSettingModel     `yaml:"config,omitempty" toml:"config,omitempty" json:"config,omitempty"`
+	Provider   *ProjectProviderSettingModel   `yaml:"provider,omitempty" toml:"provider,omitempty" json:"provider,omitempty"`
+}
+
+func (m *ProjectSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.SetDescription("project setting file, such as project.yml")
+	schema.GetProperty("name").SetPattern(projectNameCheckRegex.String()).SetDescription("project name")
+	schema.GetProperty("includes").SetDescription("files or glob patterns of project setting fragments to include, relative to the project dir")
+	schema.GetProperty("runtime").SetDescription("runtime version requirement")
+	schema.GetProperty("option").SetDescription("options of the project")
+	schema.GetProperty("dependency").SetDescription("dependencies of the project")
+	schema.GetProperty("resource").SetDescription("resources of the project")
+	schema.GetProperty("config").SetDescription("config schema of the keys owned or read by the project")
+	schema.GetProperty("provider").SetDescription("data providers used by the expressions and templates of the project")
 }
 
-func (m *ProjectSettingModel) convert(helper *ModelHelper, dir string) (_ *ProjectSetting, err error) {
+func (m *ProjectSettingModel) convert(helper *ModelHelper, fsys FileSystem, dir string) (_ *ProjectSetting, err error) {
 	if m.Name == "" {
 		return nil, helper.Child("name").NewValueEmptyError()
 	}
@@ -80,6 +107,8 @@ func (m *ProjectSettingModel) convert(helper *ModelHelper, dir string) (_ *Proje
 		return nil, helper.Child("name").NewValueInvalidError(m.Name)
 	}
 	helper.AddVariable("projectName", m.Name)
+	helper.AddVariable("projectDir", dir)
+	helper.AddVariable("projectFileSystem", fsys)
 
 	var runtime *ProjectRuntimeSetting
 	if m.Runtime != nil {
@@ -88,28 +117,68 @@ func (m *ProjectSettingModel) convert(helper *ModelHelper, dir string) (_ *Proje
 		}
 	}
 
-	var option *ProjectOptionSetting
-	if m.Option != nil {
-		if option, err = m.Option.Convert(helper.Child("option")); err != nil {
+	var provider *ProjectProviderSetting
+	if m.Provider != nil {
+		if provider, err = m.Provider.Convert(helper.Child("provider")); err != nil {
 			return nil, err
 		}
 	}
 
-	var dependency *ProjectDependencySetting
-	if m.Dependency != nil {
-		if dependency, err = m.Dependency.Convert(helper.Child("dependency")); err != nil {
-			return nil, err
-		}
+	fragments, err := loadProjectIncludeFragments(helper, fsys, m)
+	if err != nil {
+		return nil, err
 	}
 
-	var resource *ProjectResourceSetting
-	if m.Resource != nil {
-		if resource, err = m.Resource.Convert(helper.Child("resource")); err != nil {
-			return nil, err
+	// the option names and exports are unique across all fragments, the items are appended in fragment order.
+	optionNamesDict := map[string]bool{}
+	optionExportsDict := map[string]bool{}
+	var optionItems []*ProjectOptionItemSetting
+	var optionChecks []*ProjectOptionCheckSetting
+	var dependencyItems []*ProjectDependencyItemSetting
+	var resourceItems []*ProjectResourceItemSetting
+	var configItems []*ProjectConfigItemSetting
+	for i := 0; i < len(fragments); i++ {
+		fragment := fragments[i]
+		if fragment.option != nil {
+			option, err := fragment.option.convert(fragment.helper.Child("option"), optionNamesDict, optionExportsDict)
+			if err != nil {
+				return nil, err
+			}
+			optionItems = append(optionItems, option.Items...)
+			optionChecks = append(optionChecks, option.Checks...)
+		}
+		if fragment.dependency != nil {
+			dependency, err := fragment.dependency.Convert(fragment.helper.Child("dependency"))
+			if err != nil {
+				return nil, err
+			}
+			dependencyItems = append(dependencyItems, dependency.Items...)
+		}
+		if fragment.resource != nil {
+			resource, err := fragment.resource.Convert(fragment.helper.Child("resource"))
+			if err != nil {
+				return nil, err
+			}
+			resourceItems = append(resourceItems, resource.Items...)
+		}
+		if fragment.config != nil {
+			config, err := fragment.config.Convert(fragment.helper.Child("config"))
+			if err != nil {
+				return nil, err
+			}
+			configItems = append(configItems, config.Items...)
 		}
 	}
 
-	return NewProjectSetting(m.Name, dir, runtime, option, dependency, resource), nil
+	option := NewProjectOptionSetting(optionItems, optionChecks)
+	dependency := NewProjectDependencySetting(dependencyItems)
+	resource := NewProjectResourceSetting(resourceItems)
+	config := NewProjectConfigSetting(configItems)
+	setting := NewProjectSetting(m.Name, dir, runtime, option, dependency, resource, config, provider)
+	for i := 0; i < len(fragments); i++ {
+		setting.Files = append(setting.Files, fragments[i].helper.Source)
+	}
+	return setting, nil
 }
 
 // endregion
@@ -0,0 +1,156 @@
+package setting
+
+import (
+	. "github.com/orz-dsh/dsh/core/inspection"
+	. "github.com/orz-dsh/dsh/utils"
+	"regexp"
+)
+
+// region base
+
+// projectConfigPathCheckRegex checks the config path, the `*` segment matches all keys of a map or all items of a list.
+var projectConfigPathCheckRegex = regexp.MustCompile("^([A-Za-z0-9_-]+|\\*)(\\.([A-Za-z0-9_-]+|\\*))*$")
+
+type ProjectConfigValueType = CastType
+
+var projectConfigValueTypesDict = map[ProjectConfigValueType]bool{
+	CastTypeString:  true,
+	CastTypeBool:    true,
+	CastTypeInteger: true,
+	CastTypeDecimal: true,
+	CastTypeObject:  true,
+	CastTypeArray:   true,
+}
+
+// endregion
+
+// region ProjectConfigSetting
+
+type ProjectConfigSetting struct {
+	Items []*ProjectConfigItemSetting
+}
+
+func NewProjectConfigSetting(items []*ProjectConfigItemSetting) *ProjectConfigSetting {
+	return &ProjectConfigSetting{
+		Items: items,
+	}
+}
+
+func (s *ProjectConfigSetting) Inspect() *ProjectConfigSettingInspection {
+	var items []*ProjectConfigItemSettingInspection
+	for i := 0; i < len(s.Items); i++ {
+		items = append(items, s.Items[i].Inspect())
+	}
+	return NewProjectConfigSettingInspection(items)
+}
+
+// endregion
+
+// region ProjectConfigItemSetting
+
+type ProjectConfigItemSetting struct {
+	Path         string
+	Type         ProjectConfigValueType
+	Required     bool
+	Pattern      string
+	Enum         []any
+	PatternRegex *regexp.Regexp
+}
+
+func NewProjectConfigItemSetting(path string, typ ProjectConfigValueType, required bool, pattern string, enum []any, patternRegex *regexp.Regexp) *ProjectConfigItemSetting {
+	return &ProjectConfigItemSetting{
+		Path:         path,
+		Type:         typ,
+		Required:     required,
+		Pattern:      pattern,
+		Enum:         enum,
+		PatternRegex: patternRegex,
+	}
+}
+
+func (s *ProjectConfigItemSetting) Inspect() *ProjectConfigItemSettingInspection {
+	return NewProjectConfigItemSettingInspection(s.Path, string(s.Type), s.Required, s.Pattern, s.Enum)
+}
+
+// endregion
+
+// region ProjectConfigSettingModel
+
+type ProjectConfigSettingModel struct {
+	Items []*ProjectConfigItemSettingModel `yaml:"items,omitempty" toml:"items,omitempty" json:"items,omitempty"`
+}
+
+func NewProjectConfigSettingModel(items []*ProjectConfigItemSettingModel) *ProjectConfigSettingModel {
+	return &ProjectConfigSettingModel{
+		Items: items,
+	}
+}
+
+func (m *ProjectConfigSettingModel) Convert(helper *ModelHelper) (*ProjectConfigSetting, error) {
+	items, err := ConvertChildModels(helper, "items", m.Items)
+	if err != nil {
+		return nil, err
+	}
+	return NewProjectConfigSetting(items), nil
+}
+
+// endregion
+
+// region ProjectConfigItemSettingModel
+
+type ProjectConfigItemSettingModel struct {
+	Path     string `yaml:"path" toml:"path" json:"path"`
+	Type     string `yaml:"type,omitempty" toml:"type,omitempty" json:"type,omitempty"`
+	Required bool   `yaml:"required,omitempty" toml:"required,omitempty" json:"required,omitempty"`
+	Pattern  string `yaml:"pattern,omitempty" toml:"pattern,omitempty" json:"pattern,omitempty"`
+	Enum     []any  `yaml:"enum,omitempty" toml:"enum,omitempty" json:"enum,omitempty"`
+}
+
+func NewProjectConfigItemSettingModel(path, typ string, required bool, pattern string, enum []any) *ProjectConfigItemSettingModel {
+	return &ProjectConfigItemSettingModel{
+		Path:     path,
+		Type:     typ,
+		Required: required,
+		Pattern:  pattern,
+		Enum:     enum,
+	}
+}
+
+func (m *ProjectConfigItemSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("path").SetPattern(projectConfigPathCheckRegex.String()).SetDescription("config path, such as `db.port`, the `*` segment matches all keys of a map or all items of a list")
+	schema.GetProperty("type").SetEnum(CastTypeString, CastTypeBool, CastTypeInteger, CastTypeDecimal, CastTypeObject, CastTypeArray).SetDescription("value type, any type is allowed if empty")
+	schema.GetProperty("required").SetDescription("whether the value must exist in the merged config")
+	schema.GetProperty("pattern").SetDescription("regex pattern of the string value")
+	schema.GetProperty("enum").SetItems(NewJsonSchema([]string{"string", "number", "boolean"})).SetDescription("allowed values")
+}
+
+func (m *ProjectConfigItemSettingModel) Convert(helper *ModelHelper) (*ProjectConfigItemSetting, error) {
+	if m.Path == "" {
+		return nil, helper.Child("path").NewValueEmptyError()
+	}
+	if !projectConfigPathCheckRegex.MatchString(m.Path) {
+		return nil, helper.Child("path").NewValueInvalidError(m.Path)
+	}
+
+	typ := ProjectConfigValueType(m.Type)
+	if typ != "" && !projectConfigValueTypesDict[typ] {
+		return nil, helper.Child("type").NewValueInvalidError(m.Type)
+	}
+
+	var patternRegex *regexp.Regexp
+	if m.Pattern != "" {
+		if typ != CastTypeString {
+			return nil, helper.Child("pattern").NewError("pattern is only supported for string type",
+				KV("type", typ),
+			)
+		}
+		var err error
+		if patternRegex, err = regexp.Compile(m.Pattern); err != nil {
+			return nil, helper.Child("pattern").WrapValueInvalidError(err, m.Pattern)
+		}
+	}
+
+	return NewProjectConfigItemSetting(m.Path, typ, m.Required, m.Pattern, m.Enum, patternRegex), nil
+}
+
+// endregion
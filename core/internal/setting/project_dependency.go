@@ -79,6 +79,11 @@ type ProjectDependencyItemSettingModel struct {
 	Match string `yaml:"match,omitempty" toml:"match,omitempty" json:"match,omitempty"`
 }
 
+func (m *ProjectDependencyItemSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("link").SetPattern("^(registry:|@|dir:|git:|archive:|oci:|https?:).+$").SetDescription("project link, such as `dir:../lib`, `git:<url>#ref=<ref>`, `archive:<file>`, `<url>#sha256=<hex>`, `oci://<ref>` or `@<registry>/<path>`")
+	schema.GetProperty("match").SetDescription("expression to match, the item is used only when it evaluates to true")
+}
+
 func NewProjectDependencyItemSettingModel(link, match string) *ProjectDependencyItemSettingModel {
 	return &ProjectDependencyItemSettingModel{
 		Link:  link,
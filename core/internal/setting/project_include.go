@@ -0,0 +1,129 @@
+package setting
+
+import (
+	. "github.com/orz-dsh/dsh/utils"
+	"path/filepath"
+	"slices"
+)
+
+// region ProjectIncludeSettingModel
+
+// ProjectIncludeSettingModel is the project setting fragment in an included file.
+type ProjectIncludeSettingModel struct {
+	Includes   []string                       `yaml:"includes,omitempty" toml:"includes,omitempty" json:"includes,omitempty"`
+	Option     *ProjectOptionSettingModel     `yaml:"option,omitempty" toml:"option,omitempty" json:"option,omitempty"`
+	Dependency *ProjectDependencySettingModel `yaml:"dependency,omitempty" toml:"dependency,omitempty" json:"dependency,omitempty"`
+	Resource   *ProjectResourceSettingModel   `yaml:"resource,omitempty" toml:"resource,omitempty" json:"resource,omitempty"`
+	Config     *ProjectConfigSettingModel     `yaml:"config,omitempty" toml:"config,omitempty" json:"config,omitempty"`
+}
+
+func (m *ProjectIncludeSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.SetDescription("project setting fragment included by project setting files")
+	schema.GetProperty("includes").SetDescription("files or glob patterns to include, relative to the dir of this file")
+	schema.GetProperty("option").SetDescription("options merged into the project")
+	schema.GetProperty("dependency").SetDescription("dependencies merged into the project")
+	schema.GetProperty("resource").SetDescription("resources merged into the project")
+	schema.GetProperty("config").SetDescription("config schema merged into the project")
+}
+
+// endregion
+
+// region projectIncludeFragment
+
+type projectIncludeFragment struct {
+	helper     *ModelHelper
+	option     *ProjectOptionSettingModel
+	dependency *ProjectDependencySettingModel
+	resource   *ProjectResourceSettingModel
+	config     *ProjectConfigSettingModel
+}
+
+// endregion
+
+// region projectIncludeLoader
+
+// projectIncludeLoader loads the included files depth-first, the fragments of included files are placed
+// before the fragment of the including file, and a file included more than once is only loaded the first time.
+type projectIncludeLoader struct {
+	fsys      FileSystem
+	filesDict map[string]bool
+	stack     []string
+	fragments []*projectIncludeFragment
+}
+
+func loadProjectIncludeFragments(helper *ModelHelper, fsys FileSystem, model *ProjectSettingModel) ([]*projectIncludeFragment, error) {
+	file := filepath.Clean(helper.Source)
+	loader := &projectIncludeLoader{
+		fsys:      fsys,
+		filesDict: map[string]bool{file: true},
+		stack:     []string{file},
+	}
+	if err := loader.load(helper, file, model.Includes); err != nil {
+		return nil, err
+	}
+	loader.fragments = append(loader.fragments, &projectIncludeFragment{
+		helper:     helper,
+		option:     model.Option,
+		dependency: model.Dependency,
+		resource:   model.Resource,
+		config:     model.Config,
+	})
+	return loader.fragments, nil
+}
+
+func (l *projectIncludeLoader) load(helper *ModelHelper, file string, includes []string) error {
+	for i := 0; i < len(includes); i++ {
+		itemHelper := helper.ChildItem("includes", i)
+		include := includes[i]
+		if include == "" {
+			return itemHelper.NewValueEmptyError()
+		}
+		pattern := include
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(file), pattern)
+		}
+		files, err := GlobFS(l.fsys, pattern)
+		if err != nil {
+			return itemHelper.WrapValueInvalidError(err, include)
+		}
+		if len(files) == 0 {
+			return itemHelper.NewError("include file not found", KV("include", include))
+		}
+		for j := 0; j < len(files); j++ {
+			includeFile := files[j]
+			if slices.Contains(l.stack, includeFile) {
+				return itemHelper.NewError("include cycle",
+					KV("include", include),
+					KV("file", includeFile),
+					KV("stack", l.stack),
+				)
+			}
+			if l.filesDict[includeFile] {
+				continue
+			}
+			l.filesDict[includeFile] = true
+
+			model := &ProjectIncludeSettingModel{}
+			metadata, err := DeserializeFileFS(l.fsys, includeFile, "", model)
+			if err != nil {
+				return itemHelper.WrapError(err, "load include file error", KV("file", includeFile))
+			}
+			includeHelper := helper.ChildSource(metadata.File, metadata.Positions)
+			l.stack = append(l.stack, includeFile)
+			if err = l.load(includeHelper, includeFile, model.Includes); err != nil {
+				return err
+			}
+			l.stack = l.stack[:len(l.stack)-1]
+			l.fragments = append(l.fragments, &projectIncludeFragment{
+				helper:     includeHelper,
+				option:     model.Option,
+				dependency: model.Dependency,
+				resource:   model.Resource,
+				config:     model.Config,
+			})
+		}
+	}
+	return nil
+}
+
+// endregion
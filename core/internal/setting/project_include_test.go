@@ -0,0 +1,57 @@
+package setting
+
+import (
+	. "github.com/orz-dsh/dsh/utils"
+	"path/filepath"
+	"testing"
+)
+
+func newTestProjectFileSystem(t *testing.T, dir string, files map[string]string) FileSystem {
+	fsys := NewMemoryFileSystem()
+	for name, data := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := fsys.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := fsys.WriteFile(file, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return fsys
+}
+
+func TestProjectIncludeFileSystem(t *testing.T) {
+	dir := filepath.Join(string(filepath.Separator), "virtual", "app")
+	fsys := newTestProjectFileSystem(t, dir, map[string]string{
+		"project.yml":         "name: app\nincludes: [\"include/*.yml\"]\noption:\n  items:\n    - name: opt_c\n",
+		"include/a.yml":       "includes: [\"../shared/b.yml\"]\noption:\n  items:\n    - name: opt_a\n",
+		"shared/b.yml":        "option:\n  items:\n    - name: opt_b\n      type: path\n      exists: true\n",
+		"data/exists.txt":     "exists",
+		"include/skip.yml.md": "",
+	})
+	setting, err := LoadProjectSetting(NewLogger(LogLevelError), fsys, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(setting.Option.Items) != 3 || setting.Option.Items[0].Name != "opt_b" || setting.Option.Items[1].Name != "opt_a" || setting.Option.Items[2].Name != "opt_c" {
+		t.Fatal("option items", setting.Option.Items)
+	}
+	if len(setting.Files) != 3 {
+		t.Fatal("files", setting.Files)
+	}
+
+	option := setting.Option.Items[0]
+	if err = option.CheckValue(filepath.Join(dir, "data", "exists.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if err = option.CheckValue(filepath.Join(dir, "data", "missing.txt")); err == nil {
+		t.Fatal("missing path should fail the exists check")
+	}
+
+	fsys = newTestProjectFileSystem(t, dir, map[string]string{
+		"project.yml": "name: app\nincludes: [\"missing/*.yml\"]\n",
+	})
+	if _, err = LoadProjectSetting(NewLogger(LogLevelError), fsys, dir); err == nil {
+		t.Fatal("missing include should fail")
+	}
+}
@@ -1,9 +1,14 @@
 package setting
 
 import (
+	"fmt"
 	. "github.com/orz-dsh/dsh/utils"
+	"path/filepath"
 	"reflect"
 	"regexp"
+	"slices"
+	"time"
+	"unicode/utf8"
 )
 
 // region base
@@ -16,10 +21,123 @@ var projectOptionNameUnsoundDict = map[string]bool{
 	"option": true,
 	"global": true,
 	"local":  true,
+	"env":    true,
+	"file":   true,
+	"exec":   true,
 }
 
 type ProjectOptionValueType = CastType
 
+const (
+	ProjectOptionValueTypePath     ProjectOptionValueType = "path"
+	ProjectOptionValueTypeDuration ProjectOptionValueType = "duration"
+	ProjectOptionValueTypeSecret   ProjectOptionValueType = "secret"
+)
+
+const ProjectOptionSecretMask = "******"
+
+var projectOptionArrayTypeRegex = regexp.MustCompile("^array<([a-z]+)>$")
+
+var projectOptionValueTypesDict = map[ProjectOptionValueType]bool{
+	CastTypeString:                 true,
+	CastTypeBool:                   true,
+	CastTypeInteger:                true,
+	CastTypeDecimal:                true,
+	CastTypeObject:                 true,
+	CastTypeArray:                  true,
+	ProjectOptionValueTypePath:     true,
+	ProjectOptionValueTypeDuration: true,
+	ProjectOptionValueTypeSecret:   true,
+}
+
+var projectOptionTextTypesDict = map[ProjectOptionValueType]bool{
+	CastTypeString:               true,
+	ProjectOptionValueTypePath:   true,
+	ProjectOptionValueTypeSecret: true,
+}
+
+var projectOptionNumberTypesDict = map[ProjectOptionValueType]bool{
+	CastTypeInteger: true,
+	CastTypeDecimal: true,
+}
+
+var projectOptionArrayItemTypesDict = map[ProjectOptionValueType]bool{
+	CastTypeString:                 true,
+	CastTypeBool:                   true,
+	CastTypeInteger:                true,
+	CastTypeDecimal:                true,
+	CastTypeObject:                 true,
+	ProjectOptionValueTypePath:     true,
+	ProjectOptionValueTypeDuration: true,
+}
+
+func parseProjectOptionValueType(typ ProjectOptionValueType) (itemType ProjectOptionValueType, valid bool) {
+	if matches := projectOptionArrayTypeRegex.FindStringSubmatch(string(typ)); matches != nil {
+		itemType = ProjectOptionValueType(matches[1])
+		return itemType, projectOptionArrayItemTypesDict[itemType]
+	}
+	return "", projectOptionValueTypesDict[typ]
+}
+
+// castProjectOptionValue casts the value to the type, the relative path is resolved against the dir, or the current dir if the dir is empty.
+func castProjectOptionValue(value any, typ ProjectOptionValueType, dir string) (any, error) {
+	switch typ {
+	case ProjectOptionValueTypePath:
+		str, err := CastToString(value)
+		if err != nil || str == nil {
+			return nil, err
+		}
+		if *str == "" {
+			return nil, ErrN("cast to path error",
+				Reason("path is empty"),
+			)
+		}
+		path := *str
+		if dir != "" && !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		path, err = filepath.Abs(path)
+		if err != nil {
+			return nil, ErrW(err, "cast to path error",
+				Reason("get abs-path error"),
+				KV("value", value),
+			)
+		}
+		return path, nil
+	case ProjectOptionValueTypeDuration:
+		if duration, ok := value.(time.Duration); ok {
+			return duration, nil
+		}
+		str, err := CastToString(value)
+		if err != nil || str == nil {
+			return nil, err
+		}
+		duration, err := time.ParseDuration(*str)
+		if err != nil {
+			return nil, ErrW(err, "cast to duration error",
+				Reason("parse duration error"),
+				KV("value", value),
+			)
+		}
+		return duration, nil
+	case ProjectOptionValueTypeSecret:
+		if secret, ok := value.(*Secret); ok {
+			return secret, nil
+		}
+		str, err := CastToString(value)
+		if err != nil || str == nil {
+			return nil, err
+		}
+		// the secret reference is resolved lazily, so the rules of the value are not checked
+		if IsSecretReference(*str) {
+			return ParseSecret(*str)
+		}
+		return *str, nil
+	default:
+		return Cast(value, typ)
+	}
+}
+
 // endregion
 
 // region ProjectOptionSetting
@@ -36,6 +154,34 @@ func NewProjectOptionSetting(items []*ProjectOptionItemSetting, checks []*Projec
 	}
 }
 
+func (s *ProjectOptionSetting) Check(evaluator *Evaluator, values map[string]any) (failures []*ProjectOptionCheckFailure, err error) {
+	for i := 0; i < len(s.Items); i++ {
+		item := s.Items[i]
+		itemFailures, err := item.Rule.Check(evaluator, item.Name, values[item.Name])
+		if err != nil {
+			return nil, ErrW(err, "check options error",
+				Reason("check option rule error"),
+				KV("name", item.Name),
+			)
+		}
+		failures = append(failures, itemFailures...)
+	}
+	for i := 0; i < len(s.Checks); i++ {
+		check := s.Checks[i]
+		failure, err := check.Check(evaluator)
+		if err != nil {
+			return nil, ErrW(err, "check options error",
+				Reason("eval check error"),
+				KV("check", check),
+			)
+		}
+		if failure != nil {
+			failures = append(failures, failure)
+		}
+	}
+	return failures, nil
+}
+
 // endregion
 
 // region ProjectOptionItemSetting
@@ -43,68 +189,172 @@ func NewProjectOptionSetting(items []*ProjectOptionItemSetting, checks []*Projec
 type ProjectOptionItemSetting struct {
 	Name     string
 	Type     ProjectOptionValueType
+	ItemType ProjectOptionValueType
 	Usage    string
 	Export   string
 	Hidden   bool
 	Compute  string
 	Default  any
-	Choices  []any
+	Choices  []*ProjectOptionChoiceSetting
 	Optional bool
+	Exists   bool
+	Rule     *ProjectOptionRuleSetting
+	// Dir is the project dir, the relative paths in the default, choices and compute are resolved against it,
+	// while the relative paths in the arguments are resolved against the current dir.
+	Dir string
+	// FileSystem is the file system of the project, the paths of the exists check are checked by it.
+	FileSystem FileSystem
 }
 
-func NewProjectOptionItemSetting(name string, typ ProjectOptionValueType, usage, export string, hidden bool, compute string, optional bool) *ProjectOptionItemSetting {
+func NewProjectOptionItemSetting(name string, typ, itemType ProjectOptionValueType, usage, export string, hidden bool, compute string, optional, exists bool, rule *ProjectOptionRuleSetting) *ProjectOptionItemSetting {
+	if rule == nil {
+		rule = NewProjectOptionRuleSetting(nil, nil, nil, nil, nil, "", "")
+	}
 	return &ProjectOptionItemSetting{
-		Name:     name,
-		Type:     typ,
-		Usage:    usage,
-		Export:   export,
-		Hidden:   hidden,
-		Compute:  compute,
-		Optional: optional,
+		Name:       name,
+		Type:       typ,
+		ItemType:   itemType,
+		Usage:      usage,
+		Export:     export,
+		Hidden:     hidden,
+		Compute:    compute,
+		Optional:   optional,
+		Exists:     exists,
+		Rule:       rule,
+		FileSystem: OSFileSystem,
+	}
+}
+
+func (s *ProjectOptionItemSetting) IsSecret() bool {
+	return s.Type == ProjectOptionValueTypeSecret
+}
+
+func (s *ProjectOptionItemSetting) MaskValue(value any) any {
+	if s.IsSecret() && value != nil {
+		return ProjectOptionSecretMask
+	}
+	return value
+}
+
+func (s *ProjectOptionItemSetting) GetChoiceValues() []any {
+	values := make([]any, 0, len(s.Choices))
+	for i := 0; i < len(s.Choices); i++ {
+		values = append(values, s.Choices[i].Value)
 	}
+	return values
 }
 
-func (s *ProjectOptionItemSetting) setChoices(choices []string) error {
-	if choices != nil {
-		result, err := CastSlice(choices, s.Type)
+func (s *ProjectOptionItemSetting) getEvalType() CastType {
+	switch s.Type {
+	case ProjectOptionValueTypePath, ProjectOptionValueTypeDuration, ProjectOptionValueTypeSecret:
+		return CastTypeString
+	}
+	if s.ItemType != "" {
+		return CastTypeArray
+	}
+	return s.Type
+}
+
+func (s *ProjectOptionItemSetting) castValue(value any, dir string) (any, error) {
+	if s.ItemType == "" {
+		return castProjectOptionValue(value, s.Type, dir)
+	}
+	items, err := CastToArray(value)
+	if err != nil || items == nil {
+		return nil, err
+	}
+	result := make([]any, 0, len(items))
+	for i := 0; i < len(items); i++ {
+		item, err := castProjectOptionValue(items[i], s.ItemType, dir)
+		if err != nil {
+			return nil, ErrW(err, "cast array item error",
+				KV("index", i),
+				KV("itemType", s.ItemType),
+			)
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (s *ProjectOptionItemSetting) setChoices(choices []*ProjectOptionChoiceSetting) error {
+	for i := 0; i < len(choices); i++ {
+		choice := choices[i]
+		result, err := s.castValue(choice.Value, s.Dir)
 		if err != nil {
 			return ErrW(err, "parse option choices error",
 				Reason("cast error"),
 				KV("name", s.Name),
-				KV("choices", choices),
+				KV("choice", s.MaskValue(choice.Value)),
 				KV("type", s.Type),
 			)
 		}
-		s.Choices = result
+		choice.Value = result
 	}
+	s.Choices = choices
 	return nil
 }
 
 func (s *ProjectOptionItemSetting) checkChoices(value any) error {
 	if len(s.Choices) > 0 {
 		for i := 0; i < len(s.Choices); i++ {
-			if reflect.DeepEqual(s.Choices[i], value) {
+			if reflect.DeepEqual(s.Choices[i].Value, value) {
 				return nil
 			}
 		}
 		return ErrN("check option choices error",
 			Reason("not in choices"),
 			KV("name", s.Name),
-			KV("value", value),
-			KV("choices", s.Choices),
+			KV("value", s.MaskValue(value)),
+			KV("choices", s.GetChoiceValues()),
 		)
 	}
 	return nil
 }
 
-func (s *ProjectOptionItemSetting) setDefault(value *string) error {
+func (s *ProjectOptionItemSetting) checkExists(value any) error {
+	if !s.Exists || value == nil {
+		return nil
+	}
+	var paths []string
+	if s.Type == ProjectOptionValueTypePath {
+		paths = append(paths, value.(string))
+	} else if s.ItemType == ProjectOptionValueTypePath {
+		items := value.([]any)
+		for i := 0; i < len(items); i++ {
+			paths = append(paths, items[i].(string))
+		}
+	}
+	for i := 0; i < len(paths); i++ {
+		if _, err := s.FileSystem.Stat(paths[i]); err != nil {
+			return ErrN("check option path error",
+				Reason("path not exists"),
+				KV("name", s.Name),
+				KV("path", paths[i]),
+			)
+		}
+	}
+	return nil
+}
+
+func (s *ProjectOptionItemSetting) CheckValue(value any) error {
+	if err := s.checkChoices(value); err != nil {
+		return err
+	}
+	if err := s.checkExists(value); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *ProjectOptionItemSetting) setDefault(value any) error {
 	if value != nil {
-		result, err := Cast(*value, s.Type)
+		result, err := s.castValue(value, s.Dir)
 		if err != nil {
 			return ErrW(err, "parse option value error",
 				Reason("cast error"),
 				KV("name", s.Name),
-				KV("value", value),
+				KV("value", s.MaskValue(value)),
 				KV("type", s.Type),
 			)
 		}
@@ -117,23 +367,26 @@ func (s *ProjectOptionItemSetting) setDefault(value *string) error {
 }
 
 func (s *ProjectOptionItemSetting) ParseValue(value string) (any, error) {
-	result, err := Cast(value, s.Type)
+	result, err := s.castValue(value, "")
 	if err != nil {
 		return nil, ErrW(err, "parse option value error",
 			Reason("cast value error"),
 			KV("name", s.Name),
-			KV("value", value),
+			KV("value", s.MaskValue(value)),
 			KV("type", s.Type),
 		)
 	}
-	if err = s.checkChoices(result); err != nil {
-		return nil, ErrW(err, "parse option value error", Reason("check choices error"))
+	if err = s.CheckValue(result); err != nil {
+		return nil, ErrW(err, "parse option value error", Reason("check value error"))
 	}
 	return result, nil
 }
 
 func (s *ProjectOptionItemSetting) ComputeValue(evaluator *Evaluator) (any, error) {
-	result, err := evaluator.EvalExpr(s.Compute, s.Type)
+	result, err := evaluator.EvalExpr(s.Compute, s.getEvalType())
+	if err == nil {
+		result, err = s.castValue(result, s.Dir)
+	}
 	if err != nil {
 		return nil, ErrW(err, "compute option value error",
 			Reason("eval expr error"),
@@ -142,23 +395,177 @@ func (s *ProjectOptionItemSetting) ComputeValue(evaluator *Evaluator) (any, erro
 			KV("type", s.Type),
 		)
 	}
-	if err = s.checkChoices(result); err != nil {
-		return nil, ErrW(err, "compute option value error", Reason("check choices error"))
+	if err = s.CheckValue(result); err != nil {
+		return nil, ErrW(err, "compute option value error", Reason("check value error"))
 	}
 	return result, nil
 }
 
 // endregion
 
+// region ProjectOptionChoiceSetting
+
+type ProjectOptionChoiceSetting struct {
+	Value any
+	Usage string
+}
+
+func NewProjectOptionChoiceSetting(value any, usage string) *ProjectOptionChoiceSetting {
+	return &ProjectOptionChoiceSetting{
+		Value: value,
+		Usage: usage,
+	}
+}
+
+// endregion
+
+// region ProjectOptionRuleSetting
+
+type ProjectOptionRuleSetting struct {
+	Pattern    *regexp.Regexp
+	Min        *float64
+	Max        *float64
+	MinLength  *int
+	MaxLength  *int
+	RequiredIf string
+	Message    string
+}
+
+func NewProjectOptionRuleSetting(pattern *regexp.Regexp, min, max *float64, minLength, maxLength *int, requiredIf, message string) *ProjectOptionRuleSetting {
+	return &ProjectOptionRuleSetting{
+		Pattern:    pattern,
+		Min:        min,
+		Max:        max,
+		MinLength:  minLength,
+		MaxLength:  maxLength,
+		RequiredIf: requiredIf,
+		Message:    message,
+	}
+}
+
+func (s *ProjectOptionRuleSetting) Check(evaluator *Evaluator, name string, value any) (failures []*ProjectOptionCheckFailure, err error) {
+	if value == nil {
+		if s.RequiredIf != "" {
+			required, err := evaluator.EvalBoolExpr(s.RequiredIf)
+			if err != nil {
+				return nil, ErrW(err, "check option rule error",
+					Reason("eval requiredIf error"),
+					KV("name", name),
+					KV("requiredIf", s.RequiredIf),
+				)
+			}
+			if required {
+				failures = append(failures, s.newFailure(name, "requiredIf", s.RequiredIf, "value is required"))
+			}
+		}
+		return failures, nil
+	}
+	switch v := value.(type) {
+	case []any:
+		failures = append(failures, s.checkLength(name, len(v))...)
+		for i := 0; i < len(v); i++ {
+			failures = append(failures, s.checkItem(name, v[i])...)
+		}
+	case string:
+		failures = append(failures, s.checkLength(name, utf8.RuneCountInString(v))...)
+		failures = append(failures, s.checkItem(name, v)...)
+	default:
+		failures = append(failures, s.checkItem(name, v)...)
+	}
+	return failures, nil
+}
+
+func (s *ProjectOptionRuleSetting) checkItem(name string, value any) (failures []*ProjectOptionCheckFailure) {
+	var number *float64
+	switch v := value.(type) {
+	case string:
+		if s.Pattern != nil && !s.Pattern.MatchString(v) {
+			failures = append(failures, s.newFailure(name, "pattern", "", fmt.Sprintf("value does not match pattern %q", s.Pattern.String())))
+		}
+	case int64:
+		n := float64(v)
+		number = &n
+	case float64:
+		number = &v
+	}
+	if number != nil {
+		if s.Min != nil && *number < *s.Min {
+			failures = append(failures, s.newFailure(name, "min", "", fmt.Sprintf("value must be greater than or equal to %v", *s.Min)))
+		}
+		if s.Max != nil && *number > *s.Max {
+			failures = append(failures, s.newFailure(name, "max", "", fmt.Sprintf("value must be less than or equal to %v", *s.Max)))
+		}
+	}
+	return failures
+}
+
+func (s *ProjectOptionRuleSetting) checkLength(name string, length int) (failures []*ProjectOptionCheckFailure) {
+	if s.MinLength != nil && length < *s.MinLength {
+		failures = append(failures, s.newFailure(name, "minLength", "", fmt.Sprintf("length must be greater than or equal to %d", *s.MinLength)))
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		failures = append(failures, s.newFailure(name, "maxLength", "", fmt.Sprintf("length must be less than or equal to %d", *s.MaxLength)))
+	}
+	return failures
+}
+
+func (s *ProjectOptionRuleSetting) newFailure(name, rule, expr, message string) *ProjectOptionCheckFailure {
+	if s.Message != "" {
+		message = s.Message
+	}
+	return NewProjectOptionCheckFailure([]string{name}, rule, expr, message)
+}
+
+// endregion
+
 // region ProjectOptionCheckSetting
 
 type ProjectOptionCheckSetting struct {
-	Expr string
+	Expr    string
+	Message string
+	Names   []string
 }
 
-func NewProjectOptionCheckSetting(expr string) *ProjectOptionCheckSetting {
+func NewProjectOptionCheckSetting(expr, message string, names []string) *ProjectOptionCheckSetting {
 	return &ProjectOptionCheckSetting{
-		Expr: expr,
+		Expr:    expr,
+		Message: message,
+		Names:   names,
+	}
+}
+
+func (s *ProjectOptionCheckSetting) Check(evaluator *Evaluator) (*ProjectOptionCheckFailure, error) {
+	result, err := evaluator.EvalBoolExpr(s.Expr)
+	if err != nil {
+		return nil, err
+	}
+	if result {
+		return nil, nil
+	}
+	message := s.Message
+	if message == "" {
+		message = "check expr is false"
+	}
+	return NewProjectOptionCheckFailure(s.Names, "expr", s.Expr, message), nil
+}
+
+// endregion
+
+// region ProjectOptionCheckFailure
+
+type ProjectOptionCheckFailure struct {
+	Names   []string
+	Rule    string
+	Expr    string
+	Message string
+}
+
+func NewProjectOptionCheckFailure(names []string, rule, expr, message string) *ProjectOptionCheckFailure {
+	return &ProjectOptionCheckFailure{
+		Names:   names,
+		Rule:    rule,
+		Expr:    expr,
+		Message: message,
 	}
 }
 
@@ -168,13 +575,27 @@ func NewProjectOptionCheckSetting(expr string) *ProjectOptionCheckSetting {
 
 type ProjectOptionSettingModel struct {
 	Items  []*ProjectOptionItemSettingModel `yaml:"items,omitempty" toml:"items,omitempty" json:"items,omitempty"`
-	Checks []string                         `yaml:"checks,omitempty" toml:"checks,omitempty" json:"checks,omitempty"`
+	Checks []any                            `yaml:"checks,omitempty" toml:"checks,omitempty" json:"checks,omitempty"`
+}
+
+func (m *ProjectOptionSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	check := NewJsonSchema("object")
+	check.Properties = map[string]*JsonSchema{
+		"expr":    NewJsonSchema("string").SetMinLength(1).SetDescription("expression that must evaluate to true"),
+		"message": NewJsonSchema("string").SetDescription("message of the failure"),
+		"names":   NewJsonSchema("array").SetItems(NewJsonSchema("string")).SetDescription("option names related to the check"),
+	}
+	check.Required = []string{"expr"}
+	check.AdditionalProperties = false
+	schema.GetProperty("checks").SetItems(NewJsonSchema(nil).SetOneOf(NewJsonSchema("string").SetMinLength(1), check)).SetDescription("cross option checks")
 }
 
 func (m *ProjectOptionSettingModel) Convert(helper *ModelHelper) (*ProjectOptionSetting, error) {
+	return m.convert(helper, map[string]bool{}, map[string]bool{})
+}
+
+func (m *ProjectOptionSettingModel) convert(helper *ModelHelper, namesDict, exportsDict map[string]bool) (*ProjectOptionSetting, error) {
 	var items []*ProjectOptionItemSetting
-	namesDict := map[string]bool{}
-	exportsDict := map[string]bool{}
 	for i := 0; i < len(m.Items); i++ {
 		item, err := m.Items[i].Convert(helper.ChildItem("items", i), namesDict, exportsDict)
 		if err != nil {
@@ -183,16 +604,71 @@ func (m *ProjectOptionSettingModel) Convert(helper *ModelHelper) (*ProjectOption
 		items = append(items, item)
 	}
 
-	var checks []*ProjectOptionCheckSetting
+	checkModels, err := m.getCheckModels(helper.Child("checks"))
+	if err != nil {
+		return nil, err
+	}
+	checks, err := ConvertChildModels(helper, "checks", checkModels)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewProjectOptionSetting(items, checks), nil
+}
+
+// getCheckModels gets the typed models of the checks, a string check is the shorthand of the expr.
+func (m *ProjectOptionSettingModel) getCheckModels(helper *ModelHelper) (models []*ProjectOptionCheckSettingModel, err error) {
 	for i := 0; i < len(m.Checks); i++ {
-		expr := m.Checks[i]
-		if expr == "" {
-			return nil, helper.ChildItem("checks", i).NewValueEmptyError()
+		model := &ProjectOptionCheckSettingModel{}
+		switch check := m.Checks[i].(type) {
+		case string:
+			model.Expr = check
+		case map[string]any:
+			if err = DecodeModelMap(helper.Item(i), check, model); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, helper.Item(i).NewValueInvalidError(check)
 		}
-		checks = append(checks, NewProjectOptionCheckSetting(expr))
+		models = append(models, model)
 	}
+	return models, nil
+}
 
-	return NewProjectOptionSetting(items, checks), nil
+// endregion
+
+// region ProjectOptionCheckSettingModel
+
+type ProjectOptionCheckSettingModel struct {
+	Expr    string   `yaml:"expr" toml:"expr" json:"expr"`
+	Message string   `yaml:"message,omitempty" toml:"message,omitempty" json:"message,omitempty"`
+	Names   []string `yaml:"names,omitempty" toml:"names,omitempty" json:"names,omitempty"`
+}
+
+func (m *ProjectOptionCheckSettingModel) Convert(helper *ModelHelper) (*ProjectOptionCheckSetting, error) {
+	if m.Expr == "" {
+		return nil, helper.Child("expr").NewValueEmptyError()
+	}
+	if err := helper.CheckStringItemEmpty("names", m.Names); err != nil {
+		return nil, err
+	}
+	return NewProjectOptionCheckSetting(m.Expr, m.Message, m.Names), nil
+}
+
+// endregion
+
+// region ProjectOptionChoiceSettingModel
+
+type ProjectOptionChoiceSettingModel struct {
+	Value any    `yaml:"value" toml:"value" json:"value"`
+	Usage string `yaml:"usage,omitempty" toml:"usage,omitempty" json:"usage,omitempty"`
+}
+
+func (m *ProjectOptionChoiceSettingModel) Convert(helper *ModelHelper) (*ProjectOptionChoiceSetting, error) {
+	if m.Value == nil {
+		return nil, helper.Child("value").NewValueEmptyError()
+	}
+	return NewProjectOptionChoiceSetting(m.Value, m.Usage), nil
 }
 
 // endregion
@@ -200,15 +676,62 @@ func (m *ProjectOptionSettingModel) Convert(helper *ModelHelper) (*ProjectOption
 // region ProjectOptionItemSettingModel
 
 type ProjectOptionItemSettingModel struct {
-	Name     string                 `yaml:"name" toml:"name" json:"name"`
-	Type     ProjectOptionValueType `yaml:"type,omitempty" toml:"type,omitempty" json:"type,omitempty"`
-	Usage    string                 `yaml:"usage,omitempty" toml:"usage,omitempty" json:"usage,omitempty"`
-	Export   string                 `yaml:"export,omitempty" toml:"export,omitempty" json:"export,omitempty"`
-	Hidden   bool                   `yaml:"hidden,omitempty" toml:"hidden,omitempty" json:"hidden,omitempty"`
-	Compute  string                 `yaml:"compute,omitempty" toml:"compute,omitempty" json:"compute,omitempty"`
-	Default  *string                `yaml:"default,omitempty" toml:"default,omitempty" json:"default,omitempty"`
-	Choices  []string               `yaml:"choices,omitempty" toml:"choices,omitempty" json:"choices,omitempty"`
-	Optional bool                   `yaml:"optional,omitempty" toml:"optional,omitempty" json:"optional,omitempty"`
+	Name       string                 `yaml:"name" toml:"name" json:"name"`
+	Type       ProjectOptionValueType `yaml:"type,omitempty" toml:"type,omitempty" json:"type,omitempty"`
+	Usage      string                 `yaml:"usage,omitempty" toml:"usage,omitempty" json:"usage,omitempty"`
+	Export     string                 `yaml:"export,omitempty" toml:"export,omitempty" json:"export,omitempty"`
+	Hidden     bool                   `yaml:"hidden,omitempty" toml:"hidden,omitempty" json:"hidden,omitempty"`
+	Compute    string                 `yaml:"compute,omitempty" toml:"compute,omitempty" json:"compute,omitempty"`
+	Default    any                    `yaml:"default,omitempty" toml:"default,omitempty" json:"default,omitempty"`
+	Choices    []any                  `yaml:"choices,omitempty" toml:"choices,omitempty" json:"choices,omitempty"`
+	Optional   bool                   `yaml:"optional,omitempty" toml:"optional,omitempty" json:"optional,omitempty"`
+	Exists     bool                   `yaml:"exists,omitempty" toml:"exists,omitempty" json:"exists,omitempty"`
+	Pattern    string                 `yaml:"pattern,omitempty" toml:"pattern,omitempty" json:"pattern,omitempty"`
+	Min        *float64               `yaml:"min,omitempty" toml:"min,omitempty" json:"min,omitempty"`
+	Max        *float64               `yaml:"max,omitempty" toml:"max,omitempty" json:"max,omitempty"`
+	MinLength  *int                   `yaml:"minLength,omitempty" toml:"minLength,omitempty" json:"minLength,omitempty"`
+	MaxLength  *int                   `yaml:"maxLength,omitempty" toml:"maxLength,omitempty" json:"maxLength,omitempty"`
+	RequiredIf string                 `yaml:"requiredIf,omitempty" toml:"requiredIf,omitempty" json:"requiredIf,omitempty"`
+	Message    string                 `yaml:"message,omitempty" toml:"message,omitempty" json:"message,omitempty"`
+}
+
+func (m *ProjectOptionItemSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	valueTypes := MapKeys(projectOptionValueTypesDict)
+	slices.Sort(valueTypes)
+	arrayItemTypes := MapKeys(projectOptionArrayItemTypesDict)
+	slices.Sort(arrayItemTypes)
+	var types []any
+	for i := 0; i < len(valueTypes); i++ {
+		types = append(types, valueTypes[i])
+	}
+	for i := 0; i < len(arrayItemTypes); i++ {
+		types = append(types, "array<"+arrayItemTypes[i]+">")
+	}
+	choice := NewJsonSchema("object")
+	choice.Properties = map[string]*JsonSchema{
+		"value": {Description: "choice value"},
+		"usage": NewJsonSchema("string").SetDescription("usage of the choice"),
+	}
+	choice.Required = []string{"value"}
+	choice.AdditionalProperties = false
+	schema.GetProperty("name").SetPattern(projectOptionNameCheckRegex.String()).SetDescription("option name")
+	schema.GetProperty("type").SetEnum(types...).SetDescription("option value type, default is string")
+	schema.GetProperty("usage").SetDescription("usage of the option")
+	schema.GetProperty("export").SetPattern(projectOptionExportCheckRegex.String()).SetDescription("export name, default is `<project>.<name>`")
+	schema.GetProperty("hidden").SetDescription("hide the option from help")
+	schema.GetProperty("compute").SetDescription("expression to compute the value, conflicts with default")
+	schema.GetProperty("default").SetDescription("default value, cast to the option value type")
+	schema.GetProperty("default").Type = []string{"string", "number", "boolean", "array"}
+	schema.GetProperty("choices").SetItems(NewJsonSchema(nil).SetAnyOf(choice, NewJsonSchema([]string{"string", "number", "boolean"}))).SetDescription("allowed values")
+	schema.GetProperty("optional").SetDescription("the value is allowed to be empty")
+	schema.GetProperty("exists").SetDescription("the path must exist, only for path type")
+	schema.GetProperty("pattern").SetDescription("regex the value must match, only for text types")
+	schema.GetProperty("min").SetDescription("min value, only for number types")
+	schema.GetProperty("max").SetDescription("max value, only for number types")
+	schema.GetProperty("minLength").SetMinimum(0).SetDescription("min length, only for text and array types")
+	schema.GetProperty("maxLength").SetMinimum(0).SetDescription("max length, only for text and array types")
+	schema.GetProperty("requiredIf").SetDescription("expression, the value is required only when it evaluates to true")
+	schema.GetProperty("message").SetDescription("message used when the rule check fails")
 }
 
 func (m *ProjectOptionItemSettingModel) Convert(helper *ModelHelper, namesDict, exportsDict map[string]bool) (*ProjectOptionItemSetting, error) {
@@ -229,16 +752,13 @@ func (m *ProjectOptionItemSettingModel) Convert(helper *ModelHelper, namesDict,
 	if typ == "" {
 		typ = CastTypeString
 	}
-	switch typ {
-	case CastTypeString:
-	case CastTypeBool:
-	case CastTypeInteger:
-	case CastTypeDecimal:
-	case CastTypeObject:
-	case CastTypeArray:
-	default:
+	itemType, valid := parseProjectOptionValueType(typ)
+	if !valid {
 		return nil, helper.Child("type").NewValueInvalidError(typ)
 	}
+	if m.Exists && typ != ProjectOptionValueTypePath && itemType != ProjectOptionValueTypePath {
+		return nil, helper.Child("exists").NewError("option exists only supports path type", KV("type", typ))
+	}
 
 	projectName := helper.GetStringVariable("projectName")
 	export := m.Export
@@ -252,15 +772,30 @@ func (m *ProjectOptionItemSettingModel) Convert(helper *ModelHelper, namesDict,
 		return nil, helper.Child("export").NewError("option export duplicated", KV("export", export))
 	}
 
-	setting := NewProjectOptionItemSetting(m.Name, typ, m.Usage, export, m.Hidden, m.Compute, m.Optional)
+	rule, err := m.convertRule(helper, typ, itemType)
+	if err != nil {
+		return nil, err
+	}
+
+	setting := NewProjectOptionItemSetting(m.Name, typ, itemType, m.Usage, export, m.Hidden, m.Compute, m.Optional || m.RequiredIf != "", m.Exists, rule)
+	setting.Dir = helper.GetStringVariable("projectDir")
+	setting.FileSystem = helper.GetFileSystemVariable("projectFileSystem")
 
-	if err := setting.setChoices(m.Choices); err != nil {
-		return nil, helper.Child("choices").WrapValueInvalidError(err, m.Choices)
+	choiceModels, err := m.getChoiceModels(helper.Child("choices"))
+	if err != nil {
+		return nil, err
+	}
+	choices, err := ConvertChildModels(helper, "choices", choiceModels)
+	if err != nil {
+		return nil, err
+	}
+	if err = setting.setChoices(choices); err != nil {
+		return nil, helper.Child("choices").WrapValueInvalidError(err, setting.MaskValue(m.Choices))
 	}
 
 	if setting.Compute == "" {
-		if err := setting.setDefault(m.Default); err != nil {
-			return nil, helper.Child("default").WrapValueInvalidError(err, m.Default)
+		if err = setting.setDefault(m.Default); err != nil {
+			return nil, helper.Child("default").WrapValueInvalidError(err, setting.MaskValue(m.Default))
 		}
 	} else if m.Default != nil {
 		return nil, helper.Child("default").NewError("option compute and default conflict")
@@ -271,4 +806,63 @@ func (m *ProjectOptionItemSettingModel) Convert(helper *ModelHelper, namesDict,
 	return setting, nil
 }
 
+// getChoiceModels gets the typed models of the choices, a scalar choice is the shorthand of the value.
+func (m *ProjectOptionItemSettingModel) getChoiceModels(helper *ModelHelper) (models []*ProjectOptionChoiceSettingModel, err error) {
+	for i := 0; i < len(m.Choices); i++ {
+		model := &ProjectOptionChoiceSettingModel{}
+		switch choice := m.Choices[i].(type) {
+		case map[string]any:
+			if err = DecodeModelMap(helper.Item(i), choice, model); err != nil {
+				return nil, err
+			}
+		default:
+			model.Value = choice
+		}
+		models = append(models, model)
+	}
+	return models, nil
+}
+
+func (m *ProjectOptionItemSettingModel) convertRule(helper *ModelHelper, typ, itemType ProjectOptionValueType) (*ProjectOptionRuleSetting, error) {
+	baseType := typ
+	if itemType != "" {
+		baseType = itemType
+	}
+
+	var pattern *regexp.Regexp
+	if m.Pattern != "" {
+		if !projectOptionTextTypesDict[baseType] {
+			return nil, helper.Child("pattern").NewError("option pattern only supports string types", KV("type", typ))
+		}
+		value, err := regexp.Compile(m.Pattern)
+		if err != nil {
+			return nil, helper.Child("pattern").WrapValueInvalidError(err, m.Pattern)
+		}
+		pattern = value
+	}
+
+	if m.Min != nil || m.Max != nil {
+		if !projectOptionNumberTypesDict[baseType] {
+			return nil, helper.NewError("option min and max only support number types", KV("type", typ))
+		}
+		if m.Min != nil && m.Max != nil && *m.Min > *m.Max {
+			return nil, helper.Child("max").NewValueInvalidError(*m.Max)
+		}
+	}
+
+	if m.MinLength != nil || m.MaxLength != nil {
+		if !projectOptionTextTypesDict[typ] && typ != CastTypeArray && itemType == "" {
+			return nil, helper.NewError("option minLength and maxLength only support string and array types", KV("type", typ))
+		}
+		if m.MinLength != nil && *m.MinLength < 0 {
+			return nil, helper.Child("minLength").NewValueInvalidError(*m.MinLength)
+		}
+		if m.MaxLength != nil && (*m.MaxLength < 0 || (m.MinLength != nil && *m.MinLength > *m.MaxLength)) {
+			return nil, helper.Child("maxLength").NewValueInvalidError(*m.MaxLength)
+		}
+	}
+
+	return NewProjectOptionRuleSetting(pattern, m.Min, m.Max, m.MinLength, m.MaxLength, m.RequiredIf, m.Message), nil
+}
+
 // endregion
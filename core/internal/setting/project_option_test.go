@@ -0,0 +1,115 @@
+package setting
+
+import (
+	. "github.com/orz-dsh/dsh/utils"
+	"reflect"
+	"testing"
+)
+
+func newTestProjectOptionHelper() *ModelHelper {
+	return NewModelHelper(NewLogger(LogLevelError), "project setting", "project.yml").
+		AddVariable("projectName", "app").
+		AddVariable("projectDir", "/app")
+}
+
+func TestProjectOptionChecks(t *testing.T) {
+	model := &ProjectOptionSettingModel{
+		Checks: []any{
+			"option.a != ''",
+			map[string]any{"expr": "option.b > 0", "message": "b must be positive", "names": []any{"b"}},
+		},
+	}
+	setting, err := model.Convert(newTestProjectOptionHelper())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(setting.Checks) != 2 {
+		t.Fatal("checks count", len(setting.Checks))
+	}
+	if setting.Checks[0].Expr != "option.a != ''" || setting.Checks[0].Message != "" {
+		t.Fatal("string check", setting.Checks[0])
+	}
+	if setting.Checks[1].Expr != "option.b > 0" || setting.Checks[1].Message != "b must be positive" || !reflect.DeepEqual(setting.Checks[1].Names, []string{"b"}) {
+		t.Fatal("object check", setting.Checks[1])
+	}
+
+	invalids := []any{
+		map[string]any{"expr": "true", "mesage": "typo"},
+		map[string]any{"message": "no expr"},
+		map[string]any{"expr": "true", "names": "b"},
+		map[string]any{"expr": "true", "names": []any{""}},
+		"",
+		1,
+	}
+	for i := 0; i < len(invalids); i++ {
+		model = &ProjectOptionSettingModel{Checks: []any{invalids[i]}}
+		if _, err = model.Convert(newTestProjectOptionHelper()); err == nil {
+			t.Fatal("invalid check should fail", invalids[i])
+		}
+	}
+}
+
+func TestProjectOptionChoices(t *testing.T) {
+	model := &ProjectOptionSettingModel{
+		Items: []*ProjectOptionItemSettingModel{{
+			Name: "level",
+			Type: CastTypeInteger,
+			Choices: []any{
+				1,
+				map[string]any{"value": 2, "usage": "the second level"},
+			},
+		}},
+	}
+	setting, err := model.Convert(newTestProjectOptionHelper())
+	if err != nil {
+		t.Fatal(err)
+	}
+	choices := setting.Items[0].Choices
+	if len(choices) != 2 || choices[0].Usage != "" || choices[1].Usage != "the second level" {
+		t.Fatal("choices", choices)
+	}
+	if !reflect.DeepEqual(setting.Items[0].GetChoiceValues(), []any{int64(1), int64(2)}) {
+		t.Fatal("choice values", setting.Items[0].GetChoiceValues())
+	}
+
+	invalids := []any{
+		map[string]any{"value": 1, "usge": "typo"},
+		map[string]any{"usage": "no value"},
+		map[string]any{"value": 1, "usage": 1},
+		nil,
+	}
+	for i := 0; i < len(invalids); i++ {
+		model.Items[0].Choices = []any{invalids[i]}
+		if _, err = model.Convert(newTestProjectOptionHelper()); err == nil {
+			t.Fatal("invalid choice should fail", invalids[i])
+		}
+	}
+}
+
+func TestProjectOptionDefault(t *testing.T) {
+	model := &ProjectOptionSettingModel{
+		Items: []*ProjectOptionItemSettingModel{
+			{Name: "count", Type: CastTypeInteger, Default: 3},
+			{Name: "enabled", Type: CastTypeBool, Default: true},
+			{Name: "level", Type: CastTypeInteger, Default: "2"},
+			{Name: "names", Type: "array<string>", Default: []any{"a", "b"}},
+		},
+	}
+	setting, err := model.Convert(newTestProjectOptionHelper())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []any{int64(3), true, int64(2), []any{"a", "b"}}
+	for i := 0; i < len(expected); i++ {
+		if !reflect.DeepEqual(setting.Items[i].Default, expected[i]) {
+			t.Fatal("default", setting.Items[i].Name, setting.Items[i].Default)
+		}
+	}
+
+	model = &ProjectOptionSettingModel{
+		Items: []*ProjectOptionItemSettingModel{{Name: "count", Type: CastTypeInteger, Default: "x"}},
+	}
+	if _, err = model.Convert(newTestProjectOptionHelper()); err == nil {
+		t.Fatal("invalid default should fail")
+	}
+}
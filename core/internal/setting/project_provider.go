@@ -0,0 +1,257 @@
+package setting
+
+import (
+	. "github.com/orz-dsh/dsh/utils"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// region base
+
+var projectProviderEnvNameCheckRegex = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+
+var projectProviderExecTimeoutDefault = 10 * time.Second
+
+// endregion
+
+// region ProjectProviderSetting
+
+// ProjectProviderSetting declares the data providers used by the expressions and templates of the project,
+// a provider is disabled if it is not declared.
+type ProjectProviderSetting struct {
+	Env  *ProjectProviderEnvSetting
+	File *ProjectProviderFileSetting
+	Exec *ProjectProviderExecSetting
+}
+
+func NewProjectProviderSetting(env *ProjectProviderEnvSetting, file *ProjectProviderFileSetting, exec *ProjectProviderExecSetting) *ProjectProviderSetting {
+	return &ProjectProviderSetting{
+		Env:  env,
+		File: file,
+		Exec: exec,
+	}
+}
+
+// endregion
+
+// region ProjectProviderEnvSetting
+
+type ProjectProviderEnvSetting struct {
+	Names []string
+}
+
+func NewProjectProviderEnvSetting(names []string) *ProjectProviderEnvSetting {
+	return &ProjectProviderEnvSetting{
+		Names: names,
+	}
+}
+
+// endregion
+
+// region ProjectProviderFileSetting
+
+type ProjectProviderFileSetting struct {
+	Includes []string
+}
+
+func NewProjectProviderFileSetting(includes []string) *ProjectProviderFileSetting {
+	return &ProjectProviderFileSetting{
+		Includes: includes,
+	}
+}
+
+// IsIncluded checks whether the file relative to the project dir is matched by the include patterns.
+func (s *ProjectProviderFileSetting) IsIncluded(file string) bool {
+	file = filepath.ToSlash(file)
+	for i := 0; i < len(s.Includes); i++ {
+		if matched, _ := filepath.Match(s.Includes[i], file); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// endregion
+
+// region ProjectProviderExecSetting
+
+type ProjectProviderExecSetting struct {
+	Commands []string
+	Timeout  time.Duration
+}
+
+func NewProjectProviderExecSetting(commands []string, timeout time.Duration) *ProjectProviderExecSetting {
+	return &ProjectProviderExecSetting{
+		Commands: commands,
+		Timeout:  timeout,
+	}
+}
+
+func (s *ProjectProviderExecSetting) IsAllowed(command string) bool {
+	return slices.Contains(s.Commands, command)
+}
+
+// endregion
+
+// region ProjectProviderSettingModel
+
+type ProjectProviderSettingModel struct {
+	Env  *ProjectProviderEnvSettingModel  `yaml:"env,omitempty" toml:"env,omitempty" json:"env,omitempty"`
+	File *ProjectProviderFileSettingModel `yaml:"file,omitempty" toml:"file,omitempty" json:"file,omitempty"`
+	Exec *ProjectProviderExecSettingModel `yaml:"exec,omitempty" toml:"exec,omitempty" json:"exec,omitempty"`
+}
+
+func NewProjectProviderSettingModel(env *ProjectProviderEnvSettingModel, file *ProjectProviderFileSettingModel, exec *ProjectProviderExecSettingModel) *ProjectProviderSettingModel {
+	return &ProjectProviderSettingModel{
+		Env:  env,
+		File: file,
+		Exec: exec,
+	}
+}
+
+func (m *ProjectProviderSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("env").SetDescription("environment variables provider, such as `env.CI_COMMIT_SHA`")
+	schema.GetProperty("file").SetDescription("file provider, such as `file.text('VERSION')`, `file.json('a.json')` or `file.yaml('a.yml')`")
+	schema.GetProperty("exec").SetDescription("command output provider, such as `exec.output('git', 'describe')`")
+}
+
+func (m *ProjectProviderSettingModel) Convert(helper *ModelHelper) (_ *ProjectProviderSetting, err error) {
+	var env *ProjectProviderEnvSetting
+	if m.Env != nil {
+		if env, err = m.Env.Convert(helper.Child("env")); err != nil {
+			return nil, err
+		}
+	}
+
+	var file *ProjectProviderFileSetting
+	if m.File != nil {
+		if file, err = m.File.Convert(helper.Child("file")); err != nil {
+			return nil, err
+		}
+	}
+
+	var exec *ProjectProviderExecSetting
+	if m.Exec != nil {
+		if exec, err = m.Exec.Convert(helper.Child("exec")); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewProjectProviderSetting(env, file, exec), nil
+}
+
+// endregion
+
+// region ProjectProviderEnvSettingModel
+
+type ProjectProviderEnvSettingModel struct {
+	Names []string `yaml:"names" toml:"names" json:"names"`
+}
+
+func NewProjectProviderEnvSettingModel(names []string) *ProjectProviderEnvSettingModel {
+	return &ProjectProviderEnvSettingModel{
+		Names: names,
+	}
+}
+
+func (m *ProjectProviderEnvSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("names").SetItems(NewJsonSchema("string").SetPattern(projectProviderEnvNameCheckRegex.String())).SetDescription("names of the environment variables to provide")
+}
+
+func (m *ProjectProviderEnvSettingModel) Convert(helper *ModelHelper) (*ProjectProviderEnvSetting, error) {
+	if len(m.Names) == 0 {
+		return nil, helper.Child("names").NewValueEmptyError()
+	}
+	for i := 0; i < len(m.Names); i++ {
+		name := m.Names[i]
+		if name == "" {
+			return nil, helper.ChildItem("names", i).NewValueEmptyError()
+		}
+		if !projectProviderEnvNameCheckRegex.MatchString(name) {
+			return nil, helper.ChildItem("names", i).NewValueInvalidError(name)
+		}
+	}
+	return NewProjectProviderEnvSetting(m.Names), nil
+}
+
+// endregion
+
+// region ProjectProviderFileSettingModel
+
+type ProjectProviderFileSettingModel struct {
+	Includes []string `yaml:"includes" toml:"includes" json:"includes"`
+}
+
+func NewProjectProviderFileSettingModel(includes []string) *ProjectProviderFileSettingModel {
+	return &ProjectProviderFileSettingModel{
+		Includes: includes,
+	}
+}
+
+func (m *ProjectProviderFileSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("includes").SetDescription("glob patterns of the files to provide, relative to the project dir")
+}
+
+func (m *ProjectProviderFileSettingModel) Convert(helper *ModelHelper) (*ProjectProviderFileSetting, error) {
+	if len(m.Includes) == 0 {
+		return nil, helper.Child("includes").NewValueEmptyError()
+	}
+	for i := 0; i < len(m.Includes); i++ {
+		include := m.Includes[i]
+		if include == "" {
+			return nil, helper.ChildItem("includes", i).NewValueEmptyError()
+		}
+		if _, err := filepath.Match(include, ""); err != nil {
+			return nil, helper.ChildItem("includes", i).WrapValueInvalidError(err, include)
+		}
+	}
+	return NewProjectProviderFileSetting(m.Includes), nil
+}
+
+// endregion
+
+// region ProjectProviderExecSettingModel
+
+type ProjectProviderExecSettingModel struct {
+	Commands []string `yaml:"commands" toml:"commands" json:"commands"`
+	Timeout  string   `yaml:"timeout,omitempty" toml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+func NewProjectProviderExecSettingModel(commands []string, timeout string) *ProjectProviderExecSettingModel {
+	return &ProjectProviderExecSettingModel{
+		Commands: commands,
+		Timeout:  timeout,
+	}
+}
+
+func (m *ProjectProviderExecSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("commands").SetDescription("names of the commands allowed to execute")
+	schema.GetProperty("timeout").SetDescription("timeout of each command, such as `5s`, default is `10s`")
+}
+
+func (m *ProjectProviderExecSettingModel) Convert(helper *ModelHelper) (*ProjectProviderExecSetting, error) {
+	if len(m.Commands) == 0 {
+		return nil, helper.Child("commands").NewValueEmptyError()
+	}
+	if err := helper.CheckStringItemEmpty("commands", m.Commands); err != nil {
+		return nil, err
+	}
+
+	timeout := projectProviderExecTimeoutDefault
+	if m.Timeout != "" {
+		value, err := time.ParseDuration(m.Timeout)
+		if err != nil {
+			return nil, helper.Child("timeout").WrapValueInvalidError(err, m.Timeout)
+		}
+		if value <= 0 {
+			return nil, helper.Child("timeout").NewValueInvalidError(m.Timeout)
+		}
+		timeout = value
+	}
+
+	return NewProjectProviderExecSetting(m.Commands, timeout), nil
+}
+
+// endregion
@@ -82,6 +82,13 @@ type ProjectResourceItemSettingModel struct {
 	Match    string   `yaml:"match,omitempty" toml:"match,omitempty" json:"match,omitempty"`
 }
 
+func (m *ProjectResourceItemSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("dir").SetMinLength(1).SetDescription("resource dir relative to the project dir")
+	schema.GetProperty("includes").SetDescription("glob patterns of included files")
+	schema.GetProperty("excludes").SetDescription("glob patterns of excluded files")
+	schema.GetProperty("match").SetDescription("expression to match, the item is used only when it evaluates to true")
+}
+
 func NewProjectResourceItemSettingModel(dir string, includes, excludes []string, match string) *ProjectResourceItemSettingModel {
 	return &ProjectResourceItemSettingModel{
 		Dir:      dir,
@@ -25,6 +25,11 @@ type ProjectRuntimeSettingModel struct {
 	MaxVersion Version `yaml:"maxVersion,omitempty" toml:"maxVersion,omitempty" json:"maxVersion,omitempty"`
 }
 
+func (m *ProjectRuntimeSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("minVersion").SetDescription("min runtime version")
+	schema.GetProperty("maxVersion").SetDescription("max runtime version")
+}
+
 func (m *ProjectRuntimeSettingModel) Convert(helper *ModelHelper) (*ProjectRuntimeSetting, error) {
 	if err := CheckRuntimeVersion(m.MinVersion, m.MaxVersion); err != nil {
 		return nil, helper.WrapError(err, "runtime incompatible",
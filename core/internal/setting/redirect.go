@@ -9,7 +9,7 @@ import (
 
 // region base
 
-var redirectLinkCheckRegex = regexp.MustCompile("^(git|dir):.*$")
+var redirectLinkCheckRegex = regexp.MustCompile("^(git|dir|archive|oci|https?):.*$")
 
 // endregion
 
@@ -134,6 +134,12 @@ type RedirectItemSettingModel struct {
 	Match string `yaml:"match,omitempty" toml:"match,omitempty" json:"match,omitempty"`
 }
 
+func (m *RedirectItemSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("regex").SetMinLength(1).SetDescription("regex of project links to redirect")
+	schema.GetProperty("link").SetPattern(redirectLinkCheckRegex.String()).SetDescription("redirected link template")
+	schema.GetProperty("match").SetDescription("expression to match, the item is used only when it evaluates to true")
+}
+
 func NewRedirectItemSettingModel(regex, link, match string) *RedirectItemSettingModel {
 	return &RedirectItemSettingModel{
 		Regex: regex,
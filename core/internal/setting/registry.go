@@ -11,7 +11,7 @@ import (
 
 var registryNameCheckRegex = regexp.MustCompile("^[a-z][a-z0-9-]*[a-z0-9]$")
 
-var registryLinkCheckRegex = regexp.MustCompile("^(git|dir):.*$")
+var registryLinkCheckRegex = regexp.MustCompile("^(git|dir|archive|oci|https?):.*$")
 
 // endregion
 
@@ -169,6 +169,12 @@ type RegistryItemSettingModel struct {
 	Match string `yaml:"match,omitempty" toml:"match,omitempty" json:"match,omitempty"`
 }
 
+func (m *RegistryItemSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("name").SetPattern(registryNameCheckRegex.String()).SetDescription("registry name")
+	schema.GetProperty("link").SetPattern(registryLinkCheckRegex.String()).SetDescription("registry link template")
+	schema.GetProperty("match").SetDescription("expression to match, the item is used only when it evaluates to true")
+}
+
 func NewRegistryItemSettingModel(name, link, match string) *RegistryItemSettingModel {
 	return &RegistryItemSettingModel{
 		Name:  name,
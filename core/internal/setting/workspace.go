@@ -9,15 +9,19 @@ import (
 
 type WorkspaceSetting struct {
 	Clean    *WorkspaceCleanSetting
+	Lock     *WorkspaceLockSetting
 	Profile  *WorkspaceProfileSetting
 	Executor *ExecutorSetting
 	Registry *RegistrySetting
 	Redirect *RedirectSetting
 }
 
-func NewWorkspaceSetting(clean *WorkspaceCleanSetting, profile *WorkspaceProfileSetting, executor *ExecutorSetting, registry *RegistrySetting, redirect *RedirectSetting) *WorkspaceSetting {
+func NewWorkspaceSetting(clean *WorkspaceCleanSetting, lock *WorkspaceLockSetting, profile *WorkspaceProfileSetting, executor *ExecutorSetting, registry *RegistrySetting, redirect *RedirectSetting) *WorkspaceSetting {
 	if clean == nil {
-		clean = NewWorkspaceCleanSetting(nil)
+		clean = NewWorkspaceCleanSetting(nil, nil)
+	}
+	if lock == nil {
+		lock = NewWorkspaceLockSetting(nil)
 	}
 	if profile == nil {
 		profile = NewWorkspaceProfileSetting(nil)
@@ -33,6 +37,7 @@ func NewWorkspaceSetting(clean *WorkspaceCleanSetting, profile *WorkspaceProfile
 	}
 	return &WorkspaceSetting{
 		Clean:    clean,
+		Lock:     lock,
 		Profile:  profile,
 		Executor: executor,
 		Registry: registry,
@@ -49,11 +54,11 @@ func LoadWorkspaceSetting(logger *Logger, dir string) (setting *WorkspaceSetting
 			KV("dir", dir),
 		)
 	}
-	source := "default"
+	helper := NewModelHelper(logger, "workspace setting", "default")
 	if metadata != nil {
-		source = metadata.File
+		helper = NewModelHelper(logger, "workspace setting", metadata.File).SetPositions(metadata.Positions)
 	}
-	if setting, err = model.Convert(NewModelHelper(logger, "workspace setting", source)); err != nil {
+	if setting, err = model.Convert(helper); err != nil {
 		return nil, err
 	}
 	return setting, nil
@@ -61,6 +66,7 @@ func LoadWorkspaceSetting(logger *Logger, dir string) (setting *WorkspaceSetting
 
 func (s *WorkspaceSetting) Merge(other *WorkspaceSetting) {
 	s.Clean.Merge(other.Clean)
+	s.Lock.Merge(other.Lock)
 	s.Profile.Merge(other.Profile)
 	s.Executor.Merge(other.Executor)
 	s.Registry.Merge(other.Registry)
@@ -69,6 +75,7 @@ func (s *WorkspaceSetting) Merge(other *WorkspaceSetting) {
 
 func (s *WorkspaceSetting) MergeDefault() {
 	s.Clean.MergeDefault()
+	s.Lock.MergeDefault()
 	s.Executor.MergeDefault()
 	s.Registry.MergeDefault()
 }
@@ -76,6 +83,7 @@ func (s *WorkspaceSetting) MergeDefault() {
 func (s *WorkspaceSetting) Inspect() *WorkspaceSettingInspection {
 	return NewWorkspaceSettingInspection(
 		s.Clean.Inspect(),
+		s.Lock.Inspect(),
 		s.Profile.Inspect(),
 		s.Executor.Inspect(),
 		s.Registry.Inspect(),
@@ -89,15 +97,27 @@ func (s *WorkspaceSetting) Inspect() *WorkspaceSettingInspection {
 
 type WorkspaceSettingModel struct {
 	Clean    *WorkspaceCleanSettingModel   `yaml:"clean,omitempty" toml:"clean,omitempty" json:"clean,omitempty"`
+	Lock     *WorkspaceLockSettingModel    `yaml:"lock,omitempty" toml:"lock,omitempty" json:"lock,omitempty"`
 	Profile  *WorkspaceProfileSettingModel `yaml:"profile,omitempty" toml:"profile,omitempty" json:"profile,omitempty"`
 	Executor *ExecutorSettingModel         `yaml:"executor,omitempty" toml:"executor,omitempty" json:"executor,omitempty"`
 	Registry *RegistrySettingModel         `yaml:"registry,omitempty" toml:"registry,omitempty" json:"registry,omitempty"`
 	Redirect *RedirectSettingModel         `yaml:"redirect,omitempty" toml:"redirect,omitempty" json:"redirect,omitempty"`
 }
 
-func NewWorkspaceSettingModel(clean *WorkspaceCleanSettingModel, profile *WorkspaceProfileSettingModel, executor *ExecutorSettingModel, registry *RegistrySettingModel, redirect *RedirectSettingModel) *WorkspaceSettingModel {
+func (m *WorkspaceSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.SetDescription("workspace setting file, such as workspace.yml")
+	schema.GetProperty("clean").SetDescription("workspace clean policy")
+	schema.GetProperty("lock").SetDescription("workspace lock")
+	schema.GetProperty("profile").SetDescription("profiles loaded by default")
+	schema.GetProperty("executor").SetDescription("script executors")
+	schema.GetProperty("registry").SetDescription("project registries")
+	schema.GetProperty("redirect").SetDescription("project link redirects")
+}
+
+func NewWorkspaceSettingModel(clean *WorkspaceCleanSettingModel, lock *WorkspaceLockSettingModel, profile *WorkspaceProfileSettingModel, executor *ExecutorSettingModel, registry *RegistrySettingModel, redirect *RedirectSettingModel) *WorkspaceSettingModel {
 	return &WorkspaceSettingModel{
 		Clean:    clean,
+		Lock:     lock,
 		Profile:  profile,
 		Executor: executor,
 		Registry: registry,
@@ -113,6 +133,13 @@ func (m *WorkspaceSettingModel) Convert(helper *ModelHelper) (_ *WorkspaceSettin
 		}
 	}
 
+	var lock *WorkspaceLockSetting
+	if m.Lock != nil {
+		if lock, err = m.Lock.Convert(helper.Child("lock")); err != nil {
+			return nil, err
+		}
+	}
+
 	var profile *WorkspaceProfileSetting
 	if m.Profile != nil {
 		if profile, err = m.Profile.Convert(helper.Child("profile")); err != nil {
@@ -141,7 +168,7 @@ func (m *WorkspaceSettingModel) Convert(helper *ModelHelper) (_ *WorkspaceSettin
 		}
 	}
 
-	return NewWorkspaceSetting(clean, profile, executor, registry, redirect), nil
+	return NewWorkspaceSetting(clean, lock, profile, executor, registry, redirect), nil
 }
 
 // endregion
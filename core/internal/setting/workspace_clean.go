@@ -10,36 +10,45 @@ import (
 
 var workspaceCleanOutputCountDefault = 3
 var workspaceCleanOutputExpiresDefault = 24 * time.Hour
+var workspaceCleanOutputSizeDefault = int64(0)
+var workspaceCleanProjectExpiresDefault = 30 * 24 * time.Hour
 
 // endregion
 
 // region WorkspaceCleanSetting
 
 type WorkspaceCleanSetting struct {
-	Output *WorkspaceCleanOutputSetting
+	Output  *WorkspaceCleanOutputSetting
+	Project *WorkspaceCleanProjectSetting
 }
 
-func NewWorkspaceCleanSetting(output *WorkspaceCleanOutputSetting) *WorkspaceCleanSetting {
+func NewWorkspaceCleanSetting(output *WorkspaceCleanOutputSetting, project *WorkspaceCleanProjectSetting) *WorkspaceCleanSetting {
 	if output == nil {
-		output = NewWorkspaceCleanOutputSetting(nil, nil)
+		output = NewWorkspaceCleanOutputSetting(nil, nil, nil, nil)
+	}
+	if project == nil {
+		project = NewWorkspaceCleanProjectSetting(nil)
 	}
 	return &WorkspaceCleanSetting{
-		Output: output,
+		Output:  output,
+		Project: project,
 	}
 }
 
 func (s *WorkspaceCleanSetting) Merge(other *WorkspaceCleanSetting) *WorkspaceCleanSetting {
 	s.Output.Merge(other.Output)
+	s.Project.Merge(other.Project)
 	return s
 }
 
 func (s *WorkspaceCleanSetting) MergeDefault() *WorkspaceCleanSetting {
 	s.Output.MergeDefault()
+	s.Project.MergeDefault()
 	return s
 }
 
 func (s *WorkspaceCleanSetting) Inspect() *WorkspaceCleanSettingInspection {
-	return NewWorkspaceCleanSettingInspection(s.Output.Inspect())
+	return NewWorkspaceCleanSettingInspection(s.Output.Inspect(), s.Project.Inspect())
 }
 
 // endregion
@@ -49,12 +58,16 @@ func (s *WorkspaceCleanSetting) Inspect() *WorkspaceCleanSettingInspection {
 type WorkspaceCleanOutputSetting struct {
 	Count   *int
 	Expires *time.Duration
+	Size    *int64
+	Items   []*WorkspaceCleanOutputItemSetting
 }
 
-func NewWorkspaceCleanOutputSetting(count *int, expires *time.Duration) *WorkspaceCleanOutputSetting {
+func NewWorkspaceCleanOutputSetting(count *int, expires *time.Duration, size *int64, items []*WorkspaceCleanOutputItemSetting) *WorkspaceCleanOutputSetting {
 	return &WorkspaceCleanOutputSetting{
 		Count:   count,
 		Expires: expires,
+		Size:    size,
+		Items:   items,
 	}
 }
 
@@ -65,6 +78,10 @@ func (s *WorkspaceCleanOutputSetting) Merge(other *WorkspaceCleanOutputSetting)
 	if s.Expires == nil {
 		s.Expires = other.Expires
 	}
+	if s.Size == nil {
+		s.Size = other.Size
+	}
+	s.Items = append(s.Items, other.Items...)
 	return s
 }
 
@@ -75,11 +92,93 @@ func (s *WorkspaceCleanOutputSetting) MergeDefault() *WorkspaceCleanOutputSettin
 	if s.Expires == nil {
 		s.Expires = &workspaceCleanOutputExpiresDefault
 	}
+	if s.Size == nil {
+		s.Size = &workspaceCleanOutputSizeDefault
+	}
 	return s
 }
 
+func (s *WorkspaceCleanOutputSetting) GetPolicy(projectName string) (count int, expires time.Duration) {
+	count = *s.Count
+	expires = *s.Expires
+	countFound, expiresFound := false, false
+	for i := 0; i < len(s.Items); i++ {
+		item := s.Items[i]
+		if item.Name != projectName {
+			continue
+		}
+		if !countFound && item.Count != nil {
+			count = *item.Count
+			countFound = true
+		}
+		if !expiresFound && item.Expires != nil {
+			expires = *item.Expires
+			expiresFound = true
+		}
+	}
+	return count, expires
+}
+
 func (s *WorkspaceCleanOutputSetting) Inspect() *WorkspaceCleanOutputSettingInspection {
-	return NewWorkspaceCleanOutputSettingInspection(s.Count, s.Expires)
+	var items []*WorkspaceCleanOutputItemSettingInspection
+	for i := 0; i < len(s.Items); i++ {
+		items = append(items, s.Items[i].Inspect())
+	}
+	return NewWorkspaceCleanOutputSettingInspection(s.Count, s.Expires, s.Size, items)
+}
+
+// endregion
+
+// region WorkspaceCleanOutputItemSetting
+
+type WorkspaceCleanOutputItemSetting struct {
+	Name    string
+	Count   *int
+	Expires *time.Duration
+}
+
+func NewWorkspaceCleanOutputItemSetting(name string, count *int, expires *time.Duration) *WorkspaceCleanOutputItemSetting {
+	return &WorkspaceCleanOutputItemSetting{
+		Name:    name,
+		Count:   count,
+		Expires: expires,
+	}
+}
+
+func (s *WorkspaceCleanOutputItemSetting) Inspect() *WorkspaceCleanOutputItemSettingInspection {
+	return NewWorkspaceCleanOutputItemSettingInspection(s.Name, s.Count, s.Expires)
+}
+
+// endregion
+
+// region WorkspaceCleanProjectSetting
+
+type WorkspaceCleanProjectSetting struct {
+	Expires *time.Duration
+}
+
+func NewWorkspaceCleanProjectSetting(expires *time.Duration) *WorkspaceCleanProjectSetting {
+	return &WorkspaceCleanProjectSetting{
+		Expires: expires,
+	}
+}
+
+func (s *WorkspaceCleanProjectSetting) Merge(other *WorkspaceCleanProjectSetting) *WorkspaceCleanProjectSetting {
+	if s.Expires == nil {
+		s.Expires = other.Expires
+	}
+	return s
+}
+
+func (s *WorkspaceCleanProjectSetting) MergeDefault() *WorkspaceCleanProjectSetting {
+	if s.Expires == nil {
+		s.Expires = &workspaceCleanProjectExpiresDefault
+	}
+	return s
+}
+
+func (s *WorkspaceCleanProjectSetting) Inspect() *WorkspaceCleanProjectSettingInspection {
+	return NewWorkspaceCleanProjectSettingInspection(s.Expires)
 }
 
 // endregion
@@ -87,12 +186,14 @@ func (s *WorkspaceCleanOutputSetting) Inspect() *WorkspaceCleanOutputSettingInsp
 // region WorkspaceCleanSettingModel
 
 type WorkspaceCleanSettingModel struct {
-	Output *WorkspaceCleanOutputSettingModel `yaml:"output,omitempty" toml:"output,omitempty" json:"output,omitempty"`
+	Output  *WorkspaceCleanOutputSettingModel  `yaml:"output,omitempty" toml:"output,omitempty" json:"output,omitempty"`
+	Project *WorkspaceCleanProjectSettingModel `yaml:"project,omitempty" toml:"project,omitempty" json:"project,omitempty"`
 }
 
-func NewWorkspaceCleanSettingModel(output *WorkspaceCleanOutputSettingModel) *WorkspaceCleanSettingModel {
+func NewWorkspaceCleanSettingModel(output *WorkspaceCleanOutputSettingModel, project *WorkspaceCleanProjectSettingModel) *WorkspaceCleanSettingModel {
 	return &WorkspaceCleanSettingModel{
-		Output: output,
+		Output:  output,
+		Project: project,
 	}
 }
 
@@ -104,7 +205,16 @@ func (m *WorkspaceCleanSettingModel) Convert(helper *ModelHelper) (_ *WorkspaceC
 			return nil, err
 		}
 	}
-	return NewWorkspaceCleanSetting(output), nil
+
+	var project *WorkspaceCleanProjectSetting
+	if m.Project != nil {
+		project, err = m.Project.Convert(helper.Child("project"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewWorkspaceCleanSetting(output, project), nil
 }
 
 // endregion
@@ -112,14 +222,24 @@ func (m *WorkspaceCleanSettingModel) Convert(helper *ModelHelper) (_ *WorkspaceC
 // region WorkspaceCleanOutputSettingModel
 
 type WorkspaceCleanOutputSettingModel struct {
-	Count   *int   `yaml:"count,omitempty" toml:"count,omitempty" json:"count,omitempty"`
-	Expires string `yaml:"expires,omitempty" toml:"expires,omitempty" json:"expires,omitempty"`
+	Count   *int                                    `yaml:"count,omitempty" toml:"count,omitempty" json:"count,omitempty"`
+	Expires string                                  `yaml:"expires,omitempty" toml:"expires,omitempty" json:"expires,omitempty"`
+	Size    string                                  `yaml:"size,omitempty" toml:"size,omitempty" json:"size,omitempty"`
+	Items   []*WorkspaceCleanOutputItemSettingModel `yaml:"items,omitempty" toml:"items,omitempty" json:"items,omitempty"`
 }
 
-func NewWorkspaceCleanOutputSettingModel(count *int, expires string) *WorkspaceCleanOutputSettingModel {
+func (m *WorkspaceCleanOutputSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("count").SetMinimum(1).SetDescription("max count of outputs to keep")
+	schema.GetProperty("expires").SetDescription("duration to keep outputs, such as `168h`")
+	schema.GetProperty("size").SetDescription("max total size of outputs, such as `1GB`")
+}
+
+func NewWorkspaceCleanOutputSettingModel(count *int, expires, size string, items []*WorkspaceCleanOutputItemSettingModel) *WorkspaceCleanOutputSettingModel {
 	return &WorkspaceCleanOutputSettingModel{
 		Count:   count,
 		Expires: expires,
+		Size:    size,
+		Items:   items,
 	}
 }
 
@@ -142,7 +262,105 @@ func (m *WorkspaceCleanOutputSettingModel) Convert(helper *ModelHelper) (*Worksp
 		expires = &value
 	}
 
-	return NewWorkspaceCleanOutputSetting(count, expires), nil
+	var size *int64
+	if m.Size != "" {
+		value, err := ParseByteSize(m.Size)
+		if err != nil {
+			return nil, helper.Child("size").WrapValueInvalidError(err, m.Size)
+		}
+		size = &value
+	}
+
+	items, err := ConvertChildModels(helper, "items", m.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWorkspaceCleanOutputSetting(count, expires, size, items), nil
+}
+
+// endregion
+
+// region WorkspaceCleanOutputItemSettingModel
+
+type WorkspaceCleanOutputItemSettingModel struct {
+	Name    string `yaml:"name" toml:"name" json:"name"`
+	Count   *int   `yaml:"count,omitempty" toml:"count,omitempty" json:"count,omitempty"`
+	Expires string `yaml:"expires,omitempty" toml:"expires,omitempty" json:"expires,omitempty"`
+}
+
+func (m *WorkspaceCleanOutputItemSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("name").SetPattern(projectNameCheckRegex.String()).SetDescription("project name")
+	schema.GetProperty("count").SetMinimum(1).SetDescription("max count of outputs to keep")
+	schema.GetProperty("expires").SetDescription("duration to keep outputs, such as `168h`")
+}
+
+func NewWorkspaceCleanOutputItemSettingModel(name string, count *int, expires string) *WorkspaceCleanOutputItemSettingModel {
+	return &WorkspaceCleanOutputItemSettingModel{
+		Name:    name,
+		Count:   count,
+		Expires: expires,
+	}
+}
+
+func (m *WorkspaceCleanOutputItemSettingModel) Convert(helper *ModelHelper) (*WorkspaceCleanOutputItemSetting, error) {
+	if m.Name == "" {
+		return nil, helper.Child("name").NewValueEmptyError()
+	}
+	if !projectNameCheckRegex.MatchString(m.Name) {
+		return nil, helper.Child("name").NewValueInvalidError(m.Name)
+	}
+
+	var count *int
+	if m.Count != nil {
+		value := *m.Count
+		if value <= 0 {
+			return nil, helper.Child("count").NewValueInvalidError(value)
+		}
+		count = &value
+	}
+
+	var expires *time.Duration
+	if m.Expires != "" {
+		value, err := time.ParseDuration(m.Expires)
+		if err != nil {
+			return nil, helper.Child("expires").WrapValueInvalidError(err, m.Expires)
+		}
+		expires = &value
+	}
+
+	return NewWorkspaceCleanOutputItemSetting(m.Name, count, expires), nil
+}
+
+// endregion
+
+// region WorkspaceCleanProjectSettingModel
+
+type WorkspaceCleanProjectSettingModel struct {
+	Expires string `yaml:"expires,omitempty" toml:"expires,omitempty" json:"expires,omitempty"`
+}
+
+func (m *WorkspaceCleanProjectSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("expires").SetDescription("duration to keep downloaded projects, such as `720h`")
+}
+
+func NewWorkspaceCleanProjectSettingModel(expires string) *WorkspaceCleanProjectSettingModel {
+	return &WorkspaceCleanProjectSettingModel{
+		Expires: expires,
+	}
+}
+
+func (m *WorkspaceCleanProjectSettingModel) Convert(helper *ModelHelper) (*WorkspaceCleanProjectSetting, error) {
+	var expires *time.Duration
+	if m.Expires != "" {
+		value, err := time.ParseDuration(m.Expires)
+		if err != nil {
+			return nil, helper.Child("expires").WrapValueInvalidError(err, m.Expires)
+		}
+		expires = &value
+	}
+
+	return NewWorkspaceCleanProjectSetting(expires), nil
 }
 
 // endregion
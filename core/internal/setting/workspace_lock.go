@@ -0,0 +1,79 @@
+package setting
+
+import (
+	. "github.com/orz-dsh/dsh/core/inspection"
+	. "github.com/orz-dsh/dsh/utils"
+	"time"
+)
+
+// region default
+
+var workspaceLockTimeoutDefault = 10 * time.Minute
+
+// endregion
+
+// region WorkspaceLockSetting
+
+type WorkspaceLockSetting struct {
+	Timeout *time.Duration
+}
+
+func NewWorkspaceLockSetting(timeout *time.Duration) *WorkspaceLockSetting {
+	return &WorkspaceLockSetting{
+		Timeout: timeout,
+	}
+}
+
+func (s *WorkspaceLockSetting) Merge(other *WorkspaceLockSetting) *WorkspaceLockSetting {
+	if s.Timeout == nil {
+		s.Timeout = other.Timeout
+	}
+	return s
+}
+
+func (s *WorkspaceLockSetting) MergeDefault() *WorkspaceLockSetting {
+	if s.Timeout == nil {
+		s.Timeout = &workspaceLockTimeoutDefault
+	}
+	return s
+}
+
+func (s *WorkspaceLockSetting) Inspect() *WorkspaceLockSettingInspection {
+	return NewWorkspaceLockSettingInspection(s.Timeout)
+}
+
+// endregion
+
+// region WorkspaceLockSettingModel
+
+type WorkspaceLockSettingModel struct {
+	Timeout string `yaml:"timeout,omitempty" toml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+func (m *WorkspaceLockSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("timeout").SetDescription("duration to wait for the workspace lock, such as `30s`")
+}
+
+func NewWorkspaceLockSettingModel(timeout string) *WorkspaceLockSettingModel {
+	return &WorkspaceLockSettingModel{
+		Timeout: timeout,
+	}
+}
+
+func (m *WorkspaceLockSettingModel) Convert(helper *ModelHelper) (*WorkspaceLockSetting, error) {
+	var timeout *time.Duration
+	if m.Timeout != "" {
+		value, err := time.ParseDuration(m.Timeout)
+		if err != nil {
+			return nil, helper.Child("timeout").WrapValueInvalidError(err, m.Timeout)
+		}
+		if value < 0 {
+			return nil, helper.Child("timeout").NewValueInvalidError(m.Timeout)
+		}
+		timeout = &value
+	}
+
+	return NewWorkspaceLockSetting(timeout), nil
+}
+
+// endregion
@@ -124,6 +124,12 @@ type WorkspaceProfileItemSettingModel struct {
 	Match    string `yaml:"match,omitempty" toml:"match,omitempty" json:"match,omitempty"`
 }
 
+func (m *WorkspaceProfileItemSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("file").SetMinLength(1).SetDescription("profile file, relative to the workspace dir")
+	schema.GetProperty("optional").SetDescription("ignore the profile if the file does not exist")
+	schema.GetProperty("match").SetDescription("expression to match, the item is used only when it evaluates to true")
+}
+
 func NewWorkspaceProfileItemSettingModel(file string, optional bool, match string) *WorkspaceProfileItemSettingModel {
 	return &WorkspaceProfileItemSettingModel{
 		File:     file,
@@ -0,0 +1,30 @@
+package internal
+
+import (
+	. "github.com/orz-dsh/dsh/core/common"
+	. "github.com/orz-dsh/dsh/core/internal/setting"
+	. "github.com/orz-dsh/dsh/utils"
+)
+
+const settingSchemaIdPrefix = "https://github.com/orz-dsh/dsh/schema/"
+
+func MakeSettingSchema(kind SettingSchemaKind) (*JsonSchema, error) {
+	id := settingSchemaIdPrefix + string(kind) + ".json"
+	switch kind {
+	case SettingSchemaKindProject:
+		return NewJsonSchemaByModel(id, "dsh project setting", &ProjectSettingModel{}), nil
+	case SettingSchemaKindProjectInclude:
+		return NewJsonSchemaByModel(id, "dsh project setting include", &ProjectIncludeSettingModel{}), nil
+	case SettingSchemaKindProfile:
+		return NewJsonSchemaByModel(id, "dsh profile setting", &ProfileSettingModel{}), nil
+	case SettingSchemaKindWorkspace:
+		return NewJsonSchemaByModel(id, "dsh workspace setting", &WorkspaceSettingModel{}), nil
+	case SettingSchemaKindConfig:
+		return NewJsonSchemaByModel(id, "dsh config resource", &ProjectResourceConfigItemContent{}), nil
+	default:
+		return nil, ErrN("make setting schema error",
+			Reason("kind not supported"),
+			KV("kind", kind),
+		)
+	}
+}
@@ -17,6 +17,7 @@ type WorkspaceCore struct {
 	Evaluator       *Evaluator
 	Setting         *WorkspaceSetting
 	ProfileSettings []*ProfileSetting
+	OciClient       *OciClient
 }
 
 func NewWorkspaceCore(environment *EnvironmentCore, dir string) (core *WorkspaceCore, err error) {
@@ -76,6 +77,7 @@ func NewWorkspaceCore(environment *EnvironmentCore, dir string) (core *Workspace
 		Evaluator:       evaluator,
 		Setting:         setting,
 		ProfileSettings: profileSettings,
+		OciClient:       NewOciClient(nil, NewDockerCredentialProvider("")),
 	}
 	return core, nil
 }
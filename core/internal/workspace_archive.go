@@ -0,0 +1,206 @@
+package internal
+
+import (
+	"github.com/orz-dsh/dsh/core/common"
+	. "github.com/orz-dsh/dsh/utils"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// archiveDownloadTimeout limits the time of downloading the remote archive, including reading the body.
+const archiveDownloadTimeout = 10 * time.Minute
+
+// archiveDownloadSizeLimit limits the size of the remote archive, the download fails if the archive is larger.
+const archiveDownloadSizeLimit int64 = 1 << 30
+
+var archiveHttpClient = &http.Client{Timeout: archiveDownloadTimeout}
+
+// GetArchiveProjectDir gets the content-addressed dir of the archive project, the sha256 of the local archive is computed if it is not in the link.
+func (w *WorkspaceCore) GetArchiveProjectDir(archive *common.ProjectLinkArchive) (string, error) {
+	checksum := archive.Sha256
+	if checksum == "" {
+		var err error
+		if checksum, err = HashFile(archive.Path); err != nil {
+			return "", ErrW(err, "get archive project dir error",
+				Code(ErrorCodeArchiveDownloadFailed),
+				Reason("hash archive error"),
+				KV("path", archive.Path),
+			)
+		}
+	}
+	return filepath.Join(w.Dir, "archive", checksum), nil
+}
+
+func (w *WorkspaceCore) IsArchiveProjectDir(path string) bool {
+	return strings.HasPrefix(path, filepath.Join(w.Dir, "archive")+string(filepath.Separator))
+}
+
+// DownloadArchiveProject downloads the remote archive and extracts it to the dir, the dir is not changed once it exists.
+// If the archive contains a single top-level dir, the content of the dir is extracted.
+func (w *WorkspaceCore) DownloadArchiveProject(path string, archive *common.ProjectLinkArchive) (err error) {
+	if IsDirExists(path) {
+		return nil
+	}
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return ErrW(err, "download archive project error",
+			Reason("make dir error"),
+			KV("path", path),
+		)
+	}
+	lock, err := w.LockDir(path, false)
+	if err != nil {
+		return ErrW(err, "download archive project error",
+			Reason("lock dir error"),
+			KV("path", path),
+		)
+	}
+	defer w.UnlockDir(lock)
+	if IsDirExists(path) {
+		return nil
+	}
+
+	logger := w.Logger.Named(LoggerNameArchive)
+	startTime := time.Now()
+	logger.InfoDesc("download archive project start",
+		KV("path", path),
+		KV("url", archive.Url),
+		KV("file", archive.Path),
+	)
+	suffix, err := RandomString(8)
+	if err != nil {
+		return ErrW(err, "download archive project error",
+			Reason("random suffix error"),
+		)
+	}
+	tempPath := path + ".tmp-" + suffix
+	defer os.RemoveAll(tempPath)
+	if err = os.MkdirAll(tempPath, os.ModePerm); err != nil {
+		return ErrW(err, "download archive project error",
+			Reason("make temp dir error"),
+			KV("path", tempPath),
+		)
+	}
+
+	file := archive.Path
+	if archive.Url != "" {
+		file = filepath.Join(tempPath, "archive."+string(archive.Format))
+		if err = downloadArchiveFile(archiveHttpClient, archive.Url, file, archiveDownloadSizeLimit); err != nil {
+			return ErrW(err, "download archive project error",
+				Code(ErrorCodeArchiveDownloadFailed),
+				Reason("download archive file error"),
+				KV("url", archive.Url),
+			)
+		}
+	}
+	if archive.Sha256 != "" {
+		checksum, err := HashFile(file)
+		if err != nil {
+			return ErrW(err, "download archive project error",
+				Code(ErrorCodeArchiveDownloadFailed),
+				Reason("hash archive error"),
+				KV("file", file),
+			)
+		}
+		if checksum != archive.Sha256 {
+			return ErrN("download archive project error",
+				Code(ErrorCodeArchiveDownloadFailed),
+				Reason("sha256 mismatch"),
+				KV("url", archive.Url),
+				KV("file", archive.Path),
+				KV("expected", archive.Sha256),
+				KV("actual", checksum),
+			)
+		}
+	}
+
+	if err = extractProjectArchive(file, archive.Format, tempPath, path); err != nil {
+		return ErrW(err, "download archive project error",
+			Code(ErrorCodeArchiveDownloadFailed),
+			Reason("extract project error"),
+			KV("file", file),
+		)
+	}
+	logger.InfoDesc("download archive project finish",
+		KV("elapsed", time.Since(startTime)),
+	)
+	return nil
+}
+
+// extractProjectArchive extracts the archive file in the temp dir and renames the content to the dir,
+// if the archive contains a single top-level dir, the content of the dir is renamed.
+func extractProjectArchive(file string, format ArchiveFormat, tempPath string, path string) error {
+	extractPath := filepath.Join(tempPath, "content")
+	if err := ExtractArchive(file, format, extractPath); err != nil {
+		return ErrW(err, "extract project archive error",
+			Reason("extract archive error"),
+			KV("file", file),
+		)
+	}
+	contentPath := extractPath
+	if entries, err := os.ReadDir(extractPath); err == nil && len(entries) == 1 && entries[0].IsDir() {
+		contentPath = filepath.Join(extractPath, entries[0].Name())
+	}
+	if err := os.Rename(contentPath, path); err != nil {
+		return ErrW(err, "extract project archive error",
+			Reason("rename dir error"),
+			KV("source", contentPath),
+			KV("target", path),
+		)
+	}
+	return nil
+}
+
+// downloadArchiveFile downloads the archive to the file, the download fails if the archive is larger than the size limit.
+func downloadArchiveFile(client *http.Client, url, file string, sizeLimit int64) error {
+	response, err := client.Get(url)
+	if err != nil {
+		return ErrW(err, "download archive file error",
+			Reason("http get error"),
+			KV("url", url),
+		)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return ErrN("download archive file error",
+			Reason("http status error"),
+			KV("url", url),
+			KV("status", response.Status),
+		)
+	}
+	if response.ContentLength > sizeLimit {
+		return ErrN("download archive file error",
+			Reason("archive too large"),
+			KV("url", url),
+			KV("size", response.ContentLength),
+			KV("sizeLimit", sizeLimit),
+		)
+	}
+	writer, err := os.Create(file)
+	if err != nil {
+		return ErrW(err, "download archive file error",
+			Reason("create file error"),
+			KV("file", file),
+		)
+	}
+	defer writer.Close()
+	// one more byte is read to check whether the archive exceeds the size limit
+	size, err := io.Copy(writer, io.LimitReader(response.Body, sizeLimit+1))
+	if err != nil {
+		return ErrW(err, "download archive file error",
+			Reason("io copy error"),
+			KV("url", url),
+		)
+	}
+	if size > sizeLimit {
+		return ErrN("download archive file error",
+			Reason("archive too large"),
+			KV("url", url),
+			KV("sizeLimit", sizeLimit),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDownloadArchiveFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/lib.tar.gz":
+			_, _ = w.Write([]byte("archive"))
+		case "/stream.tar.gz":
+			// the content length is unknown, so the size is checked while reading
+			w.Header().Set("Transfer-Encoding", "chunked")
+			w.(http.Flusher).Flush()
+			_, _ = w.Write([]byte(strings.Repeat("x", 32)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "archive.tar.gz")
+
+	if err := downloadArchiveFile(server.Client(), server.URL+"/lib.tar.gz", file, 16); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(file); err != nil || string(data) != "archive" {
+		t.Fatal("archive file", string(data), err)
+	}
+	if err := downloadArchiveFile(server.Client(), server.URL+"/lib.tar.gz", file, 4); err == nil {
+		t.Fatal("archive larger than the content length limit should fail")
+	}
+	if err := downloadArchiveFile(server.Client(), server.URL+"/stream.tar.gz", file, 16); err == nil {
+		t.Fatal("archive larger than the read limit should fail")
+	}
+	if err := downloadArchiveFile(server.Client(), server.URL+"/missing.tar.gz", file, 16); err == nil {
+		t.Fatal("http status error should fail")
+	}
+	if archiveHttpClient.Timeout <= 0 {
+		t.Fatal("archive http client should have a timeout")
+	}
+}
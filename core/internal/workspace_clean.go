@@ -0,0 +1,395 @@
+package internal
+
+import (
+	. "github.com/orz-dsh/dsh/core/common"
+	. "github.com/orz-dsh/dsh/utils"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// region base
+
+const workspaceGitProjectUsedFileName = "dsh-used"
+
+// endregion
+
+// region WorkspaceCore
+
+func (w *WorkspaceCore) Clean(options WorkspaceCleanOptions) (*WorkspaceCleanReport, error) {
+	report := NewWorkspaceCleanReport(options.DryRun)
+	var errorPaths []string
+
+	outputs, paths := w.listCleanOutputs()
+	errorPaths = append(errorPaths, paths...)
+	w.planCleanOutputs(report, outputs, options.ExcludeOutputDir)
+
+	projects, paths := w.listCleanProjects()
+	errorPaths = append(errorPaths, paths...)
+	archives, paths := w.listCleanDownloads(WorkspaceCleanItemKindArchive, "archive")
+	errorPaths = append(errorPaths, paths...)
+	ocis, paths := w.listCleanDownloads(WorkspaceCleanItemKindOci, "oci")
+	errorPaths = append(errorPaths, paths...)
+	w.planCleanProjects(report, append(projects, append(archives, ocis...)...))
+
+	if options.DryRun {
+		for i := 0; i < len(report.Items); i++ {
+			w.Logger.DebugDesc("cleanup workspace dir (dry run)",
+				KV("item", report.Items[i]),
+			)
+		}
+	} else {
+		errorPaths = append(errorPaths, w.executeClean(report)...)
+		w.cleanLockFiles()
+	}
+	if len(errorPaths) > 0 {
+		return report, ErrN("cleanup workspace error",
+			Reason("clean dirs error"),
+			KV("workspaceDir", w.Dir),
+			KV("errorPaths", errorPaths),
+		)
+	}
+	return report, nil
+}
+
+func (w *WorkspaceCore) executeClean(report *WorkspaceCleanReport) (errorPaths []string) {
+	var items []*WorkspaceCleanReportItem
+	for i := 0; i < len(report.Items); i++ {
+		item := report.Items[i]
+		lock, err := w.TryLockDir(item.Path)
+		if err != nil {
+			w.Logger.WarnDesc("cleanup workspace dir error",
+				Reason("lock dir error"),
+				KV("item", item),
+			)
+			errorPaths = append(errorPaths, item.Path)
+			items = append(items, item)
+			continue
+		}
+		if lock == nil {
+			// locked by another process after planning
+			item.Reason = WorkspaceCleanItemReasonLocked
+			report.Skipped = append(report.Skipped, item)
+			continue
+		}
+		if err = os.RemoveAll(item.Path); err != nil {
+			w.Logger.WarnDesc("cleanup workspace dir error",
+				Reason("remove dir error"),
+				KV("item", item),
+			)
+			errorPaths = append(errorPaths, item.Path)
+		} else {
+			item.Removed = true
+			w.Logger.DebugDesc("cleanup workspace dir",
+				KV("item", item),
+			)
+		}
+		w.UnlockDir(lock)
+		items = append(items, item)
+	}
+	report.Items = items
+	return errorPaths
+}
+
+func (w *WorkspaceCore) TouchGitProject(path string) error {
+	file := filepath.Join(path, ".git", workspaceGitProjectUsedFileName)
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		return ErrW(err, "touch git project error",
+			Reason("write used file error"),
+			KV("file", file),
+		)
+	}
+	now := time.Now()
+	if err := os.Chtimes(file, now, now); err != nil {
+		return ErrW(err, "touch git project error",
+			Reason("change used time error"),
+			KV("file", file),
+		)
+	}
+	return nil
+}
+
+// IsDownloadedProjectDir checks whether the dir is downloaded to the workspace, such as the git, archive and oci projects, which can be cleaned.
+func (w *WorkspaceCore) IsDownloadedProjectDir(path string) bool {
+	return w.IsGitProjectDir(path) || w.IsArchiveProjectDir(path) || w.IsOciProjectDir(path)
+}
+
+// TouchDownloadedProject touches the used time of the downloaded project, the used time of the content-addressed dir
+// is the modified time of the dir, so no file is written to the project.
+func (w *WorkspaceCore) TouchDownloadedProject(path string) error {
+	if w.IsGitProjectDir(path) {
+		return w.TouchGitProject(path)
+	}
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		return ErrW(err, "touch downloaded project error",
+			Reason("change used time error"),
+			KV("path", path),
+		)
+	}
+	return nil
+}
+
+func (w *WorkspaceCore) getGitProjectUsedTime(path string) (time.Time, error) {
+	info, err := os.Stat(filepath.Join(path, ".git", workspaceGitProjectUsedFileName))
+	if os.IsNotExist(err) {
+		info, err = os.Stat(path)
+	}
+	if err != nil {
+		return time.Time{}, ErrW(err, "get git project used time error",
+			Reason("stat error"),
+			KV("path", path),
+		)
+	}
+	return info.ModTime(), nil
+}
+
+func (w *WorkspaceCore) listCleanOutputs() (outputs []*workspaceCleanEntry, errorPaths []string) {
+	outputPath := filepath.Join(w.Dir, "output")
+	if IsDirExists(outputPath) {
+		dirNames, err := ListChildDirs(outputPath)
+		if err != nil {
+			w.Logger.WarnDesc("cleanup workspace output dir error",
+				Reason("list child dirs error"),
+				KV("outputPath", outputPath),
+			)
+			errorPaths = append(errorPaths, outputPath)
+		}
+		for i := 0; i < len(dirNames); i++ {
+			dirPath := filepath.Join(outputPath, dirNames[i])
+			projectName, createTime, err := w.ParseOutputDirName(dirNames[i])
+			if err != nil {
+				w.Logger.WarnDesc("cleanup workspace output dir error",
+					Reason("parse dir name error"),
+					KV("dirPath", dirPath),
+				)
+				errorPaths = append(errorPaths, dirPath)
+				continue
+			}
+			outputs = append(outputs, newWorkspaceCleanEntry(WorkspaceCleanItemKindOutput, dirPath, projectName, createTime))
+		}
+	}
+
+	cachePath := filepath.Join(w.Dir, "cache")
+	if IsDirExists(cachePath) {
+		dirNames, err := ListChildDirs(cachePath)
+		if err != nil {
+			w.Logger.WarnDesc("cleanup workspace output cache dir error",
+				Reason("list child dirs error"),
+				KV("cachePath", cachePath),
+			)
+			errorPaths = append(errorPaths, cachePath)
+		}
+		for i := 0; i < len(dirNames); i++ {
+			dirPath := filepath.Join(cachePath, dirNames[i])
+			projectName, _, err := w.ParseOutputCacheDirName(dirNames[i])
+			if err != nil {
+				w.Logger.WarnDesc("cleanup workspace output cache dir error",
+					Reason("parse dir name error"),
+					KV("dirPath", dirPath),
+				)
+				errorPaths = append(errorPaths, dirPath)
+				continue
+			}
+			usedTime, err := w.getOutputCacheUsedTime(dirPath)
+			if err != nil {
+				w.Logger.WarnDesc("cleanup workspace output cache dir error",
+					Reason("get used time error"),
+					KV("dirPath", dirPath),
+				)
+				errorPaths = append(errorPaths, dirPath)
+				continue
+			}
+			outputs = append(outputs, newWorkspaceCleanEntry(WorkspaceCleanItemKindCache, dirPath, projectName, usedTime))
+		}
+	}
+
+	for i := 0; i < len(outputs); i++ {
+		output := outputs[i]
+		size, err := GetDirSize(output.path)
+		if err != nil {
+			w.Logger.WarnDesc("cleanup workspace output dir error",
+				Reason("get dir size error"),
+				KV("dirPath", output.path),
+			)
+		}
+		output.size = size
+	}
+	slices.SortStableFunc(outputs, func(l, r *workspaceCleanEntry) int {
+		return r.time.Compare(l.time)
+	})
+	return outputs, errorPaths
+}
+
+func (w *WorkspaceCore) planCleanOutputs(report *WorkspaceCleanReport, outputs []*workspaceCleanEntry, excludeOutputPath string) {
+	outputSetting := w.Setting.Clean.Output
+
+	now := time.Now()
+	var keeps []*workspaceCleanEntry
+	var keepSize int64
+	projectCounts := map[WorkspaceCleanItemKind]map[string]int{}
+	for i := 0; i < len(outputs); i++ {
+		output := outputs[i]
+		if projectCounts[output.kind] == nil {
+			projectCounts[output.kind] = map[string]int{}
+		}
+		projectCounts[output.kind][output.projectName]++
+		count, expires := outputSetting.GetPolicy(output.projectName)
+
+		var reason WorkspaceCleanItemReason
+		if output.path == excludeOutputPath {
+			reason = WorkspaceCleanItemReasonExcluded
+		} else if locked, _ := w.IsDirLocked(output.path); locked {
+			reason = WorkspaceCleanItemReasonLocked
+		} else if projectCounts[output.kind][output.projectName] > count {
+			reason = WorkspaceCleanItemReasonCount
+		} else if now.Sub(output.time) > expires {
+			reason = WorkspaceCleanItemReasonExpires
+		}
+
+		switch reason {
+		case "":
+			keeps = append(keeps, output)
+			keepSize += output.size
+		case WorkspaceCleanItemReasonExcluded, WorkspaceCleanItemReasonLocked:
+			report.Skipped = append(report.Skipped, output.newReportItem(reason))
+			keepSize += output.size
+		default:
+			report.Items = append(report.Items, output.newReportItem(reason))
+		}
+	}
+
+	sizeLimit := *outputSetting.Size
+	if sizeLimit <= 0 {
+		return
+	}
+	for i := len(keeps) - 1; i >= 0 && keepSize > sizeLimit; i-- {
+		output := keeps[i]
+		report.Items = append(report.Items, output.newReportItem(WorkspaceCleanItemReasonSize))
+		keepSize -= output.size
+	}
+}
+
+func (w *WorkspaceCore) listCleanProjects() (projects []*workspaceCleanEntry, errorPaths []string) {
+	projectPath := filepath.Join(w.Dir, "project")
+	if !IsDirExists(projectPath) {
+		return nil, nil
+	}
+	hostNames, err := ListChildDirs(projectPath)
+	if err != nil {
+		w.Logger.WarnDesc("cleanup workspace project dir error",
+			Reason("list child dirs error"),
+			KV("projectPath", projectPath),
+		)
+		return nil, []string{projectPath}
+	}
+	for i := 0; i < len(hostNames); i++ {
+		hostPath := filepath.Join(projectPath, hostNames[i])
+		dirNames, err := ListChildDirs(hostPath)
+		if err != nil {
+			w.Logger.WarnDesc("cleanup workspace project dir error",
+				Reason("list child dirs error"),
+				KV("hostPath", hostPath),
+			)
+			errorPaths = append(errorPaths, hostPath)
+			continue
+		}
+		for j := 0; j < len(dirNames); j++ {
+			dirPath := filepath.Join(hostPath, dirNames[j])
+			usedTime, err := w.getGitProjectUsedTime(dirPath)
+			if err != nil {
+				w.Logger.WarnDesc("cleanup workspace project dir error",
+					Reason("get used time error"),
+					KV("dirPath", dirPath),
+				)
+				errorPaths = append(errorPaths, dirPath)
+				continue
+			}
+			projects = append(projects, newWorkspaceCleanEntry(WorkspaceCleanItemKindProject, dirPath, "", usedTime))
+		}
+	}
+	return projects, errorPaths
+}
+
+// listCleanDownloads lists the content-addressed dirs of the downloaded projects in the workspace dir with the name, such as the archive and oci projects.
+func (w *WorkspaceCore) listCleanDownloads(kind WorkspaceCleanItemKind, name string) (projects []*workspaceCleanEntry, errorPaths []string) {
+	downloadPath := filepath.Join(w.Dir, name)
+	if !IsDirExists(downloadPath) {
+		return nil, nil
+	}
+	dirNames, err := ListChildDirs(downloadPath)
+	if err != nil {
+		w.Logger.WarnDesc("cleanup workspace download dir error",
+			Reason("list child dirs error"),
+			KV("downloadPath", downloadPath),
+		)
+		return nil, []string{downloadPath}
+	}
+	for i := 0; i < len(dirNames); i++ {
+		dirPath := filepath.Join(downloadPath, dirNames[i])
+		info, err := os.Stat(dirPath)
+		if err != nil {
+			w.Logger.WarnDesc("cleanup workspace download dir error",
+				Reason("get used time error"),
+				KV("dirPath", dirPath),
+			)
+			errorPaths = append(errorPaths, dirPath)
+			continue
+		}
+		projects = append(projects, newWorkspaceCleanEntry(kind, dirPath, "", info.ModTime()))
+	}
+	return projects, errorPaths
+}
+
+func (w *WorkspaceCore) planCleanProjects(report *WorkspaceCleanReport, projects []*workspaceCleanEntry) {
+	expires := *w.Setting.Clean.Project.Expires
+
+	now := time.Now()
+	for i := 0; i < len(projects); i++ {
+		project := projects[i]
+		if now.Sub(project.time) <= expires {
+			continue
+		}
+		if locked, _ := w.IsDirLocked(project.path); locked {
+			report.Skipped = append(report.Skipped, project.newReportItem(WorkspaceCleanItemReasonLocked))
+			continue
+		}
+		size, err := GetDirSize(project.path)
+		if err != nil {
+			w.Logger.WarnDesc("cleanup workspace project dir error",
+				Reason("get dir size error"),
+				KV("dirPath", project.path),
+			)
+		}
+		project.size = size
+		report.Items = append(report.Items, project.newReportItem(WorkspaceCleanItemReasonUnused))
+	}
+}
+
+// endregion
+
+// region workspaceCleanEntry
+
+type workspaceCleanEntry struct {
+	kind        WorkspaceCleanItemKind
+	path        string
+	projectName string
+	time        time.Time
+	size        int64
+}
+
+func newWorkspaceCleanEntry(kind WorkspaceCleanItemKind, path, projectName string, time time.Time) *workspaceCleanEntry {
+	return &workspaceCleanEntry{
+		kind:        kind,
+		path:        path,
+		projectName: projectName,
+		time:        time,
+	}
+}
+
+func (e *workspaceCleanEntry) newReportItem(reason WorkspaceCleanItemReason) *WorkspaceCleanReportItem {
+	return NewWorkspaceCleanReportItem(e.kind, e.path, e.projectName, e.size, reason)
+}
+
+// endregion
@@ -3,6 +3,7 @@ package internal
 import (
 	"errors"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/orz-dsh/dsh/core/common"
 	. "github.com/orz-dsh/dsh/utils"
 	"net/url"
@@ -19,7 +20,12 @@ func (w *WorkspaceCore) GetGitProjectDir(parsedUrl *url.URL, parsedRef *common.P
 	return filepath.Join(w.Dir, "project", path1, path2)
 }
 
+func (w *WorkspaceCore) IsGitProjectDir(path string) bool {
+	return strings.HasPrefix(path, filepath.Join(w.Dir, "project")+string(filepath.Separator))
+}
+
 func (w *WorkspaceCore) DownloadGitProject(path string, rawUrl string, parsedUrl *url.URL, rawRef string, parsedRef *common.ProjectLinkGitRef) (err error) {
+	logger := w.Logger.Named(LoggerNameGit)
 	if err = os.MkdirAll(path, os.ModePerm); err != nil {
 		return ErrW(err, "download git project error",
 			Reason("make dir error"),
@@ -28,10 +34,20 @@ func (w *WorkspaceCore) DownloadGitProject(path string, rawUrl string, parsedUrl
 			KV("path", path),
 		)
 	}
+	lock, err := w.LockDir(path, false)
+	if err != nil {
+		return ErrW(err, "download git project error",
+			Reason("lock dir error"),
+			KV("url", rawUrl),
+			KV("ref", rawRef),
+			KV("path", path),
+		)
+	}
+	defer w.UnlockDir(lock)
 	repo, err := git.PlainOpen(path)
 	if errors.Is(err, git.ErrRepositoryNotExists) {
 		startTime := time.Now()
-		w.Logger.InfoDesc("download git project start",
+		logger.InfoDesc("download git project start",
 			KV("action", "clone project"),
 			KV("path", path),
 			KV("url", rawUrl),
@@ -43,8 +59,15 @@ func (w *WorkspaceCore) DownloadGitProject(path string, rawUrl string, parsedUrl
 			SingleBranch:  true,
 			Depth:         1,
 		}
-		if w.Logger.IsDebugEnabled() {
-			cloneOptions.Progress = w.Logger.GetDebugWriter()
+		if parsedRef.Type == common.ProjectLinkGitRefTypeCommit {
+			// the commit may be on any branch, so the repository is cloned fully and checked out later
+			cloneOptions = &git.CloneOptions{
+				URL:        rawUrl,
+				NoCheckout: true,
+			}
+		}
+		if logger.IsDebugEnabled() && logger.GetFormat() == LogFormatDesc {
+			cloneOptions.Progress = logger.GetDebugWriter()
 		}
 		repo, err = git.PlainClone(path, false, cloneOptions)
 		if err != nil {
@@ -55,7 +78,17 @@ func (w *WorkspaceCore) DownloadGitProject(path string, rawUrl string, parsedUrl
 				KV("path", path),
 			)
 		}
-		w.Logger.InfoDesc("download git project finish",
+		if parsedRef.Type == common.ProjectLinkGitRefTypeCommit {
+			if err = resetGitProjectCommit(repo, parsedRef); err != nil {
+				return ErrW(err, "download git project error",
+					Reason("checkout commit error"),
+					KV("url", rawUrl),
+					KV("ref", rawRef),
+					KV("path", path),
+				)
+			}
+		}
+		logger.InfoDesc("download git project finish",
 			KV("action", "clone project"),
 			KV("elapsed", time.Since(startTime)),
 		)
@@ -66,9 +99,19 @@ func (w *WorkspaceCore) DownloadGitProject(path string, rawUrl string, parsedUrl
 			KV("ref", rawRef),
 			KV("path", path),
 		)
+	} else if parsedRef.Type == common.ProjectLinkGitRefTypeCommit {
+		// the commit never moves, so the project is only reset to the commit instead of pulling
+		if err = resetGitProjectCommit(repo, parsedRef); err != nil {
+			return ErrW(err, "download git project error",
+				Reason("reset commit error"),
+				KV("url", rawUrl),
+				KV("ref", rawRef),
+				KV("path", path),
+			)
+		}
 	} else {
 		startTime := time.Now()
-		w.Logger.InfoDesc("download git project start",
+		logger.InfoDesc("download git project start",
 			KV("action", "pull project"),
 			KV("path", path),
 			KV("url", rawUrl),
@@ -110,8 +153,8 @@ func (w *WorkspaceCore) DownloadGitProject(path string, rawUrl string, parsedUrl
 			SingleBranch:  true,
 			Depth:         1,
 		}
-		if w.Logger.IsDebugEnabled() {
-			pullOptions.Progress = w.Logger.GetDebugWriter()
+		if logger.IsDebugEnabled() && logger.GetFormat() == LogFormatDesc {
+			pullOptions.Progress = logger.GetDebugWriter()
 		}
 		err = worktree.Pull(pullOptions)
 		if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
@@ -122,10 +165,50 @@ func (w *WorkspaceCore) DownloadGitProject(path string, rawUrl string, parsedUrl
 				KV("path", path),
 			)
 		}
-		w.Logger.InfoDesc("download git project finish",
+		logger.InfoDesc("download git project finish",
 			KV("action", "pull project"),
 			KV("elapsed", time.Since(startTime)),
 		)
 	}
+	if err = w.TouchGitProject(path); err != nil {
+		return ErrW(err, "download git project error",
+			Reason("touch project error"),
+			KV("url", rawUrl),
+			KV("ref", rawRef),
+			KV("path", path),
+		)
+	}
 	return nil
 }
+
+// resetGitProjectCommit resets the worktree to the commit of the ref hardly, and removes the untracked files.
+func resetGitProjectCommit(repo *git.Repository, parsedRef *common.ProjectLinkGitRef) error {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	if err = worktree.Reset(&git.ResetOptions{Commit: plumbing.NewHash(parsedRef.Name), Mode: git.HardReset}); err != nil {
+		return err
+	}
+	return worktree.Clean(&git.CleanOptions{Dir: true})
+}
+
+func (w *WorkspaceCore) GetGitProjectCommit(path string) (string, error) {
+	repo, err := git.PlainOpen(path)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		return "", nil
+	} else if err != nil {
+		return "", ErrW(err, "get git project commit error",
+			Reason("open repository error"),
+			KV("path", path),
+		)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", ErrW(err, "get git project commit error",
+			Reason("get head error"),
+			KV("path", path),
+		)
+	}
+	return head.Hash().String(), nil
+}
@@ -0,0 +1,126 @@
+package internal
+
+import (
+	. "github.com/orz-dsh/dsh/utils"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// region WorkspaceCore
+
+func (w *WorkspaceCore) getLockFile(path string) string {
+	name := NewHasher().WriteString("path", path).Sum()[:16]
+	return filepath.Join(w.Dir, "lock", name+".lock")
+}
+
+func (w *WorkspaceCore) LockDir(path string, shared bool) (*FileLock, error) {
+	lock := NewFileLock(w.getLockFile(path))
+	timeout := *w.Setting.Lock.Timeout
+	err := lock.Lock(shared, timeout, func() {
+		w.Logger.InfoDesc("waiting for lock",
+			KV("path", path),
+			KV("shared", shared),
+			KV("timeout", timeout),
+		)
+	})
+	if err != nil {
+		return nil, ErrW(err, "lock workspace dir error",
+			Reason("wait lock error"),
+			KV("path", path),
+			KV("shared", shared),
+		)
+	}
+	return lock, nil
+}
+
+func (w *WorkspaceCore) LockDirs(paths []string, shared bool) (locks []*FileLock, err error) {
+	// lock in a stable order to avoid deadlocks between processes
+	sortedPaths := slices.Clone(paths)
+	slices.Sort(sortedPaths)
+	sortedPaths = slices.Compact(sortedPaths)
+	for i := 0; i < len(sortedPaths); i++ {
+		lock, err := w.LockDir(sortedPaths[i], shared)
+		if err != nil {
+			w.UnlockDirs(locks)
+			return nil, err
+		}
+		locks = append(locks, lock)
+	}
+	return locks, nil
+}
+
+func (w *WorkspaceCore) TryLockDir(path string) (*FileLock, error) {
+	lock := NewFileLock(w.getLockFile(path))
+	locked, err := lock.TryLock(false)
+	if err != nil {
+		return nil, ErrW(err, "try lock workspace dir error",
+			Reason("try lock error"),
+			KV("path", path),
+		)
+	}
+	if !locked {
+		return nil, nil
+	}
+	return lock, nil
+}
+
+func (w *WorkspaceCore) IsDirLocked(path string) (bool, error) {
+	lock, err := w.TryLockDir(path)
+	if err != nil {
+		return false, err
+	}
+	if lock == nil {
+		return true, nil
+	}
+	w.UnlockDir(lock)
+	return false, nil
+}
+
+func (w *WorkspaceCore) UnlockDir(lock *FileLock) {
+	if err := lock.Unlock(); err != nil {
+		w.Logger.WarnDesc("unlock workspace dir error",
+			Reason("unlock error"),
+			KV("file", lock.File),
+		)
+	}
+}
+
+func (w *WorkspaceCore) UnlockDirs(locks []*FileLock) {
+	for i := len(locks) - 1; i >= 0; i-- {
+		w.UnlockDir(locks[i])
+	}
+}
+
+// cleanLockFiles removes the lock files which are not locked, the lock files are created by path hash and never removed on unlock,
+// so they are removed on cleanup to keep the lock dir bounded.
+func (w *WorkspaceCore) cleanLockFiles() {
+	lockPath := filepath.Join(w.Dir, "lock")
+	entries, err := os.ReadDir(lockPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			w.Logger.WarnDesc("cleanup workspace lock files error",
+				Reason("read dir error"),
+				KV("lockPath", lockPath),
+			)
+		}
+		return
+	}
+	for i := 0; i < len(entries); i++ {
+		if entries[i].IsDir() || !strings.HasSuffix(entries[i].Name(), ".lock") {
+			continue
+		}
+		file := filepath.Join(lockPath, entries[i].Name())
+		if removed, err := NewFileLock(file).TryRemove(); err != nil {
+			w.Logger.WarnDesc("cleanup workspace lock file error",
+				Reason("remove file error"),
+				KV("file", file),
+			)
+		} else if removed {
+			w.Logger.DebugDesc("cleanup workspace lock file", KV("file", file))
+		}
+	}
+}
+
+// endregion
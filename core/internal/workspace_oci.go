@@ -0,0 +1,257 @@
+package internal
+
+import (
+	"bytes"
+	"github.com/orz-dsh/dsh/core/common"
+	. "github.com/orz-dsh/dsh/core/internal/setting"
+	. "github.com/orz-dsh/dsh/utils"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	ociProjectArtifactType   = "application/vnd.dsh.project.v1"
+	ociProjectLayerMediaType = "application/vnd.dsh.project.content.v1.tar+gzip"
+)
+
+// ociProjectExcludes are the names skipped when packing the project dir, and the output dir of the artifacts by convention,
+// the workspace dir is also skipped if it is in the project dir, see getOciProjectExcludes.
+var ociProjectExcludes = []string{".git", "/output"}
+
+// ResolveOciProject resolves the tag of the oci link to the digest of the manifest, the link is returned as is if it is pinned.
+func (w *WorkspaceCore) ResolveOciProject(oci *common.ProjectLinkOci) (*common.ProjectLinkOci, error) {
+	if oci.ParsedRef.Digest != "" {
+		return oci, nil
+	}
+	digest, err := w.OciClient.ResolveManifest(oci.ParsedRef)
+	if err != nil {
+		return nil, ErrW(err, "resolve oci project error",
+			Code(ErrorCodeOciDownloadFailed),
+			Reason("resolve manifest error"),
+			KV("ref", oci.Ref),
+		)
+	}
+	return common.NewProjectLinkOci(oci.ParsedRef.WithDigest(digest)), nil
+}
+
+// getOciProjectExcludes gets the excludes of packing the project dir, the workspace dir in the project dir is excluded,
+// so the downloaded projects and the outputs are not pushed.
+func (w *WorkspaceCore) getOciProjectExcludes(dir string) []string {
+	excludes := slices.Clone(ociProjectExcludes)
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return excludes
+	}
+	absWorkspaceDir, err := filepath.Abs(w.Dir)
+	if err != nil {
+		return excludes
+	}
+	if relPath, err := filepath.Rel(absDir, absWorkspaceDir); err == nil && relPath != "." && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		excludes = append(excludes, "/"+filepath.ToSlash(relPath))
+	}
+	return excludes
+}
+
+func (w *WorkspaceCore) IsOciProjectDir(path string) bool {
+	return strings.HasPrefix(path, filepath.Join(w.Dir, "oci")+string(filepath.Separator))
+}
+
+// GetOciProjectDir gets the content-addressed dir of the oci project by the digest of the manifest.
+func (w *WorkspaceCore) GetOciProjectDir(digest string) string {
+	return filepath.Join(w.Dir, "oci", strings.Replace(digest, ":", "-", 1))
+}
+
+// DownloadOciProject pulls the pinned oci project and extracts it to the dir, the dir is not changed once it exists.
+func (w *WorkspaceCore) DownloadOciProject(path string, oci *common.ProjectLinkOci) (err error) {
+	if IsDirExists(path) {
+		return nil
+	}
+	if oci.ParsedRef.Digest == "" {
+		return ErrN("download oci project error",
+			Reason("ref is not pinned"),
+			KV("ref", oci.Ref),
+		)
+	}
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return ErrW(err, "download oci project error",
+			Reason("make dir error"),
+			KV("path", path),
+		)
+	}
+	lock, err := w.LockDir(path, false)
+	if err != nil {
+		return ErrW(err, "download oci project error",
+			Reason("lock dir error"),
+			KV("path", path),
+		)
+	}
+	defer w.UnlockDir(lock)
+	if IsDirExists(path) {
+		return nil
+	}
+
+	logger := w.Logger.Named(LoggerNameOci)
+	startTime := time.Now()
+	logger.InfoDesc("download oci project start",
+		KV("path", path),
+		KV("ref", oci.Ref),
+	)
+	manifest, _, err := w.OciClient.PullManifest(oci.ParsedRef)
+	if err != nil {
+		return ErrW(err, "download oci project error",
+			Code(ErrorCodeOciDownloadFailed),
+			Reason("pull manifest error"),
+			KV("ref", oci.Ref),
+		)
+	}
+	var layer *OciDescriptor
+	for i := 0; i < len(manifest.Layers); i++ {
+		if manifest.Layers[i].MediaType == ociProjectLayerMediaType {
+			layer = manifest.Layers[i]
+			break
+		}
+	}
+	if manifest.ArtifactType != ociProjectArtifactType || layer == nil {
+		return ErrN("download oci project error",
+			Code(ErrorCodeOciDownloadFailed),
+			Reason("artifact is not a project"),
+			KV("ref", oci.Ref),
+			KV("artifactType", manifest.ArtifactType),
+		)
+	}
+
+	suffix, err := RandomString(8)
+	if err != nil {
+		return ErrW(err, "download oci project error",
+			Reason("random suffix error"),
+		)
+	}
+	tempPath := path + ".tmp-" + suffix
+	defer os.RemoveAll(tempPath)
+	if err = os.MkdirAll(tempPath, os.ModePerm); err != nil {
+		return ErrW(err, "download oci project error",
+			Reason("make temp dir error"),
+			KV("path", tempPath),
+		)
+	}
+	file := filepath.Join(tempPath, "project."+string(ArchiveFormatTarGz))
+	if err = w.pullOciProjectLayer(oci, layer, file); err != nil {
+		return ErrW(err, "download oci project error",
+			Code(ErrorCodeOciDownloadFailed),
+			Reason("pull layer error"),
+			KV("ref", oci.Ref),
+		)
+	}
+	if err = extractProjectArchive(file, ArchiveFormatTarGz, tempPath, path); err != nil {
+		return ErrW(err, "download oci project error",
+			Code(ErrorCodeOciDownloadFailed),
+			Reason("extract project error"),
+			KV("ref", oci.Ref),
+		)
+	}
+	logger.InfoDesc("download oci project finish",
+		KV("elapsed", time.Since(startTime)),
+	)
+	return nil
+}
+
+func (w *WorkspaceCore) pullOciProjectLayer(oci *common.ProjectLinkOci, layer *OciDescriptor, file string) error {
+	writer, err := os.Create(file)
+	if err != nil {
+		return ErrW(err, "pull oci project layer error",
+			Reason("create file error"),
+			KV("file", file),
+		)
+	}
+	defer writer.Close()
+	return w.OciClient.PullBlob(oci.ParsedRef, layer, writer)
+}
+
+// PullOciProject resolves and downloads the oci project, and gets the pinned link and the dir.
+func (w *WorkspaceCore) PullOciProject(rawRef string) (*common.ProjectLinkOci, string, error) {
+	parsedRef, err := ParseOciReference(rawRef)
+	if err != nil {
+		return nil, "", ErrW(err, "pull oci project error",
+			Reason("parse ref error"),
+			KV("ref", rawRef),
+		)
+	}
+	oci, err := w.ResolveOciProject(common.NewProjectLinkOci(parsedRef))
+	if err != nil {
+		return nil, "", err
+	}
+	path := w.GetOciProjectDir(oci.ParsedRef.Digest)
+	if err = w.DownloadOciProject(path, oci); err != nil {
+		return nil, "", err
+	}
+	return oci, path, nil
+}
+
+// PushOciProject packs the project dir as a tar.gz layer and pushes it with the manifest, and gets the pinned link.
+// The archive is reproducible, so pushing the same files again gets the same digest.
+func (w *WorkspaceCore) PushOciProject(dir string, rawRef string) (*common.ProjectLinkOci, error) {
+	parsedRef, err := ParseOciReference(rawRef)
+	if err != nil {
+		return nil, ErrW(err, "push oci project error",
+			Reason("parse ref error"),
+			KV("ref", rawRef),
+		)
+	}
+	setting, err := LoadProjectSetting(w.Logger, OSFileSystem, dir)
+	if err != nil {
+		return nil, ErrW(err, "push oci project error",
+			Reason("load project setting error"),
+			KV("dir", dir),
+		)
+	}
+
+	logger := w.Logger.Named(LoggerNameOci)
+	startTime := time.Now()
+	logger.InfoDesc("push oci project start",
+		KV("dir", setting.Dir),
+		KV("ref", parsedRef),
+	)
+	buffer := &bytes.Buffer{}
+	if err = CreateArchive(setting.Dir, ArchiveFormatTarGz, w.getOciProjectExcludes(setting.Dir), buffer); err != nil {
+		return nil, ErrW(err, "push oci project error",
+			Code(ErrorCodeOciPushFailed),
+			Reason("pack project error"),
+			KV("dir", setting.Dir),
+		)
+	}
+	layerData := buffer.Bytes()
+	layer := NewOciDescriptor(ociProjectLayerMediaType, layerData)
+	layer.Annotations = map[string]string{OciAnnotationTitle: setting.Name + "." + string(ArchiveFormatTarGz)}
+	config := NewOciDescriptor(OciMediaTypeEmpty, OciEmptyData)
+	for _, blob := range []struct {
+		descriptor *OciDescriptor
+		data       []byte
+	}{{config, OciEmptyData}, {layer, layerData}} {
+		if err = w.OciClient.PushBlob(parsedRef, blob.descriptor, blob.data); err != nil {
+			return nil, ErrW(err, "push oci project error",
+				Code(ErrorCodeOciPushFailed),
+				Reason("push blob error"),
+				KV("ref", parsedRef),
+			)
+		}
+	}
+	manifest := NewOciManifest(ociProjectArtifactType, config, []*OciDescriptor{layer}, map[string]string{
+		OciAnnotationTitle: setting.Name,
+	})
+	digest, err := w.OciClient.PushManifest(parsedRef, manifest)
+	if err != nil {
+		return nil, ErrW(err, "push oci project error",
+			Code(ErrorCodeOciPushFailed),
+			Reason("push manifest error"),
+			KV("ref", parsedRef),
+		)
+	}
+	logger.InfoDesc("push oci project finish",
+		KV("digest", digest),
+		KV("elapsed", time.Since(startTime)),
+	)
+	return common.NewProjectLinkOci(parsedRef.WithDigest(digest)), nil
+}
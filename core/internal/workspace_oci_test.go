@@ -0,0 +1,25 @@
+package internal
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestOciProjectExcludes(t *testing.T) {
+	dir := t.TempDir()
+	workspace := &WorkspaceCore{Dir: filepath.Join(dir, ".dsh", "workspace")}
+	excludes := workspace.getOciProjectExcludes(dir)
+	if !slices.Equal(excludes, []string{".git", "/output", "/.dsh/workspace"}) {
+		t.Fatal("workspace dir in the project dir should be excluded", excludes)
+	}
+
+	workspace = &WorkspaceCore{Dir: t.TempDir()}
+	excludes = workspace.getOciProjectExcludes(dir)
+	if !slices.Equal(excludes, []string{".git", "/output"}) {
+		t.Fatal("workspace dir out of the project dir should not be excluded", excludes)
+	}
+	if len(ociProjectExcludes) != 2 {
+		t.Fatal("default excludes should not be changed", ociProjectExcludes)
+	}
+}
@@ -2,18 +2,18 @@ package internal
 
 import (
 	"fmt"
-	. "github.com/orz-dsh/dsh/core/common"
 	. "github.com/orz-dsh/dsh/utils"
 	"os"
 	"path/filepath"
-	"slices"
 	"strings"
 	"time"
 )
 
-func (w *WorkspaceCore) Clean(options WorkspaceCleanOptions) error {
-	return w.CleanOutputDir(options.ExcludeOutputDir)
-}
+// region base
+
+const workspaceOutputCacheFileName = "@cache.json"
+
+// endregion
 
 func (w *WorkspaceCore) BuildOutputDirName(projectName string) (dirName string, err error) {
 	random, err := RandomString(8)
@@ -81,81 +81,93 @@ func (w *WorkspaceCore) MakeOutputDir(projectName string) (string, error) {
 	)
 }
 
-func (w *WorkspaceCore) CleanOutputDir(excludeOutputPath string) error {
-	outputPath := filepath.Join(w.Dir, "output")
-	if !IsDirExists(outputPath) {
-		return nil
-	}
-	dirNames, err := ListChildDirs(outputPath)
-	if err != nil {
-		w.Logger.Warn("cleanup workspace output dir error",
-			Reason("list child dirs error"),
-			KV("outputPath", outputPath),
-		)
-		return ErrW(err, "cleanup workspace dir error",
-			Reason("list child dirs error"),
-			KV("outputPath", outputPath),
+func (w *WorkspaceCore) GetOutputCacheDir(projectName string, hash string) string {
+	return filepath.Join(w.Dir, "cache", projectName+"-"+hash)
+}
+
+func (w *WorkspaceCore) ParseOutputCacheDirName(dirName string) (projectName string, hash string, err error) {
+	index := strings.LastIndex(dirName, "-")
+	if index <= 0 || index == len(dirName)-1 {
+		return "", "", ErrN("parse output cache dir name error",
+			Reason("invalid format"),
+			KV("dirName", dirName),
 		)
 	}
-	slices.Sort(dirNames)
-	slices.Reverse(dirNames)
-
-	var errorDirNames []string
-	var removeDirNames []string
-	var projectCounts = map[string]int{}
-
-	cleanOutputCount := *w.Setting.Clean.Output.Count
-	cleanOutputExpires := *w.Setting.Clean.Output.Expires
+	return dirName[:index], dirName[index+1:], nil
+}
 
-	now := time.Now()
-	for i := 0; i < len(dirNames); i++ {
-		dirName := dirNames[i]
-		projectName, createTime, err := w.ParseOutputDirName(dirName)
-		if err != nil {
-			w.Logger.Warn("cleanup workspace output dir error",
-				Reason("parse dir name error"),
-				KV("dirName", dirName),
-			)
-			errorDirNames = append(errorDirNames, dirName)
-			continue
-		}
-		projectCount, exist := projectCounts[projectName]
-		if exist {
-			projectCount = projectCount + 1
-		} else {
-			projectCount = 1
-		}
-		if projectCount > cleanOutputCount {
-			removeDirNames = append(removeDirNames, dirName)
-		} else if now.Sub(createTime) > cleanOutputExpires {
-			removeDirNames = append(removeDirNames, dirName)
-		}
-		projectCounts[projectName] = projectCount
+// LoadOutputCache loads the cache file in the output cache dir, nil is returned if the cache file or any target file
+// does not exist, so that the output is remade. The cache file is rewritten to record the used time for cleaning.
+func (w *WorkspaceCore) LoadOutputCache(fsys FileSystem, dir string) (*WorkspaceOutputCache, error) {
+	file := filepath.Join(dir, workspaceOutputCacheFileName)
+	if !IsFileExistsFS(fsys, file) {
+		return nil, nil
 	}
-	for i := 0; i < len(removeDirNames); i++ {
-		dirName := removeDirNames[i]
-		dirPath := filepath.Join(outputPath, dirName)
-		if dirPath == excludeOutputPath {
-			continue
-		}
-		if err = os.RemoveAll(dirPath); err != nil {
-			w.Logger.Warn("cleanup workspace output dir error",
-				Reason("remove dir error"),
-				KV("dirPath", dirPath),
-			)
-			errorDirNames = append(errorDirNames, dirName)
-		} else {
-			w.Logger.DebugDesc("cleanup workspace output dir",
-				KV("dirPath", dirPath),
+	cache := &WorkspaceOutputCache{}
+	if err := ReadJsonFileFS(fsys, file, cache); err != nil {
+		return nil, ErrW(err, "load output cache error",
+			Reason("read cache file error"),
+			KV("file", file),
+		)
+	}
+	for i := 0; i < len(cache.TargetNames); i++ {
+		targetFile := filepath.Join(dir, filepath.FromSlash(cache.TargetNames[i]))
+		if !IsFileExistsFS(fsys, targetFile) {
+			w.Logger.WarnDesc("output cache target file not found",
+				KV("dir", dir),
+				KV("target", cache.TargetNames[i]),
 			)
+			return nil, nil
 		}
 	}
-	if len(errorDirNames) > 0 {
-		return ErrN("cleanup workspace error",
-			Reason("remove dirs error"),
-			KV("outputPath", outputPath),
-			KV("errorDirNames", errorDirNames),
+	if err := w.SaveOutputCache(fsys, dir, cache); err != nil {
+		return nil, ErrW(err, "load output cache error",
+			Reason("touch cache file error"),
+			KV("file", file),
+		)
+	}
+	return cache, nil
+}
+
+func (w *WorkspaceCore) SaveOutputCache(fsys FileSystem, dir string, cache *WorkspaceOutputCache) error {
+	file := filepath.Join(dir, workspaceOutputCacheFileName)
+	if err := SerializeFileFS(fsys, JsonSerializerDefault, file, cache); err != nil {
+		return ErrW(err, "save output cache error",
+			Reason("write cache file error"),
+			KV("file", file),
 		)
 	}
 	return nil
 }
+
+func (w *WorkspaceCore) getOutputCacheUsedTime(dir string) (time.Time, error) {
+	info, err := os.Stat(filepath.Join(dir, workspaceOutputCacheFileName))
+	if os.IsNotExist(err) {
+		info, err = os.Stat(dir)
+	}
+	if err != nil {
+		return time.Time{}, ErrW(err, "get output cache used time error",
+			Reason("stat error"),
+			KV("dir", dir),
+		)
+	}
+	return info.ModTime(), nil
+}
+
+// region WorkspaceOutputCache
+
+type WorkspaceOutputCache struct {
+	ProjectName string   `yaml:"projectName" toml:"projectName" json:"projectName"`
+	Hash        string   `yaml:"hash" toml:"hash" json:"hash"`
+	TargetNames []string `yaml:"targetNames,omitempty" toml:"targetNames,omitempty" json:"targetNames,omitempty"`
+}
+
+func NewWorkspaceOutputCache(projectName, hash string, targetNames []string) *WorkspaceOutputCache {
+	return &WorkspaceOutputCache{
+		ProjectName: projectName,
+		Hash:        hash,
+		TargetNames: targetNames,
+	}
+}
+
+// endregion
@@ -0,0 +1,12 @@
+package core
+
+import (
+	. "github.com/orz-dsh/dsh/core/common"
+	. "github.com/orz-dsh/dsh/core/internal"
+	. "github.com/orz-dsh/dsh/utils"
+)
+
+// GetSettingSchema returns the JSON Schema of the setting file kind, it can be used by editors to validate and autocomplete the files.
+func GetSettingSchema(kind SettingSchemaKind) (*JsonSchema, error) {
+	return MakeSettingSchema(kind)
+}
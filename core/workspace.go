@@ -30,9 +30,34 @@ func (w *Workspace) GetDir() string {
 }
 
 func (w *Workspace) Clean(options WorkspaceCleanOptions) error {
+	_, err := w.core.Clean(options)
+	return err
+}
+
+// CleanWithReport cleans the workspace like Clean, and gets the report of the removed dirs and the freed size,
+// nothing is removed if the options is a dry run.
+func (w *Workspace) CleanWithReport(options WorkspaceCleanOptions) (*WorkspaceCleanReport, error) {
 	return w.core.Clean(options)
 }
 
 func (w *Workspace) NewAppBuilder() *ApplicationBuilder {
 	return newAppBuilder(w.core)
 }
+
+// PushOciProject packs the project dir and pushes it to the OCI registry by the ref such as `ghcr.io/org/repo:tag`,
+// and gets the link pinned to the digest such as `oci://ghcr.io/org/repo:tag@sha256:<hex>`.
+// The credentials are read from the docker config file.
+func (w *Workspace) PushOciProject(dir string, ref string) (string, error) {
+	oci, err := w.core.PushOciProject(dir, ref)
+	if err != nil {
+		return "", err
+	}
+	return "oci://" + oci.Ref, nil
+}
+
+// PullOciProject pulls the project from the OCI registry by the ref into the workspace, and gets the dir of the project.
+// The dir is cached by the digest of the manifest, so the pinned ref is pulled only once.
+func (w *Workspace) PullOciProject(ref string) (string, error) {
+	_, dir, err := w.core.PullOciProject(ref)
+	return dir, err
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	. "github.com/orz-dsh/dsh/core/common"
+	. "github.com/orz-dsh/dsh/utils"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestWorkspace(t *testing.T) *Workspace {
+	environment, err := NewEnvironment(NewLogger(LogLevelError), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspace, err := NewWorkspace(environment, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return workspace
+}
+
+func newTestGitProject(t *testing.T, workspace *Workspace, name string, usedTime time.Time) string {
+	dir := filepath.Join(workspace.GetDir(), "project", "example.com", name)
+	if err := os.MkdirAll(filepath.Join(dir, ".git"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "project.yml"), []byte("name: "+name+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(dir, usedTime, usedTime); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func getTestCleanItem(report *WorkspaceCleanReport, path string) *WorkspaceCleanReportItem {
+	for i := 0; i < len(report.Items); i++ {
+		if report.Items[i].Path == path {
+			return report.Items[i]
+		}
+	}
+	return nil
+}
+
+func TestWorkspaceCleanWithReport(t *testing.T) {
+	workspace := newTestWorkspace(t)
+	dir := newTestGitProject(t, workspace, "project1", time.Now().Add(-60*24*time.Hour))
+
+	report, err := workspace.CleanWithReport(WorkspaceCleanOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item := getTestCleanItem(report, dir); item == nil || item.Removed {
+		t.Fatal("expired project should be planned but not removed", report)
+	}
+	if !IsDirExists(dir) {
+		t.Fatal("project dir should not be removed by dry run")
+	}
+
+	if err = workspace.Clean(WorkspaceCleanOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if IsDirExists(dir) {
+		t.Fatal("expired project dir should be removed")
+	}
+}
+
+func TestWorkspaceCleanLoadedProject(t *testing.T) {
+	workspace := newTestWorkspace(t)
+	dir := newTestGitProject(t, workspace, "project1", time.Now().Add(-60*24*time.Hour))
+
+	app, err := workspace.NewAppBuilder().Build("dir:" + dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = app.Inspect(); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := workspace.CleanWithReport(WorkspaceCleanOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item := getTestCleanItem(report, dir); item != nil {
+		t.Fatal("loaded project should not be planned", item)
+	}
+}
+
+func TestWorkspaceCleanDownloadedProjects(t *testing.T) {
+	workspace := newTestWorkspace(t)
+	archiveDir := filepath.Join(workspace.GetDir(), "archive", "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08")
+	ociDir := filepath.Join(workspace.GetDir(), "oci", "sha256-9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08")
+	recentDir := filepath.Join(workspace.GetDir(), "oci", "sha256-0000000000000000000000000000000000000000000000000000000000000000")
+	for _, dir := range []string{archiveDir, ociDir, recentDir} {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	usedTime := time.Now().Add(-60 * 24 * time.Hour)
+	for _, dir := range []string{archiveDir, ociDir} {
+		if err := os.Chtimes(dir, usedTime, usedTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := workspace.CleanWithReport(WorkspaceCleanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item := getTestCleanItem(report, archiveDir); item == nil || item.Kind != WorkspaceCleanItemKindArchive || !item.Removed {
+		t.Fatal("expired archive project should be removed", report)
+	}
+	if item := getTestCleanItem(report, ociDir); item == nil || item.Kind != WorkspaceCleanItemKindOci || !item.Removed {
+		t.Fatal("expired oci project should be removed", report)
+	}
+	if item := getTestCleanItem(report, recentDir); item != nil || !IsDirExists(recentDir) {
+		t.Fatal("recently used oci project should be kept", report)
+	}
+}
+
+func TestWorkspaceRedirectMissingArchive(t *testing.T) {
+	workspace := newTestWorkspace(t)
+	libDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(libDir, "project.yml"), []byte("name: lib\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	appDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(appDir, "project.yml"), []byte("name: app\ndependency:\n  items:\n    - link: archive:./missing.tar.gz\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// the missing local archive is not hashed before redirecting
+	app, err := workspace.NewAppBuilder().
+		AddProfileSetting("test", 0).
+		SetRedirectSetting().
+		AddItem("^archive:.*missing\\.tar\\.gz$", "dir:"+libDir, "").
+		CommitRedirectSetting().
+		CommitProfileSetting().
+		Build("dir:" + appDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inspection, err := app.Inspect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inspection.DependencyProjects) != 1 || inspection.DependencyProjects[0].Name != "lib" {
+		t.Fatal("dependency should be redirected", inspection.DependencyProjects)
+	}
+}
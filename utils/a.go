@@ -22,6 +22,10 @@ func Reason(reason any) DescKeyValue {
 	return KV("reason", reason)
 }
 
+func Code(code ErrorCode) DescKeyValue {
+	return KV("code", code)
+}
+
 func ValT[T any](expr bool, trueValue T, falseValue T) T {
 	return Ternary(expr, trueValue, falseValue)
 }
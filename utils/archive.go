@@ -0,0 +1,328 @@
+package utils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// region ArchiveFormat
+
+type ArchiveFormat string
+
+const (
+	ArchiveFormatTarGz ArchiveFormat = "tar.gz"
+	ArchiveFormatZip   ArchiveFormat = "zip"
+)
+
+// GetArchiveFormat gets the archive format by the file ext, such as `.tar.gz`, `.tgz` and `.zip`, it is empty if not supported.
+func GetArchiveFormat(file string) ArchiveFormat {
+	name := strings.ToLower(file)
+	if strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz") {
+		return ArchiveFormatTarGz
+	} else if strings.HasSuffix(name, ".zip") {
+		return ArchiveFormatZip
+	}
+	return ""
+}
+
+// endregion
+
+// region extract
+
+// ExtractArchive extracts the regular files and dirs in the archive file to the dir, the other entries such as symlinks are skipped,
+// and the entries out of the dir are rejected.
+func ExtractArchive(file string, format ArchiveFormat, dir string) error {
+	var err error
+	switch format {
+	case ArchiveFormatTarGz:
+		err = extractTarGzArchive(file, dir)
+	case ArchiveFormatZip:
+		err = extractZipArchive(file, dir)
+	default:
+		err = ErrN("extract archive error",
+			Reason("format not supported"),
+			KV("format", format),
+		)
+	}
+	if err != nil {
+		return ErrW(err, "extract archive error",
+			KV("file", file),
+			KV("dir", dir),
+		)
+	}
+	return nil
+}
+
+func extractTarGzArchive(file, dir string) error {
+	reader, err := os.Open(file)
+	if err != nil {
+		return ErrW(err, "extract tar.gz archive error",
+			Reason("open file error"),
+		)
+	}
+	defer reader.Close()
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return ErrW(err, "extract tar.gz archive error",
+			Reason("open gzip reader error"),
+		)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return ErrW(err, "extract tar.gz archive error",
+				Reason("read entry error"),
+			)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = extractArchiveEntry(dir, header.Name, true, 0, nil)
+		case tar.TypeReg:
+			err = extractArchiveEntry(dir, header.Name, false, header.FileInfo().Mode(), tarReader)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func extractZipArchive(file, dir string) error {
+	reader, err := zip.OpenReader(file)
+	if err != nil {
+		return ErrW(err, "extract zip archive error",
+			Reason("open file error"),
+		)
+	}
+	defer reader.Close()
+
+	for i := 0; i < len(reader.File); i++ {
+		entry := reader.File[i]
+		mode := entry.Mode()
+		if mode.IsDir() {
+			err = extractArchiveEntry(dir, entry.Name, true, 0, nil)
+		} else if mode.IsRegular() {
+			var entryReader io.ReadCloser
+			if entryReader, err = entry.Open(); err != nil {
+				return ErrW(err, "extract zip archive error",
+					Reason("open entry error"),
+					KV("entry", entry.Name),
+				)
+			}
+			err = extractArchiveEntry(dir, entry.Name, false, mode, entryReader)
+			_ = entryReader.Close()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractArchiveEntry(dir, name string, isDir bool, mode os.FileMode, reader io.Reader) error {
+	relPath := filepath.FromSlash(strings.TrimPrefix(name, "./"))
+	if !filepath.IsLocal(relPath) {
+		return ErrN("extract archive entry error",
+			Reason("entry out of dir"),
+			KV("entry", name),
+		)
+	}
+	path := filepath.Join(dir, relPath)
+	if isDir {
+		if err := os.MkdirAll(path, os.ModePerm); err != nil {
+			return ErrW(err, "extract archive entry error",
+				Reason("make dir error"),
+				KV("entry", name),
+			)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return ErrW(err, "extract archive entry error",
+			Reason("make parent dir error"),
+			KV("entry", name),
+		)
+	}
+	writer, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0600)
+	if err != nil {
+		return ErrW(err, "extract archive entry error",
+			Reason("create file error"),
+			KV("entry", name),
+		)
+	}
+	defer writer.Close()
+	if _, err = io.Copy(writer, reader); err != nil {
+		return ErrW(err, "extract archive entry error",
+			Reason("io copy error"),
+			KV("entry", name),
+		)
+	}
+	return nil
+}
+
+// endregion
+
+// region create
+
+// archiveModTime is the mod time of all entries, so that the archives of the same files are identical.
+var archiveModTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// CreateArchive creates the archive of the regular files and dirs in the dir, the entries are sorted and their mod times are fixed,
+// so that the archives of the same files are identical, and the entries whose names are in the excludes are skipped with their children,
+// the excludes starting with `/` match the slash-separated paths relative to the dir, such as `/output`.
+func CreateArchive(dir string, format ArchiveFormat, excludes []string, writer io.Writer) error {
+	var err error
+	switch format {
+	case ArchiveFormatTarGz:
+		err = createTarGzArchive(dir, excludes, writer)
+	case ArchiveFormatZip:
+		err = createZipArchive(dir, excludes, writer)
+	default:
+		err = ErrN("create archive error",
+			Reason("format not supported"),
+			KV("format", format),
+		)
+	}
+	if err != nil {
+		return ErrW(err, "create archive error",
+			KV("dir", dir),
+		)
+	}
+	return nil
+}
+
+func createTarGzArchive(dir string, excludes []string, writer io.Writer) error {
+	gzipWriter := gzip.NewWriter(writer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	err := walkArchiveEntries(dir, excludes, func(name string, info fs.FileInfo, file string) error {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    int64(info.Mode().Perm()),
+			ModTime: archiveModTime,
+			Format:  tar.FormatPAX,
+		}
+		if info.IsDir() {
+			header.Name += "/"
+			header.Typeflag = tar.TypeDir
+			return tarWriter.WriteHeader(header)
+		}
+		header.Typeflag = tar.TypeReg
+		header.Size = info.Size()
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		return copyArchiveFile(file, tarWriter)
+	})
+	if err != nil {
+		return err
+	}
+	if err = tarWriter.Close(); err != nil {
+		return ErrW(err, "create tar.gz archive error",
+			Reason("close tar writer error"),
+		)
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return ErrW(err, "create tar.gz archive error",
+			Reason("close gzip writer error"),
+		)
+	}
+	return nil
+}
+
+func createZipArchive(dir string, excludes []string, writer io.Writer) error {
+	zipWriter := zip.NewWriter(writer)
+	err := walkArchiveEntries(dir, excludes, func(name string, info fs.FileInfo, file string) error {
+		header := &zip.FileHeader{
+			Name:     name,
+			Modified: archiveModTime,
+			Method:   zip.Deflate,
+		}
+		if info.IsDir() {
+			header.Name += "/"
+			header.Method = zip.Store
+		}
+		header.SetMode(info.Mode())
+		entryWriter, err := zipWriter.CreateHeader(header)
+		if err != nil || info.IsDir() {
+			return err
+		}
+		return copyArchiveFile(file, entryWriter)
+	})
+	if err != nil {
+		return err
+	}
+	if err = zipWriter.Close(); err != nil {
+		return ErrW(err, "create zip archive error",
+			Reason("close zip writer error"),
+		)
+	}
+	return nil
+}
+
+func walkArchiveEntries(dir string, excludes []string, fn func(name string, info fs.FileInfo, file string) error) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return ErrW(err, "walk archive entries error",
+				Reason("walk dir error"),
+				KV("path", path),
+			)
+		}
+		if path == dir {
+			return nil
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return ErrW(err, "walk archive entries error",
+				Reason("get rel-path error"),
+				KV("path", path),
+			)
+		}
+		if slices.Contains(excludes, entry.Name()) || slices.Contains(excludes, "/"+filepath.ToSlash(relPath)) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.IsDir() && !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return ErrW(err, "walk archive entries error",
+				Reason("get file info error"),
+				KV("path", path),
+			)
+		}
+		if err = fn(filepath.ToSlash(relPath), info, path); err != nil {
+			return ErrW(err, "walk archive entries error",
+				Reason("write entry error"),
+				KV("path", path),
+			)
+		}
+		return nil
+	})
+}
+
+func copyArchiveFile(file string, writer io.Writer) error {
+	reader, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	_, err = io.Copy(writer, reader)
+	return err
+}
+
+// endregion
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractArchive(t *testing.T) {
+	dir := t.TempDir()
+	tarFile := filepath.Join(dir, "lib.tar.gz")
+	writeTestTarGzArchive(t, tarFile, map[string]string{
+		"lib/project.yml":     "name: lib\n",
+		"lib/script/main.sh":  "echo lib\n",
+		"lib/script/empty.sh": "",
+	})
+	if err := ExtractArchive(tarFile, GetArchiveFormat(tarFile), filepath.Join(dir, "tar")); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "tar", "lib", "script", "main.sh")); err != nil || string(data) != "echo lib\n" {
+		t.Fatal(string(data), err)
+	}
+
+	zipFile := filepath.Join(dir, "lib.zip")
+	writeTestZipArchive(t, zipFile, map[string]string{
+		"project.yml": "name: lib\n",
+	})
+	if err := ExtractArchive(zipFile, GetArchiveFormat(zipFile), filepath.Join(dir, "zip")); err != nil {
+		t.Fatal(err)
+	}
+	if !IsFileExists(filepath.Join(dir, "zip", "project.yml")) {
+		t.Fatal("zip entry not extracted")
+	}
+
+	evilFile := filepath.Join(dir, "evil.tgz")
+	writeTestTarGzArchive(t, evilFile, map[string]string{
+		"../evil.txt": "evil",
+	})
+	if err := ExtractArchive(evilFile, GetArchiveFormat(evilFile), filepath.Join(dir, "evil")); err != nil {
+		t.Log(err)
+	} else {
+		Impossible()
+	}
+	if IsFileExists(filepath.Join(dir, "evil.txt")) {
+		t.Fatal("entry extracted out of dir")
+	}
+	if GetArchiveFormat("lib.rar") != "" {
+		t.Fatal("rar format supported")
+	}
+}
+
+func TestCreateArchive(t *testing.T) {
+	dir := t.TempDir()
+	sourceDir := filepath.Join(dir, "source")
+	for file, content := range map[string]string{
+		"project.yml":     "name: lib\n",
+		"script/main.sh":  "echo lib\n",
+		".git/HEAD":       "ref: refs/heads/main\n",
+		"script/.git/obj": "skip",
+		"output/main.sh":  "skip",
+		"script/output/a": "keep",
+	} {
+		path := filepath.Join(sourceDir, file)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, format := range []ArchiveFormat{ArchiveFormatTarGz, ArchiveFormatZip} {
+		buffer1, buffer2 := &bytes.Buffer{}, &bytes.Buffer{}
+		if err := CreateArchive(sourceDir, format, []string{".git", "/output"}, buffer1); err != nil {
+			t.Fatal(err)
+		}
+		if err := CreateArchive(sourceDir, format, []string{".git", "/output"}, buffer2); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buffer1.Bytes(), buffer2.Bytes()) {
+			t.Fatal("archive not reproducible", format)
+		}
+		file := filepath.Join(dir, "lib."+string(format))
+		if err := os.WriteFile(file, buffer1.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		targetDir := filepath.Join(dir, "target-"+string(format))
+		if err := ExtractArchive(file, format, targetDir); err != nil {
+			t.Fatal(err)
+		}
+		if data, err := os.ReadFile(filepath.Join(targetDir, "script", "main.sh")); err != nil || string(data) != "echo lib\n" {
+			t.Fatal(string(data), err)
+		}
+		if IsDirExists(filepath.Join(targetDir, ".git")) || IsDirExists(filepath.Join(targetDir, "script", ".git")) || IsDirExists(filepath.Join(targetDir, "output")) {
+			t.Fatal("excluded entry archived", format)
+		}
+		if !IsFileExists(filepath.Join(targetDir, "script", "output", "a")) {
+			t.Fatal("anchored exclude should match the root entry only", format)
+		}
+	}
+}
+
+func writeTestTarGzArchive(t *testing.T, file string, entries map[string]string) {
+	writer, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+	gzipWriter := gzip.NewWriter(writer)
+	defer gzipWriter.Close()
+	tarWriter := tar.NewWriter(gzipWriter)
+	defer tarWriter.Close()
+	for name, content := range entries {
+		if err = tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func writeTestZipArchive(t *testing.T, file string, entries map[string]string) {
+	writer, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+	zipWriter := zip.NewWriter(writer)
+	defer zipWriter.Close()
+	for name, content := range entries {
+		entryWriter, err := zipWriter.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = entryWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
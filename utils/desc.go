@@ -9,17 +9,40 @@ import (
 // region Desc
 
 type Desc struct {
-	Title string
-	Body  DescBody
+	Title    string
+	Body     DescBody
+	Values   DescKeyValues
+	Code     ErrorCode
+	Source   ErrorSource
+	Field    ErrorField
+	Position ErrorPosition
 }
 
 type DescList []Desc
 
 func NewDesc(title string, kvs DescKeyValues) Desc {
-	return Desc{
-		Title: title,
-		Body:  NewDescBody(kvs),
+	desc := Desc{
+		Title:  title,
+		Body:   NewDescBody(kvs),
+		Values: kvs,
 	}
+	// the typed values are picked by the value types rather than the keys, so other values with the same keys don't
+	// shadow them.
+	for i := 0; i < len(kvs); i++ {
+		switch value := kvs[i].Value.(type) {
+		case ErrorCode:
+			if desc.Code == "" {
+				desc.Code = value
+			}
+		case ErrorSource:
+			desc.Source = value
+		case ErrorField:
+			desc.Field = value
+		case ErrorPosition:
+			desc.Position = value
+		}
+	}
+	return desc
 }
 
 func (d Desc) ToString(titleIdent string, bodyIdent string) string {
@@ -200,6 +223,8 @@ func NewDescKeyValue(key string, value any) DescKeyValue {
 		return NewDescKeyValue(key, value.(reflect.Value).String())
 	case reflect.Kind:
 		return NewDescKeyValue(key, value.(reflect.Kind).String())
+	case *Secret:
+		return NewDescKeyValue(key, SecretMask)
 	}
 	valueReflect := reflect.ValueOf(value)
 	valueIsNil := value == nil
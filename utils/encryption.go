@@ -0,0 +1,311 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// region base
+
+// encryptionHeader is the first line prefix of the encrypted data, followed by the key id,
+// the second line is the base64 encoded nonce and cipher text, and the first line is authenticated with the data.
+const encryptionHeader = "DSH-ENCRYPTED;v1;aes-256-gcm;"
+
+const EncryptedFileExt = ".enc"
+
+const encryptionKeySize = 32
+
+// endregion
+
+// region EncryptionKey
+
+type EncryptionKey struct {
+	Id   string
+	data []byte
+}
+
+func NewEncryptionKey(data []byte) (*EncryptionKey, error) {
+	if len(data) != encryptionKeySize {
+		return nil, ErrN("new encryption key error",
+			Reason("key size invalid"),
+			KV("size", len(data)),
+			KV("expectSize", encryptionKeySize),
+		)
+	}
+	hash := sha256.Sum256(data)
+	return &EncryptionKey{
+		Id:   hex.EncodeToString(hash[:8]),
+		data: bytes.Clone(data),
+	}, nil
+}
+
+func GenerateEncryptionKey() (*EncryptionKey, error) {
+	data := make([]byte, encryptionKeySize)
+	if _, err := rand.Read(data); err != nil {
+		return nil, ErrW(err, "generate encryption key error",
+			Reason("read random error"),
+		)
+	}
+	return NewEncryptionKey(data)
+}
+
+// ParseEncryptionKey parses the base64 encoded key.
+func ParseEncryptionKey(str string) (*EncryptionKey, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(str))
+	if err != nil {
+		return nil, ErrW(err, "parse encryption key error",
+			Reason("decode base64 error"),
+		)
+	}
+	return NewEncryptionKey(data)
+}
+
+// ReadEncryptionKeyFile reads the base64 encoded keys from the file, one key per line,
+// the empty lines and the lines starting with `#` are ignored.
+func ReadEncryptionKeyFile(file string) ([]*EncryptionKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, ErrW(err, "read encryption key file error",
+			Reason("read file error"),
+			KV("file", file),
+		)
+	}
+	var keys []*EncryptionKey
+	lines := strings.Split(string(data), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, err := ParseEncryptionKey(line)
+		if err != nil {
+			return nil, ErrW(err, "read encryption key file error",
+				Reason("parse key error"),
+				KV("file", file),
+				KV("line", i+1),
+			)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Encode returns the base64 encoded key, which can be parsed by ParseEncryptionKey.
+func (k *EncryptionKey) Encode() string {
+	return base64.StdEncoding.EncodeToString(k.data)
+}
+
+func (k *EncryptionKey) String() string {
+	return "key:" + k.Id
+}
+
+func (k *EncryptionKey) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+func (k *EncryptionKey) newCipher() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(k.data)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// endregion
+
+// region encryption
+
+func IsEncryptedData(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptionHeader))
+}
+
+func EncryptData(key *EncryptionKey, data []byte) ([]byte, error) {
+	aead, err := key.newCipher()
+	if err != nil {
+		return nil, ErrW(err, "encrypt data error",
+			Reason("new cipher error"),
+			KV("key", key),
+		)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, ErrW(err, "encrypt data error",
+			Reason("read random error"),
+		)
+	}
+	header := encryptionHeader + key.Id
+	sealed := aead.Seal(nonce, nonce, data, []byte(header))
+	return []byte(header + "\n" + base64.StdEncoding.EncodeToString(sealed) + "\n"), nil
+}
+
+// DecryptData decrypts the data with the key matched by the key id in the header,
+// so the data encrypted by an old key can still be decrypted while rotating keys.
+func DecryptData(keys []*EncryptionKey, data []byte) ([]byte, *EncryptionKey, error) {
+	header, body, _ := strings.Cut(string(data), "\n")
+	keyId, found := strings.CutPrefix(strings.TrimSpace(header), encryptionHeader)
+	if !found {
+		return nil, nil, ErrN("decrypt data error",
+			Reason("header invalid"),
+		)
+	}
+	var key *EncryptionKey
+	for i := 0; i < len(keys); i++ {
+		if keys[i].Id == keyId {
+			key = keys[i]
+			break
+		}
+	}
+	if key == nil {
+		return nil, nil, ErrN("decrypt data error",
+			Reason("key not found"),
+			KV("keyId", keyId),
+			KV("keys", keys),
+		)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(body))
+	if err != nil {
+		return nil, nil, ErrW(err, "decrypt data error",
+			Reason("decode base64 error"),
+		)
+	}
+	aead, err := key.newCipher()
+	if err != nil {
+		return nil, nil, ErrW(err, "decrypt data error",
+			Reason("new cipher error"),
+			KV("key", key),
+		)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, nil, ErrN("decrypt data error",
+			Reason("data too short"),
+		)
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(encryptionHeader+keyId))
+	if err != nil {
+		return nil, nil, ErrW(err, "decrypt data error",
+			Reason("authenticate error"),
+			KV("key", key),
+		)
+	}
+	return plain, key, nil
+}
+
+// EncryptFile encrypts the file to the target file, the target is the file with `.enc` ext if it is empty.
+func EncryptFile(key *EncryptionKey, file, target string) (string, error) {
+	if target == "" {
+		target = file + EncryptedFileExt
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", ErrW(err, "encrypt file error",
+			Reason("read file error"),
+			KV("file", file),
+		)
+	}
+	encrypted, err := EncryptData(key, data)
+	if err != nil {
+		return "", ErrW(err, "encrypt file error",
+			KV("file", file),
+		)
+	}
+	if err = writeEncryptionFile(target, encrypted); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// DecryptFile decrypts the file to the target file, the target is the file without `.enc` ext if it is empty.
+func DecryptFile(keys []*EncryptionKey, file, target string) (string, error) {
+	if target == "" {
+		if !strings.HasSuffix(file, EncryptedFileExt) {
+			return "", ErrN("decrypt file error",
+				Reason("target is required"),
+				KV("file", file),
+			)
+		}
+		target = strings.TrimSuffix(file, EncryptedFileExt)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", ErrW(err, "decrypt file error",
+			Reason("read file error"),
+			KV("file", file),
+		)
+	}
+	plain, _, err := DecryptData(keys, data)
+	if err != nil {
+		return "", ErrW(err, "decrypt file error",
+			KV("file", file),
+		)
+	}
+	if err = writeEncryptionFile(target, plain); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// RotateEncryptedFile decrypts the file by one of the keys, and encrypts it again by the new key in place.
+func RotateEncryptedFile(keys []*EncryptionKey, newKey *EncryptionKey, file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return ErrW(err, "rotate encrypted file error",
+			Reason("read file error"),
+			KV("file", file),
+		)
+	}
+	plain, _, err := DecryptData(keys, data)
+	if err != nil {
+		return ErrW(err, "rotate encrypted file error",
+			KV("file", file),
+		)
+	}
+	encrypted, err := EncryptData(newKey, plain)
+	if err != nil {
+		return ErrW(err, "rotate encrypted file error",
+			KV("file", file),
+		)
+	}
+	return writeEncryptionFile(file, encrypted)
+}
+
+// writeEncryptionFile writes the file by renaming a temp file, so the file is not broken if writing fails.
+func writeEncryptionFile(file string, data []byte) error {
+	temp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return ErrW(err, "write file error",
+			Reason("create temp file error"),
+			KV("file", file),
+		)
+	}
+	defer os.Remove(temp.Name())
+	if _, err = temp.Write(data); err != nil {
+		_ = temp.Close()
+		return ErrW(err, "write file error",
+			Reason("write temp file error"),
+			KV("file", file),
+		)
+	}
+	if err = temp.Close(); err != nil {
+		return ErrW(err, "write file error",
+			Reason("close temp file error"),
+			KV("file", file),
+		)
+	}
+	if err = os.Rename(temp.Name(), file); err != nil {
+		return ErrW(err, "write file error",
+			Reason("rename temp file error"),
+			KV("file", file),
+		)
+	}
+	return nil
+}
+
+// endregion
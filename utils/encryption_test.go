@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryption(t *testing.T) {
+	oldKey, err := GenerateEncryptionKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := GenerateEncryptionKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ParseEncryptionKey("YWJj"); err == nil {
+		t.Fatal("expected key size invalid error")
+	}
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "keys")
+	if err = os.WriteFile(keyFile, []byte("# keys\n"+oldKey.Encode()+"\n\n"+newKey.Encode()+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := ReadEncryptionKeyFile(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0].Id != oldKey.Id || keys[1].Id != newKey.Id {
+		t.Fatal(keys)
+	}
+
+	file := filepath.Join(dir, "app.dcfg.yml")
+	if err = os.WriteFile(file, []byte("value:\n  db:\n    pass: p@ss\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	encFile, err := EncryptFile(oldKey, file, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encFile != file+EncryptedFileExt || !IsConfigEncFile(encFile) {
+		t.Fatal(encFile)
+	}
+	data, _ := os.ReadFile(encFile)
+	if !IsEncryptedData(data) || strings.Contains(string(data), "p@ss") {
+		t.Fatal(string(data))
+	}
+
+	if err = RotateEncryptedFile(keys, newKey, encFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = DecryptData([]*EncryptionKey{oldKey}, data); err != nil {
+		t.Fatal(err)
+	}
+	rotated, _ := os.ReadFile(encFile)
+	if _, _, err = DecryptData([]*EncryptionKey{oldKey}, rotated); err == nil {
+		t.Fatal("expected key not found error")
+	}
+	tampered := []byte(strings.Replace(string(rotated), newKey.Id, oldKey.Id, 1))
+	if _, _, err = DecryptData(keys, tampered); err == nil {
+		t.Fatal("expected authenticate error")
+	}
+
+	model := map[string]any{}
+	metadata, err := DeserializeEncryptedFile(encFile, "", []*EncryptionKey{newKey}, &model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.Format != SerializationFormatYaml || model["value"].(map[string]any)["db"].(map[string]any)["pass"] != "p@ss" {
+		t.Fatal(model)
+	}
+
+	if err = os.Remove(file); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = DecryptFile(keys, encFile, ""); err != nil {
+		t.Fatal(err)
+	}
+	plain, _ := os.ReadFile(file)
+	if string(plain) != "value:\n  db:\n    pass: p@ss\n" {
+		t.Fatal(string(plain))
+	}
+}
+
+func TestDeserializeEncryptedFileRedacted(t *testing.T) {
+	key, err := GenerateEncryptionKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := EncryptData(key, []byte("db:\n  pass: p@ss\n  port: abc\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys := NewMemoryFileSystem()
+	file := filepath.Join(string(filepath.Separator), "app.dcfg.yml"+EncryptedFileExt)
+	if err = fsys.WriteFile(file, encrypted, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	model := map[string]any{}
+	metadata, err := DeserializeEncryptedFileFS(fsys, file, "", []*EncryptionKey{key}, &model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	position := metadata.Positions.Get("db.pass")
+	if position == nil || position.Line != 2 {
+		t.Fatal("position should be kept", position)
+	}
+	if snippet := metadata.Positions.GetSnippet(position, 1); snippet != nil {
+		t.Fatal("snippet should not be shown", snippet)
+	}
+
+	invalid := &struct {
+		Db struct {
+			Port int `yaml:"port"`
+		} `yaml:"db"`
+	}{}
+	if _, err = DeserializeEncryptedFileFS(fsys, file, "", []*EncryptionKey{key}, invalid); err == nil {
+		t.Fatal("unmarshal error expected")
+	} else if strings.Contains(err.Error(), "abc") {
+		t.Fatal("decrypted value should not be in the error", err)
+	}
+}
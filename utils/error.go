@@ -1,9 +1,11 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/pkg/errors"
 	"io"
+	"slices"
 	"strings"
 )
 
@@ -11,6 +13,15 @@ type stackTracer interface {
 	StackTrace() errors.StackTrace
 }
 
+// ErrorSource is the source file of the error, it is typed so that it is recorded by NewDesc.
+type ErrorSource string
+
+// ErrorField is the field in the source file of the error.
+type ErrorField string
+
+// ErrorPosition is the position of the field in the source file of the error.
+type ErrorPosition string
+
 type Error struct {
 	Details DescList
 	Stacks  errors.StackTrace
@@ -80,3 +91,165 @@ func WrapError(skipStacks int, err error, title string, kvs ...DescKeyValue) *Er
 		cause:   err,
 	}
 }
+
+func (e *Error) GetCodes() (codes []ErrorCode) {
+	for i := 0; i < len(e.Details); i++ {
+		if e.Details[i].Code != "" {
+			codes = append(codes, e.Details[i].Code)
+		}
+	}
+	return codes
+}
+
+func (e *Error) GetCode() ErrorCode {
+	if codes := e.GetCodes(); len(codes) > 0 {
+		return codes[0]
+	}
+	return ""
+}
+
+func (e *Error) GetSource() (source, field string) {
+	for i := 0; i < len(e.Details); i++ {
+		if e.Details[i].Source != "" {
+			return string(e.Details[i].Source), string(e.Details[i].Field)
+		}
+	}
+	return "", ""
+}
+
+func (e *Error) GetPosition() string {
+	for i := 0; i < len(e.Details); i++ {
+		if e.Details[i].Position != "" {
+			return string(e.Details[i].Position)
+		}
+	}
+	return ""
+}
+
+func (e *Error) Export() *ErrorExport {
+	source, field := e.GetSource()
+	var details []*ErrorExportDetail
+	for i := 0; i < len(e.Details); i++ {
+		details = append(details, NewErrorExportDetail(e.Details[i].Title, getErrorExportFields(e.Details[i].Values)))
+	}
+	var stacks []string
+	for i := 0; i < len(e.Stacks); i++ {
+		stacks = append(stacks, strings.ReplaceAll(fmt.Sprintf("%+s:%d", e.Stacks[i], e.Stacks[i]), "\n\t", " "))
+	}
+	cause := ""
+	if e.cause != nil {
+		cause = e.cause.Error()
+	}
+	message := ""
+	if len(e.Details) > 0 {
+		message = e.Details[len(e.Details)-1].Title
+	}
+	return &ErrorExport{
+		Code:     e.GetCode(),
+		Codes:    GetErrorCodes(e),
+		Message:  message,
+		Source:   source,
+		Field:    field,
+		Position: e.GetPosition(),
+		Details:  details,
+		Cause:    cause,
+		Stacks:   stacks,
+	}
+}
+
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Export())
+}
+
+// GetErrorCodes walks the error chain and returns all codes, the innermost first.
+func GetErrorCodes(err error) (codes []ErrorCode) {
+	walkErrorChain(err, func(err_ *Error) {
+		codes = append(codes, err_.GetCodes()...)
+	})
+	return codes
+}
+
+// getErrorExportFields gets the raw values of the key values, the values are converted to the plain data by json, so the
+// multiline and nested values are kept as they are.
+func getErrorExportFields(kvs DescKeyValues) map[string]any {
+	if len(kvs) == 0 {
+		return nil
+	}
+	fields := make(map[string]any, len(kvs))
+	for i := 0; i < len(kvs); i++ {
+		fields[kvs[i].Key] = getErrorExportValue(kvs[i].Value)
+	}
+	return fields
+}
+
+func getErrorExportValue(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case *Secret:
+		return SecretMask
+	case error:
+		return v.Error()
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%+v", value)
+	}
+	var result any
+	if err = json.Unmarshal(data, &result); err != nil {
+		return fmt.Sprintf("%+v", value)
+	}
+	return result
+}
+
+func HasErrorCode(err error, code ErrorCode) bool {
+	return slices.Contains(GetErrorCodes(err), code)
+}
+
+func walkErrorChain(err error, visit func(err_ *Error)) {
+	if err == nil {
+		return
+	}
+	if err_, ok := err.(*Error); ok {
+		walkErrorChain(err_.cause, visit)
+		visit(err_)
+		return
+	}
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		errs := e.Unwrap()
+		for i := 0; i < len(errs); i++ {
+			walkErrorChain(errs[i], visit)
+		}
+	case interface{ Unwrap() error }:
+		walkErrorChain(e.Unwrap(), visit)
+	}
+}
+
+// region ErrorExport
+
+type ErrorExport struct {
+	Code     ErrorCode            `yaml:"code,omitempty" toml:"code,omitempty" json:"code,omitempty"`
+	Codes    []ErrorCode          `yaml:"codes,omitempty" toml:"codes,omitempty" json:"codes,omitempty"`
+	Message  string               `yaml:"message" toml:"message" json:"message"`
+	Source   string               `yaml:"source,omitempty" toml:"source,omitempty" json:"source,omitempty"`
+	Field    string               `yaml:"field,omitempty" toml:"field,omitempty" json:"field,omitempty"`
+	Position string               `yaml:"position,omitempty" toml:"position,omitempty" json:"position,omitempty"`
+	Details  []*ErrorExportDetail `yaml:"details,omitempty" toml:"details,omitempty" json:"details,omitempty"`
+	Cause    string               `yaml:"cause,omitempty" toml:"cause,omitempty" json:"cause,omitempty"`
+	Stacks   []string             `yaml:"stacks,omitempty" toml:"stacks,omitempty" json:"stacks,omitempty"`
+}
+
+type ErrorExportDetail struct {
+	Title  string         `yaml:"title" toml:"title" json:"title"`
+	Fields map[string]any `yaml:"fields,omitempty" toml:"fields,omitempty" json:"fields,omitempty"`
+}
+
+func NewErrorExportDetail(title string, fields map[string]any) *ErrorExportDetail {
+	return &ErrorExportDetail{
+		Title:  title,
+		Fields: fields,
+	}
+}
+
+// endregion
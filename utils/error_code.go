@@ -0,0 +1,36 @@
+package utils
+
+// region ErrorCode
+
+type ErrorCode string
+
+const (
+	ErrorCodeSettingInvalid      ErrorCode = "DSH-SETTING-001"
+	ErrorCodeSettingValueEmpty   ErrorCode = "DSH-SETTING-002"
+	ErrorCodeSettingValueInvalid ErrorCode = "DSH-SETTING-003"
+
+	ErrorCodeLinkInvalid          ErrorCode = "DSH-LINK-001"
+	ErrorCodeLinkRegistryNotFound ErrorCode = "DSH-LINK-002"
+
+	ErrorCodeProjectNotFound      ErrorCode = "DSH-PROJECT-001"
+	ErrorCodeProjectSettingFailed ErrorCode = "DSH-PROJECT-002"
+
+	ErrorCodeOptionValueEmpty    ErrorCode = "DSH-OPTION-001"
+	ErrorCodeOptionValueInvalid  ErrorCode = "DSH-OPTION-002"
+	ErrorCodeOptionValueConflict ErrorCode = "DSH-OPTION-003"
+	ErrorCodeOptionCheckFailed   ErrorCode = "DSH-OPTION-004"
+	ErrorCodeOptionPromptFailed  ErrorCode = "DSH-OPTION-005"
+
+	ErrorCodeConfigInvalid ErrorCode = "DSH-CONFIG-001"
+
+	ErrorCodeGitDownloadFailed ErrorCode = "DSH-GIT-001"
+
+	ErrorCodeArchiveDownloadFailed ErrorCode = "DSH-ARCHIVE-001"
+
+	ErrorCodeOciDownloadFailed ErrorCode = "DSH-OCI-001"
+	ErrorCodeOciPushFailed     ErrorCode = "DSH-OCI-002"
+
+	ErrorCodeLockTimeout ErrorCode = "DSH-LOCK-001"
+)
+
+// endregion
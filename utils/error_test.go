@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	errors2 "github.com/pkg/errors"
 	"testing"
 )
@@ -106,3 +108,74 @@ func TestError4(t *testing.T) {
 		t.Logf("%+v", err)
 	}
 }
+
+func TestErrorCode(t *testing.T) {
+	helper := NewModelHelper(NewLogger(LogLevelNone), "test setting", "test.yml").Child("items").Item(1).Child("name")
+	err := ErrW(helper.NewValueInvalidError("bad"), "load test error", Code(ErrorCodeProjectSettingFailed))
+	err = fmt.Errorf("outer: %w", errors.Join(err, ErrN("other error", Code(ErrorCodeLockTimeout))))
+
+	codes := GetErrorCodes(err)
+	if len(codes) != 3 || codes[0] != ErrorCodeSettingValueInvalid || codes[1] != ErrorCodeProjectSettingFailed || codes[2] != ErrorCodeLockTimeout {
+		t.Fatalf("unexpected codes: %v", codes)
+	}
+	if !HasErrorCode(err, ErrorCodeLockTimeout) || HasErrorCode(err, ErrorCodeLinkInvalid) {
+		t.Fatalf("unexpected has code result: %v", codes)
+	}
+
+	var err_ *Error
+	if !errors.As(err, &err_) {
+		t.Fatal("expected *Error")
+	}
+	if source, field := err_.GetSource(); source != "test.yml" || field != "items[1].name" {
+		t.Fatalf("unexpected source: %s %s", source, field)
+	}
+	data, jsonErr := json.Marshal(err_)
+	if jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+	export := &ErrorExport{}
+	if jsonErr = json.Unmarshal(data, export); jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+	if export.Code != ErrorCodeSettingValueInvalid || export.Message != "load test error" || export.Field != "items[1].name" || export.Details[0].Fields["value"] != "bad" || len(export.Stacks) == 0 {
+		t.Fatalf("unexpected export: %s", data)
+	}
+}
+
+func TestErrorTypedValues(t *testing.T) {
+	err := ErrN("find value error",
+		KV("code", "not-a-code"),
+		KV("source", "unset"),
+	)
+	var err_ *Error
+	if !errors.As(err, &err_) {
+		t.Fatal("expected *Error")
+	}
+	if codes := err_.GetCodes(); len(codes) != 0 {
+		t.Fatalf("untyped code should not be picked: %v", codes)
+	}
+	if source, _ := err_.GetSource(); source != "" {
+		t.Fatalf("untyped source should not be picked: %s", source)
+	}
+
+	helper := NewModelHelper(NewLogger(LogLevelNone), "test setting", "test.yml").Child("name")
+	err = ErrW(helper.NewValueEmptyError(), "wrap error", KV("source", "unset"))
+	if !errors.As(err, &err_) {
+		t.Fatal("expected *Error")
+	}
+	if source, field := err_.GetSource(); source != "test.yml" || field != "name" {
+		t.Fatalf("unexpected source: %s %s", source, field)
+	}
+
+	err = ErrN("find value error",
+		KV("value", "line1\nline2"),
+		KV("items", map[string]any{"a": []any{1, "b"}}),
+	)
+	fields := err.(*Error).Export().Details[0].Fields
+	if fields["value"] != "line1\nline2" {
+		t.Fatalf("unexpected multiline value: %v", fields["value"])
+	}
+	if items, ok := fields["items"].(map[string]any); !ok || len(items["a"].([]any)) != 2 {
+		t.Fatalf("unexpected nested value: %v", fields["items"])
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"bytes"
 	"github.com/expr-lang/expr"
 	"maps"
 	"os"
@@ -38,45 +39,73 @@ func EvalExpr(str string, data map[string]any, cast CastType) (any, error) {
 }
 
 func EvalFileTemplate(inputPath string, libraryPaths []string, outputPath string, data map[string]any, funcs template.FuncMap) error {
+	return EvalFileTemplateFS(OSFileSystem, inputPath, libraryPaths, outputPath, data, funcs)
+}
+
+// EvalFileTemplateFS reads the template files from the file system like template.ParseFiles, and writes the result to the output file in the file system.
+func EvalFileTemplateFS(fsys FileSystem, inputPath string, libraryPaths []string, outputPath string, data map[string]any, funcs template.FuncMap) error {
 	tpl := template.New(filepath.Base(inputPath)).Option("missingkey=error")
 	if funcs != nil {
 		tpl = tpl.Funcs(funcs)
 	}
 	files := append([]string{inputPath}, libraryPaths...)
-	tpl, err := tpl.ParseFiles(files...)
-	if err != nil {
-		return ErrW(err, "eval file template error",
-			Reason("parse template error"),
+	for i := 0; i < len(files); i++ {
+		content, err := fsys.ReadFile(files[i])
+		if err != nil {
+			return ErrW(err, "eval file template error",
+				Reason("read template error"),
+				KV("inputPath", inputPath),
+				KV("libraryPaths", libraryPaths),
+				KV("file", files[i]),
+			)
+		}
+		fileTpl := tpl
+		if name := filepath.Base(files[i]); name != tpl.Name() {
+			fileTpl = tpl.New(name)
+		}
+		if _, err = fileTpl.Parse(string(content)); err != nil {
+			return ErrW(err, "eval file template error",
+				Reason("parse template error"),
+				KV("inputPath", inputPath),
+				KV("libraryPaths", libraryPaths),
+			)
+		}
+	}
+
+	// the secrets are only resolved for the template execution, the data in errors is still masked,
+	// and a secret failing to resolve only fails the template using it
+	resolver := &secretResolver{}
+	resolvedData, _ := resolver.resolve(data)
+
+	buffer := &bytes.Buffer{}
+	err := tpl.Execute(buffer, resolvedData)
+	if usedErr := resolver.getUsedError(); usedErr != nil {
+		return ErrW(usedErr, "eval file template error",
+			Reason("resolve secrets error"),
 			KV("inputPath", inputPath),
-			KV("libraryPaths", libraryPaths),
 		)
 	}
-
-	if err = os.MkdirAll(filepath.Dir(outputPath), os.ModePerm); err != nil {
+	if err != nil {
 		return ErrW(err, "eval file template error",
-			Reason("make target dir error"),
+			Reason("execute template error"),
+			KV("inputPath", inputPath),
+			KV("libraryPaths", libraryPaths),
 			KV("outputPath", outputPath),
+			KV("data", data),
+			KV("funcs", funcs),
 		)
 	}
 
-	targetFile, err := os.Create(outputPath)
-	if err != nil {
+	if err = fsys.MkdirAll(filepath.Dir(outputPath), os.ModePerm); err != nil {
 		return ErrW(err, "eval file template error",
-			Reason("create target file error"),
+			Reason("make target dir error"),
 			KV("outputPath", outputPath),
 		)
 	}
-	defer targetFile.Close()
-
-	err = tpl.Execute(targetFile, data)
-	if err != nil {
+	if err = fsys.WriteFile(outputPath, buffer.Bytes(), 0666); err != nil {
 		return ErrW(err, "eval file template error",
-			Reason("execute template error"),
-			KV("inputPath", inputPath),
-			KV("libraryPaths", libraryPaths),
+			Reason("write target file error"),
 			KV("outputPath", outputPath),
-			KV("data", data),
-			KV("funcs", funcs),
 		)
 	}
 	return nil
@@ -96,8 +125,16 @@ func EvalStringTemplate(str string, data map[string]any, funcs template.FuncMap)
 			KV("funcs", funcs),
 		)
 	}
+	resolver := &secretResolver{}
+	resolvedData, _ := resolver.resolve(data)
 	var writer strings.Builder
-	err = tpl.Execute(&writer, data)
+	err = tpl.Execute(&writer, resolvedData)
+	if usedErr := resolver.getUsedError(); usedErr != nil {
+		return "", ErrW(usedErr, "eval string template error",
+			Reason("resolve secrets error"),
+			KV("str", str),
+		)
+	}
 	if err != nil {
 		return "", ErrW(err, "eval string template error",
 			Reason("execute template error"),
@@ -304,6 +341,10 @@ func (e *Evaluator) EvalFileTemplate(inputPath string, libraryPaths []string, ou
 	return EvalFileTemplate(inputPath, libraryPaths, outputPath, e.GetMap(false), e.funcs.ToTemplateFuncMap())
 }
 
+func (e *Evaluator) EvalFileTemplateFS(fsys FileSystem, inputPath string, libraryPaths []string, outputPath string) error {
+	return EvalFileTemplateFS(fsys, inputPath, libraryPaths, outputPath, e.GetMap(false), e.funcs.ToTemplateFuncMap())
+}
+
 func (e *Evaluator) EvalStringTemplate(str string) (string, error) {
 	return EvalStringTemplate(str, e.GetMap(false), e.funcs.ToTemplateFuncMap())
 }
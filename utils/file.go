@@ -5,8 +5,10 @@ import (
 	"github.com/pelletier/go-toml/v2"
 	"gopkg.in/yaml.v3"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 )
 
@@ -16,6 +18,7 @@ const (
 	FileTypeConfigYaml  FileType = "config-yaml"
 	FileTypeConfigToml  FileType = "config-toml"
 	FileTypeConfigJson  FileType = "config-json"
+	FileTypeConfigEnc   FileType = "config-enc"
 	FileTypeTemplate    FileType = "template"
 	FileTypeTemplateLib FileType = "template-lib"
 	FileTypeYaml        FileType = "yaml"
@@ -25,7 +28,11 @@ const (
 )
 
 func IsFileExists(file string) bool {
-	info, err := os.Stat(file)
+	return IsFileExistsFS(OSFileSystem, file)
+}
+
+func IsFileExistsFS(fsys FileSystem, file string) bool {
+	info, err := fsys.Stat(file)
 	if err != nil {
 		return false
 	}
@@ -33,21 +40,83 @@ func IsFileExists(file string) bool {
 }
 
 func IsDirExists(dir string) bool {
-	info, err := os.Stat(dir)
+	return IsDirExistsFS(OSFileSystem, dir)
+}
+
+func IsDirExistsFS(fsys FileSystem, dir string) bool {
+	info, err := fsys.Stat(dir)
 	if err != nil {
 		return false
 	}
 	return info.IsDir()
 }
 
+// GlobFS returns the names of the files matching the pattern like filepath.Glob, but the dirs are read by the file system.
+func GlobFS(fsys FileSystem, pattern string) ([]string, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	if !hasGlobMeta(pattern) {
+		if _, err := fsys.Stat(pattern); err != nil {
+			return nil, nil
+		}
+		return []string{pattern}, nil
+	}
+	dir, file := filepath.Split(pattern)
+	dir = cleanGlobDir(dir)
+	dirs := []string{dir}
+	if hasGlobMeta(dir) {
+		var err error
+		if dirs, err = GlobFS(fsys, dir); err != nil {
+			return nil, err
+		}
+	}
+	var matches []string
+	for i := 0; i < len(dirs); i++ {
+		entries, err := fsys.ReadDir(dirs[i])
+		if err != nil {
+			continue
+		}
+		for j := 0; j < len(entries); j++ {
+			if matched, _ := filepath.Match(file, entries[j].Name()); matched {
+				matches = append(matches, filepath.Join(dirs[i], entries[j].Name()))
+			}
+		}
+	}
+	return matches, nil
+}
+
+func hasGlobMeta(path string) bool {
+	magicChars := `*?[`
+	if runtime.GOOS != "windows" {
+		magicChars = `*?[\`
+	}
+	return strings.ContainsAny(path, magicChars)
+}
+
+func cleanGlobDir(dir string) string {
+	switch dir {
+	case "":
+		return "."
+	case string(filepath.Separator):
+		return dir
+	default:
+		return dir[:len(dir)-1]
+	}
+}
+
 func RemakeDir(dir string) (err error) {
-	if err = os.RemoveAll(dir); err != nil {
+	return RemakeDirFS(OSFileSystem, dir)
+}
+
+func RemakeDirFS(fsys FileSystem, dir string) (err error) {
+	if err = fsys.RemoveAll(dir); err != nil {
 		return ErrW(err, "remake dir error",
 			Reason("remove dir error"),
 			KV("dir", dir),
 		)
 	}
-	if err = os.MkdirAll(dir, os.ModePerm); err != nil {
+	if err = fsys.MkdirAll(dir, os.ModePerm); err != nil {
 		return ErrW(err, "remake dir error",
 			Reason("make dir error"),
 			KV("dir", dir),
@@ -57,7 +126,11 @@ func RemakeDir(dir string) (err error) {
 }
 
 func ClearDir(dir string) (err error) {
-	children, err := os.ReadDir(dir)
+	return ClearDirFS(OSFileSystem, dir)
+}
+
+func ClearDirFS(fsys FileSystem, dir string) (err error) {
+	children, err := fsys.ReadDir(dir)
 	if err != nil {
 		return ErrW(err, "clear dir error",
 			Reason("read dir error"),
@@ -66,20 +139,11 @@ func ClearDir(dir string) (err error) {
 	}
 	for i := 0; i < len(children); i++ {
 		child := filepath.Join(dir, children[i].Name())
-		if children[i].IsDir() {
-			if err = os.RemoveAll(child); err != nil {
-				return ErrW(err, "clear dir error",
-					Reason("remove child dir error"),
-					KV("child", child),
-				)
-			}
-		} else {
-			if err = os.Remove(child); err != nil {
-				return ErrW(err, "clear dir error",
-					Reason("remove child file error"),
-					KV("child", child),
-				)
-			}
+		if err = fsys.RemoveAll(child); err != nil {
+			return ErrW(err, "clear dir error",
+				Reason("remove child error"),
+				KV("child", child),
+			)
 		}
 	}
 	return nil
@@ -134,6 +198,42 @@ func CopyFile(sourceFile string, targetFile string) (err error) {
 	return nil
 }
 
+// CopyFileFS copies the file in the file system, the file is copied by CopyFile in OSFileSystem.
+func CopyFileFS(fsys FileSystem, sourceFile string, targetFile string) (err error) {
+	if fsys == OSFileSystem {
+		return CopyFile(sourceFile, targetFile)
+	}
+	targetDir := filepath.Dir(targetFile)
+	if err = fsys.MkdirAll(targetDir, os.ModePerm); err != nil {
+		return ErrW(err, "copy file error",
+			Reason("make target dir error"),
+			KV("targetDir", targetDir),
+		)
+	}
+	data, err := fsys.ReadFile(sourceFile)
+	if err != nil {
+		return ErrW(err, "copy file error",
+			Reason("read source file error"),
+			KV("sourceFile", sourceFile),
+		)
+	}
+	if err = fsys.WriteFile(targetFile, data, 0666); err != nil {
+		return ErrW(err, "copy file error",
+			Reason("write target file error"),
+			KV("targetFile", targetFile),
+		)
+	}
+	return nil
+}
+
+// LinkOrCopyFileFS links the file in OSFileSystem, and copies the file in the other file systems.
+func LinkOrCopyFileFS(fsys FileSystem, sourceFile string, targetFile string) (err error) {
+	if fsys == OSFileSystem {
+		return LinkOrCopyFile(sourceFile, targetFile)
+	}
+	return CopyFileFS(fsys, sourceFile, targetFile)
+}
+
 func LinkOrCopyFile(sourceFile string, targetFile string) (err error) {
 	err = LinkFile(sourceFile, targetFile)
 	if err != nil {
@@ -186,7 +286,11 @@ func ReadTomlFile(file string, model any) error {
 }
 
 func ReadJsonFile(file string, model any) error {
-	data, err := os.ReadFile(file)
+	return ReadJsonFileFS(OSFileSystem, file, model)
+}
+
+func ReadJsonFileFS(fsys FileSystem, file string, model any) error {
+	data, err := fsys.ReadFile(file)
 	if err != nil {
 		return ErrW(err, "read json file error",
 			Reason("read file error"),
@@ -227,6 +331,15 @@ func IsConfigJsonFile(file string) bool {
 	return strings.HasSuffix(file, ".dcfg.json")
 }
 
+// IsConfigEncFile checks whether the file is an encrypted config file, such as `app.dcfg.yml.enc`.
+func IsConfigEncFile(file string) bool {
+	if !strings.HasSuffix(file, EncryptedFileExt) {
+		return false
+	}
+	file = strings.TrimSuffix(file, EncryptedFileExt)
+	return IsConfigYamlFile(file) || IsConfigTomlFile(file) || IsConfigJsonFile(file)
+}
+
 func IsTemplateFile(file string) bool {
 	return strings.HasSuffix(file, ".dtpl")
 }
@@ -251,6 +364,10 @@ func GetFileType(file string, types []FileType) FileType {
 			if IsConfigJsonFile(file) {
 				return FileTypeConfigJson
 			}
+		case FileTypeConfigEnc:
+			if IsConfigEncFile(file) {
+				return FileTypeConfigEnc
+			}
 		case FileTypeTemplate:
 			if IsTemplateFile(file) {
 				return FileTypeTemplate
@@ -294,6 +411,11 @@ func GetFileNames(globs []string, types []FileType) []string {
 				fileNames = append(fileNames, fileName+".dcfg.toml")
 			case FileTypeConfigJson:
 				fileNames = append(fileNames, fileName+".dcfg.json")
+			case FileTypeConfigEnc:
+				fileNames = append(fileNames, fileName+".dcfg.yml.enc")
+				fileNames = append(fileNames, fileName+".dcfg.yaml.enc")
+				fileNames = append(fileNames, fileName+".dcfg.toml.enc")
+				fileNames = append(fileNames, fileName+".dcfg.json.enc")
 			case FileTypeTemplate:
 				fileNames = append(fileNames, fileName+".dtpl")
 			case FileTypeTemplateLib:
@@ -330,9 +452,13 @@ type File struct {
 }
 
 func FindFile(dir string, fileNames []string, fileTypes []FileType) *File {
+	return FindFileFS(OSFileSystem, dir, fileNames, fileTypes)
+}
+
+func FindFileFS(fsys FileSystem, dir string, fileNames []string, fileTypes []FileType) *File {
 	for i := 0; i < len(fileNames); i++ {
 		filePath := filepath.Join(dir, fileNames[i])
-		if IsFileExists(filePath) {
+		if IsFileExistsFS(fsys, filePath) {
 			return &File{Path: filePath, RelPath: fileNames[i], Type: GetFileType(filePath, fileTypes)}
 		}
 	}
@@ -340,6 +466,10 @@ func FindFile(dir string, fileNames []string, fileTypes []FileType) *File {
 }
 
 func ScanFiles(dir string, includes []string, excludes []string, types []FileType) (files []*File, err error) {
+	return ScanFilesFS(OSFileSystem, dir, includes, excludes, types)
+}
+
+func ScanFilesFS(fsys FileSystem, dir string, includes []string, excludes []string, types []FileType) (files []*File, err error) {
 	var includeFiles map[string]bool
 	if len(includes) > 0 {
 		includeFiles = map[string]bool{}
@@ -354,7 +484,7 @@ func ScanFiles(dir string, includes []string, excludes []string, types []FileTyp
 			excludeFiles[filepath.Join(dir, excludes[i])] = true
 		}
 	}
-	err = filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+	err = walkDirFS(fsys, dir, func(path string, entry fs.DirEntry, err error) error {
 		if err != nil {
 			return ErrW(err, "scan files error",
 				Reason("walk dir error"),
@@ -394,6 +524,46 @@ func ScanFiles(dir string, includes []string, excludes []string, types []FileTyp
 	return files, nil
 }
 
+// walkDirFS walks the file tree like filepath.WalkDir, the entries are walked in lexical order and the symlinks are not followed.
+func walkDirFS(fsys FileSystem, dir string, fn fs.WalkDirFunc) error {
+	info, err := fsys.Stat(dir)
+	if err != nil {
+		return fn(dir, nil, err)
+	}
+	err = walkDirEntryFS(fsys, dir, fs.FileInfoToDirEntry(info), fn)
+	if err == filepath.SkipDir || err == filepath.SkipAll {
+		return nil
+	}
+	return err
+}
+
+func walkDirEntryFS(fsys FileSystem, path string, entry fs.DirEntry, fn fs.WalkDirFunc) error {
+	if err := fn(path, entry, nil); err != nil || !entry.IsDir() {
+		if err == filepath.SkipDir && entry.IsDir() {
+			err = nil
+		}
+		return err
+	}
+	children, err := fsys.ReadDir(path)
+	if err != nil {
+		if err = fn(path, entry, err); err != nil {
+			if err == filepath.SkipDir && entry.IsDir() {
+				err = nil
+			}
+			return err
+		}
+	}
+	for i := 0; i < len(children); i++ {
+		if err = walkDirEntryFS(fsys, filepath.Join(path, children[i].Name()), children[i], fn); err != nil {
+			if err == filepath.SkipDir {
+				break
+			}
+			return err
+		}
+	}
+	return nil
+}
+
 func ListChildDirs(dir string) (names []string, err error) {
 	children, err := os.ReadDir(dir)
 	if err != nil {
@@ -410,3 +580,26 @@ func ListChildDirs(dir string) (names []string, err error) {
 	}
 	return names, nil
 }
+
+func GetDirSize(dir string) (size int64, err error) {
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() {
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, ErrW(err, "get dir size error",
+			Reason("walk dir error"),
+			KV("dir", dir),
+		)
+	}
+	return size, nil
+}
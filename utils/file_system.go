@@ -0,0 +1,348 @@
+package utils
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// region FileSystem
+
+// FileSystem is the file system of the project, resource, template and output files, the names are native paths.
+// The implementations are OSFileSystem, the read-only FSFileSystem over an fs.FS such as embed.FS,
+// the writable MemoryFileSystem, and the MountFileSystem to combine them.
+type FileSystem interface {
+	Stat(name string) (fs.FileInfo, error)
+
+	ReadFile(name string) ([]byte, error)
+
+	ReadDir(name string) ([]fs.DirEntry, error)
+
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+
+	MkdirAll(name string, perm fs.FileMode) error
+
+	RemoveAll(name string) error
+}
+
+// IsOSFileSystemPath checks whether the name is served by the OSFileSystem, so it is a real os path, such as the dir to run a command in.
+func IsOSFileSystemPath(fsys FileSystem, name string) bool {
+	switch s := fsys.(type) {
+	case *osFileSystem:
+		return true
+	case *MountFileSystem:
+		if sub, rel := s.resolve(name); sub == s.base {
+			return IsOSFileSystemPath(sub, rel)
+		}
+	}
+	return false
+}
+
+// getFileSystemName converts the native path to the unrooted slash path used by fs.FS.
+func getFileSystemName(name string) string {
+	name = path.Clean(strings.TrimPrefix(filepath.ToSlash(filepath.Clean(name)), "/"))
+	if name == "" || name == "/" {
+		return "."
+	}
+	return name
+}
+
+// endregion
+
+// region OSFileSystem
+
+var OSFileSystem FileSystem = &osFileSystem{}
+
+type osFileSystem struct{}
+
+func (s *osFileSystem) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (s *osFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (s *osFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (s *osFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (s *osFileSystem) MkdirAll(name string, perm fs.FileMode) error {
+	return os.MkdirAll(name, perm)
+}
+
+func (s *osFileSystem) RemoveAll(name string) error {
+	return os.RemoveAll(name)
+}
+
+// endregion
+
+// region FSFileSystem
+
+// FSFileSystem is the read-only file system over an fs.FS, the native paths are converted to the paths relative to the root of the fs.FS,
+// it is usually mounted to a dir by MountFileSystem.
+type FSFileSystem struct {
+	fs fs.FS
+}
+
+func NewFSFileSystem(fsys fs.FS) *FSFileSystem {
+	return &FSFileSystem{fs: fsys}
+}
+
+func (s *FSFileSystem) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(s.fs, getFileSystemName(name))
+}
+
+func (s *FSFileSystem) ReadFile(name string) ([]byte, error) {
+	return fs.ReadFile(s.fs, getFileSystemName(name))
+}
+
+func (s *FSFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(s.fs, getFileSystemName(name))
+}
+
+func (s *FSFileSystem) WriteFile(name string, _ []byte, _ fs.FileMode) error {
+	return &fs.PathError{Op: "write", Path: name, Err: fs.ErrPermission}
+}
+
+func (s *FSFileSystem) MkdirAll(name string, _ fs.FileMode) error {
+	return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrPermission}
+}
+
+func (s *FSFileSystem) RemoveAll(name string) error {
+	return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrPermission}
+}
+
+// endregion
+
+// region MemoryFileSystem
+
+// MemoryFileSystem is the writable file system in memory, it is safe for concurrent use.
+type MemoryFileSystem struct {
+	entries map[string]*memoryFileSystemEntry
+	mutex   sync.RWMutex
+}
+
+type memoryFileSystemEntry struct {
+	name    string
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func NewMemoryFileSystem() *MemoryFileSystem {
+	return &MemoryFileSystem{
+		entries: map[string]*memoryFileSystemEntry{
+			".": {name: ".", mode: fs.ModeDir | fs.ModePerm, modTime: time.Now()},
+		},
+	}
+}
+
+func (s *MemoryFileSystem) Stat(name string) (fs.FileInfo, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	entry, exist := s.entries[getFileSystemName(name)]
+	if !exist {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return entry, nil
+}
+
+func (s *MemoryFileSystem) ReadFile(name string) ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	entry, exist := s.entries[getFileSystemName(name)]
+	if !exist {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	if entry.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+	return slices.Clone(entry.data), nil
+}
+
+func (s *MemoryFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	dir := getFileSystemName(name)
+	entry, exist := s.entries[dir]
+	if !exist {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	if !entry.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	var entries []fs.DirEntry
+	for k, v := range s.entries {
+		if k != "." && path.Dir(k) == dir {
+			entries = append(entries, fs.FileInfoToDirEntry(v))
+		}
+	}
+	slices.SortFunc(entries, func(l, r fs.DirEntry) int {
+		return strings.Compare(l.Name(), r.Name())
+	})
+	return entries, nil
+}
+
+// WriteFile writes the file, the parent dir must exist like os.WriteFile.
+func (s *MemoryFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	file := getFileSystemName(name)
+	if parent, exist := s.entries[path.Dir(file)]; !exist || !parent.IsDir() {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrNotExist}
+	}
+	if entry, exist := s.entries[file]; exist && entry.IsDir() {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+	s.entries[file] = &memoryFileSystemEntry{name: path.Base(file), data: slices.Clone(data), mode: perm.Perm(), modTime: time.Now()}
+	return nil
+}
+
+func (s *MemoryFileSystem) MkdirAll(name string, perm fs.FileMode) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for dir := getFileSystemName(name); dir != "."; dir = path.Dir(dir) {
+		if entry, exist := s.entries[dir]; exist {
+			if !entry.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrExist}
+			}
+			continue
+		}
+		s.entries[dir] = &memoryFileSystemEntry{name: path.Base(dir), mode: fs.ModeDir | perm.Perm(), modTime: time.Now()}
+	}
+	return nil
+}
+
+func (s *MemoryFileSystem) RemoveAll(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	target := getFileSystemName(name)
+	for k := range s.entries {
+		if k != "." && (target == "." || k == target || strings.HasPrefix(k, target+"/")) {
+			delete(s.entries, k)
+		}
+	}
+	return nil
+}
+
+func (e *memoryFileSystemEntry) Name() string {
+	return e.name
+}
+
+func (e *memoryFileSystemEntry) Size() int64 {
+	return int64(len(e.data))
+}
+
+func (e *memoryFileSystemEntry) Mode() fs.FileMode {
+	return e.mode
+}
+
+func (e *memoryFileSystemEntry) ModTime() time.Time {
+	return e.modTime
+}
+
+func (e *memoryFileSystemEntry) IsDir() bool {
+	return e.mode.IsDir()
+}
+
+func (e *memoryFileSystemEntry) Sys() any {
+	return nil
+}
+
+// endregion
+
+// region MountFileSystem
+
+// MountFileSystem dispatches the names in the mounted dirs to the mounted file systems with the paths relative to the dirs,
+// and the other names to the base file system, the longest mounted dir wins.
+type MountFileSystem struct {
+	base   FileSystem
+	mounts []*mountFileSystemItem
+}
+
+type mountFileSystemItem struct {
+	dir string
+	fs  FileSystem
+}
+
+func NewMountFileSystem(base FileSystem) *MountFileSystem {
+	if base == nil {
+		base = OSFileSystem
+	}
+	return &MountFileSystem{base: base}
+}
+
+// Mount mounts the file system to the dir, the dir is converted to an absolute path.
+func (s *MountFileSystem) Mount(dir string, fsys FileSystem) error {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return ErrW(err, "mount file system error",
+			Reason("get abs-path error"),
+			KV("dir", dir),
+		)
+	}
+	s.mounts = append(s.mounts, &mountFileSystemItem{dir: absDir, fs: fsys})
+	slices.SortStableFunc(s.mounts, func(l, r *mountFileSystemItem) int {
+		return len(r.dir) - len(l.dir)
+	})
+	return nil
+}
+
+func (s *MountFileSystem) resolve(name string) (FileSystem, string) {
+	absName, err := filepath.Abs(name)
+	if err != nil {
+		return s.base, name
+	}
+	for i := 0; i < len(s.mounts); i++ {
+		mount := s.mounts[i]
+		if absName == mount.dir || strings.HasPrefix(absName, mount.dir+string(filepath.Separator)) {
+			rel, err := filepath.Rel(mount.dir, absName)
+			if err == nil {
+				return mount.fs, rel
+			}
+		}
+	}
+	return s.base, name
+}
+
+func (s *MountFileSystem) Stat(name string) (fs.FileInfo, error) {
+	fsys, rel := s.resolve(name)
+	return fsys.Stat(rel)
+}
+
+func (s *MountFileSystem) ReadFile(name string) ([]byte, error) {
+	fsys, rel := s.resolve(name)
+	return fsys.ReadFile(rel)
+}
+
+func (s *MountFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	fsys, rel := s.resolve(name)
+	return fsys.ReadDir(rel)
+}
+
+func (s *MountFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	fsys, rel := s.resolve(name)
+	return fsys.WriteFile(rel, data, perm)
+}
+
+func (s *MountFileSystem) MkdirAll(name string, perm fs.FileMode) error {
+	fsys, rel := s.resolve(name)
+	return fsys.MkdirAll(rel, perm)
+}
+
+func (s *MountFileSystem) RemoveAll(name string) error {
+	fsys, rel := s.resolve(name)
+	return fsys.RemoveAll(rel)
+}
+
+// endregion
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFileSystem(t *testing.T) {
+	source := NewFSFileSystem(fstest.MapFS{
+		"app/project.yml":          {Data: []byte("name: app\n")},
+		"app/script/main.sh.dtpl":  {Data: []byte(`{{ template "lib" . }} {{ .name }}`)},
+		"app/script/lib.dtpl.lib":  {Data: []byte(`{{ define "lib" }}hello{{ end }}`)},
+		"app/script/plain.sh":      {Data: []byte("echo plain")},
+		"app/script/skip/skip.txt": {Data: []byte("skip")},
+	})
+	output := NewMemoryFileSystem()
+	fsys := NewMountFileSystem(nil)
+	sourceDir := filepath.Join(t.TempDir(), "source")
+	outputDir := filepath.Join(t.TempDir(), "output")
+	if err := fsys.Mount(sourceDir, source); err != nil {
+		t.Fatal(err)
+	}
+	if err := fsys.Mount(outputDir, output); err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsDirExistsFS(fsys, filepath.Join(sourceDir, "app")) || IsDirExists(filepath.Join(sourceDir, "app")) {
+		t.Fatal("mount dir invalid")
+	}
+	model := map[string]any{}
+	metadata, err := DeserializeDirFS(fsys, filepath.Join(sourceDir, "app"), []string{"project"}, &model, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if model["name"] != "app" || metadata.Format != SerializationFormatYaml {
+		t.Fatal(model, metadata)
+	}
+
+	scriptDir := filepath.Join(sourceDir, "app", "script")
+	files, err := ScanFilesFS(fsys, scriptDir, nil, []string{"skip/skip.txt"}, []FileType{FileTypeTemplate, FileTypeTemplateLib, FileTypePlain})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 || files[0].RelPath != "lib.dtpl.lib" || files[1].RelPath != "main.sh.dtpl" || files[2].RelPath != "plain.sh" {
+		t.Fatal(files)
+	}
+
+	target := filepath.Join(outputDir, "app", "main.sh")
+	if err = EvalFileTemplateFS(fsys, files[1].Path, []string{files[0].Path}, target, map[string]any{"name": "world"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err = LinkOrCopyFileFS(fsys, files[2].Path, filepath.Join(outputDir, "app", "plain.sh")); err != nil {
+		t.Fatal(err)
+	}
+	if err = SerializeFileFS(fsys, JsonSerializerDefault, filepath.Join(outputDir, "app", "model.json"), model); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := output.ReadFile("app/main.sh"); err != nil || string(data) != "hello world" {
+		t.Fatal(string(data), err)
+	}
+	entries, err := output.ReadDir("app")
+	if err != nil || len(entries) != 3 || entries[0].Name() != "main.sh" || entries[1].Name() != "model.json" {
+		t.Fatal(entries, err)
+	}
+	if IsFileExists(target) {
+		t.Fatal("output written to os")
+	}
+
+	if err = fsys.WriteFile(filepath.Join(sourceDir, "app", "new.txt"), nil, 0666); err == nil {
+		t.Fatal("expected read-only error")
+	}
+	if err = output.WriteFile("missing/file.txt", nil, 0666); err == nil {
+		t.Fatal("expected parent dir not exist error")
+	}
+	if err = ClearDirFS(fsys, filepath.Join(outputDir, "app")); err != nil {
+		t.Fatal(err)
+	}
+	if entries, err = output.ReadDir("app"); err != nil || len(entries) != 0 {
+		t.Fatal(entries, err)
+	}
+}
+
+func TestGlobFS(t *testing.T) {
+	fsys := NewMemoryFileSystem()
+	dir := filepath.Join(string(filepath.Separator), "app")
+	for _, name := range []string{"a.yml", "b.yml", "c.txt", filepath.Join("sub1", "d.yml"), filepath.Join("sub2", "e.yml")} {
+		if err := fsys.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := fsys.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, err := GlobFS(fsys, filepath.Join(dir, "*.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files[0] != filepath.Join(dir, "a.yml") || files[1] != filepath.Join(dir, "b.yml") {
+		t.Fatal(files)
+	}
+	if files, err = GlobFS(fsys, filepath.Join(dir, "sub*", "*.yml")); err != nil || len(files) != 2 || files[1] != filepath.Join(dir, "sub2", "e.yml") {
+		t.Fatal(files, err)
+	}
+	if files, err = GlobFS(fsys, filepath.Join(dir, "c.txt")); err != nil || len(files) != 1 {
+		t.Fatal(files, err)
+	}
+	if files, err = GlobFS(fsys, filepath.Join(dir, "x.txt")); err != nil || len(files) != 0 {
+		t.Fatal(files, err)
+	}
+	if _, err = GlobFS(fsys, filepath.Join(dir, "[")); err == nil {
+		t.Fatal("bad pattern should fail")
+	}
+}
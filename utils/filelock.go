@@ -0,0 +1,187 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// region FileLock
+
+type FileLock struct {
+	File   string
+	handle *os.File
+	shared bool
+}
+
+func NewFileLock(file string) *FileLock {
+	return &FileLock{
+		File: file,
+	}
+}
+
+func (l *FileLock) TryLock(shared bool) (bool, error) {
+	if l.handle != nil {
+		return false, ErrN("try lock file error",
+			Reason("already locked"),
+			KV("file", l.File),
+		)
+	}
+	if err := os.MkdirAll(filepath.Dir(l.File), os.ModePerm); err != nil {
+		return false, ErrW(err, "try lock file error",
+			Reason("make dir error"),
+			KV("file", l.File),
+		)
+	}
+	handle, err := l.openLockedFile(shared)
+	if err != nil || handle == nil {
+		return false, err
+	}
+	if !shared {
+		// the owner pid is informational only, the lock itself is held by the os
+		if err = handle.Truncate(0); err == nil {
+			_, err = handle.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0)
+		}
+		if err != nil {
+			_ = unlockFile(handle)
+			_ = handle.Close()
+			return false, ErrW(err, "try lock file error",
+				Reason("write owner error"),
+				KV("file", l.File),
+			)
+		}
+	}
+	l.handle = handle
+	l.shared = shared
+	return true, nil
+}
+
+// openLockedFile opens and locks the file, the file is reopened if it is removed by TryRemove of another lock
+// after opening, so the lock is always held on the file at the path.
+func (l *FileLock) openLockedFile(shared bool) (*os.File, error) {
+	for {
+		handle, err := os.OpenFile(l.File, os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, ErrW(err, "try lock file error",
+				Reason("open file error"),
+				KV("file", l.File),
+			)
+		}
+		locked, err := tryLockFile(handle, shared)
+		if err != nil || !locked {
+			_ = handle.Close()
+			if err != nil {
+				return nil, ErrW(err, "try lock file error",
+					Reason("lock file error"),
+					KV("file", l.File),
+					KV("shared", shared),
+				)
+			}
+			return nil, nil
+		}
+		handleInfo, err1 := handle.Stat()
+		fileInfo, err2 := os.Stat(l.File)
+		if err1 == nil && err2 == nil && os.SameFile(handleInfo, fileInfo) {
+			return handle, nil
+		}
+		_ = unlockFile(handle)
+		_ = handle.Close()
+		if err1 != nil {
+			return nil, ErrW(err1, "try lock file error",
+				Reason("stat file error"),
+				KV("file", l.File),
+			)
+		}
+	}
+}
+
+// TryRemove removes the lock file if it is not locked, the lock must not be held by itself.
+func (l *FileLock) TryRemove() (bool, error) {
+	locked, err := l.TryLock(false)
+	if err != nil || !locked {
+		return false, err
+	}
+	removeErr := os.Remove(l.File)
+	if err = l.Unlock(); err != nil {
+		return false, err
+	}
+	if removeErr != nil {
+		return false, ErrW(removeErr, "try remove file lock error",
+			Reason("remove file error"),
+			KV("file", l.File),
+		)
+	}
+	return true, nil
+}
+
+func (l *FileLock) Lock(shared bool, timeout time.Duration, waiting func()) error {
+	startTime := time.Now()
+	interval := 10 * time.Millisecond
+	waited := false
+	for {
+		locked, err := l.TryLock(shared)
+		if err != nil {
+			return err
+		}
+		if locked {
+			return nil
+		}
+		elapsed := time.Since(startTime)
+		if elapsed >= timeout {
+			return ErrN("lock file error",
+				Code(ErrorCodeLockTimeout),
+				Reason("wait timeout"),
+				KV("file", l.File),
+				KV("shared", shared),
+				KV("timeout", timeout),
+			)
+		}
+		if !waited && waiting != nil {
+			waiting()
+		}
+		waited = true
+		time.Sleep(min(interval, timeout-elapsed))
+		interval = min(interval*2, time.Second)
+	}
+}
+
+func (l *FileLock) Inherit() error {
+	if l.handle == nil {
+		return ErrN("inherit file lock error",
+			Reason("not locked"),
+			KV("file", l.File),
+		)
+	}
+	if err := inheritFile(l.handle); err != nil {
+		return ErrW(err, "inherit file lock error",
+			Reason("clear close-on-exec error"),
+			KV("file", l.File),
+		)
+	}
+	return nil
+}
+
+func (l *FileLock) Unlock() error {
+	if l.handle == nil {
+		return nil
+	}
+	handle := l.handle
+	l.handle = nil
+	if err := unlockFile(handle); err != nil {
+		_ = handle.Close()
+		return ErrW(err, "unlock file error",
+			Reason("unlock file error"),
+			KV("file", l.File),
+		)
+	}
+	if err := handle.Close(); err != nil {
+		return ErrW(err, "unlock file error",
+			Reason("close file error"),
+			KV("file", l.File),
+		)
+	}
+	return nil
+}
+
+// endregion
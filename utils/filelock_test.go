@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileLock(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "test.lock")
+
+	shared1 := NewFileLock(file)
+	if err := shared1.Lock(true, time.Second, nil); err != nil {
+		t.Fatal(err)
+	}
+	shared2 := NewFileLock(file)
+	if locked, err := shared2.TryLock(true); err != nil || !locked {
+		t.Fatal("shared lock should be acquired", err)
+	}
+	exclusive := NewFileLock(file)
+	if locked, err := exclusive.TryLock(false); err != nil || locked {
+		t.Fatal("exclusive lock should not be acquired", err)
+	}
+
+	waited := false
+	if err := exclusive.Lock(false, 50*time.Millisecond, func() { waited = true }); err == nil {
+		t.Fatal("exclusive lock should timeout")
+	}
+	if !waited {
+		t.Fatal("waiting callback should be called")
+	}
+
+	if err := shared1.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := shared2.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := exclusive.Lock(false, time.Second, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := exclusive.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileLockRemove(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "test.lock")
+
+	shared := NewFileLock(file)
+	if err := shared.Lock(true, time.Second, nil); err != nil {
+		t.Fatal(err)
+	}
+	if removed, err := NewFileLock(file).TryRemove(); err != nil || removed {
+		t.Fatal("locked file should not be removed", err)
+	}
+	if err := shared.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	if removed, err := NewFileLock(file).TryRemove(); err != nil || !removed {
+		t.Fatal("unlocked file should be removed", err)
+	}
+	if IsFileExists(file) {
+		t.Fatal("lock file should not exist")
+	}
+
+	exclusive := NewFileLock(file)
+	if err := exclusive.Lock(false, time.Second, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !IsFileExists(file) {
+		t.Fatal("lock file should be recreated")
+	}
+	if err := exclusive.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+}
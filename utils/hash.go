@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"os"
+)
+
+// region Hasher
+
+type Hasher struct {
+	hash hash.Hash
+}
+
+func NewHasher() *Hasher {
+	return &Hasher{
+		hash: sha256.New(),
+	}
+}
+
+func (h *Hasher) WriteString(key string, value string) *Hasher {
+	_, _ = io.WriteString(h.hash, key)
+	_, _ = h.hash.Write([]byte{0})
+	_, _ = io.WriteString(h.hash, value)
+	_, _ = h.hash.Write([]byte{0})
+	return h
+}
+
+// WriteValue writes the json of the value, the secrets are written by getSecretHashValue instead of the mask.
+func (h *Hasher) WriteValue(key string, value any) error {
+	data, err := json.Marshal(getHashValue(value))
+	if err != nil {
+		return ErrW(err, "hash value error",
+			Reason("marshal json error"),
+			KV("key", key),
+		)
+	}
+	h.WriteString(key, string(data))
+	return nil
+}
+
+func (h *Hasher) WriteFile(key string, file string) error {
+	fileHash, err := HashFile(file)
+	if err != nil {
+		return ErrW(err, "hash value error",
+			Reason("hash file error"),
+			KV("key", key),
+		)
+	}
+	h.WriteString(key, fileHash)
+	return nil
+}
+
+func (h *Hasher) WriteFileFS(key string, fsys FileSystem, file string) error {
+	fileHash, err := HashFileFS(fsys, file)
+	if err != nil {
+		return ErrW(err, "hash value error",
+			Reason("hash file error"),
+			KV("key", key),
+		)
+	}
+	h.WriteString(key, fileHash)
+	return nil
+}
+
+func (h *Hasher) Sum() string {
+	return hex.EncodeToString(h.hash.Sum(nil))
+}
+
+// getHashValue returns a copy of the value, the secrets in the maps and lists are replaced by getSecretHashValue.
+func getHashValue(value any) any {
+	switch v := value.(type) {
+	case *Secret:
+		return getSecretHashValue(v)
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[key] = getHashValue(item)
+		}
+		return result
+	case EvalData:
+		return getHashValue(map[string]any(v))
+	case []any:
+		result := make([]any, len(v))
+		for i := 0; i < len(v); i++ {
+			result[i] = getHashValue(v[i])
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// getSecretHashValue returns the reference and the digest of the resolved value of the secret, so the hash changes
+// when the reference or the resolved value changes, and the resolved value is never written as it is.
+// The digest is omitted if the secret fails to resolve, the error is reported when the secret is used.
+func getSecretHashValue(secret *Secret) string {
+	resolved, err := secret.Resolve()
+	if err != nil {
+		return secret.GetReference()
+	}
+	digest := sha256.Sum256([]byte(resolved))
+	return secret.GetReference() + "@" + hex.EncodeToString(digest[:])
+}
+
+// endregion
+
+func HashFile(file string) (string, error) {
+	reader, err := os.Open(file)
+	if err != nil {
+		return "", ErrW(err, "hash file error",
+			Reason("open file error"),
+			KV("file", file),
+		)
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+	if _, err = io.Copy(hasher, reader); err != nil {
+		return "", ErrW(err, "hash file error",
+			Reason("io copy error"),
+			KV("file", file),
+		)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func HashFileFS(fsys FileSystem, file string) (string, error) {
+	if fsys == OSFileSystem {
+		return HashFile(file)
+	}
+	data, err := fsys.ReadFile(file)
+	if err != nil {
+		return "", ErrW(err, "hash file error",
+			Reason("read file error"),
+			KV("file", file),
+		)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
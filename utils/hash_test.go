@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHasher(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(file, []byte("content"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	hash1 := NewHasher().WriteString("key", "value").Sum()
+	hash2 := NewHasher().WriteString("key", "value").Sum()
+	if hash1 != hash2 {
+		t.Fatal("hash not stable")
+	}
+	hash3 := NewHasher().WriteString("keyv", "alue").Sum()
+	if hash1 == hash3 {
+		t.Fatal("hash key value not separated")
+	}
+
+	hasher := NewHasher()
+	if err := hasher.WriteValue("map", map[string]any{"b": 2, "a": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := hasher.WriteFile("file", file); err != nil {
+		t.Fatal(err)
+	}
+	t.Log(DescN("test hasher",
+		KV("hash1", hash1),
+		KV("hash3", hash3),
+		KV("hash4", hasher.Sum()),
+	))
+
+	if _, err := HashFile(filepath.Join(t.TempDir(), "not-exist.txt")); err != nil {
+		t.Log(err)
+	} else {
+		Impossible()
+	}
+}
+
+func TestHasherSecret(t *testing.T) {
+	t.Setenv("DSH_TEST_HASH_SECRET1", "value1")
+	t.Setenv("DSH_TEST_HASH_SECRET2", "value1")
+	hash := func(reference string) string {
+		secret, err := ParseSecret(reference)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hasher := NewHasher()
+		if err = hasher.WriteValue("config", map[string]any{"pass": secret}); err != nil {
+			t.Fatal(err)
+		}
+		return hasher.Sum()
+	}
+	hash1 := hash("secret:env:DSH_TEST_HASH_SECRET1")
+	if hash1 != hash("secret:env:DSH_TEST_HASH_SECRET1") {
+		t.Fatal("hash not stable")
+	}
+	if hash1 == hash("secret:env:DSH_TEST_HASH_SECRET2") {
+		t.Fatal("hash should change with the reference")
+	}
+	t.Setenv("DSH_TEST_HASH_SECRET1", "value2")
+	if hash1 == hash("secret:env:DSH_TEST_HASH_SECRET1") {
+		t.Fatal("hash should change with the resolved value")
+	}
+	if hash("secret:env:DSH_TEST_HASH_SECRET_MISSING") == "" {
+		t.Fatal("unresolved secret should be hashed by the reference")
+	}
+}
@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// region base
+
+const JsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JsonSchemaDescriber is implemented by models to add descriptions and constraints to the generated schema.
+type JsonSchemaDescriber interface {
+	DescribeJsonSchema(schema *JsonSchema)
+}
+
+var jsonSchemaDescriberType = reflect.TypeOf((*JsonSchemaDescriber)(nil)).Elem()
+
+// endregion
+
+// region JsonSchema
+
+type JsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Id                   string                 `json:"$id,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 any                    `json:"type,omitempty"`
+	Enum                 []any                  `json:"enum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	Properties           map[string]*JsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties any                    `json:"additionalProperties,omitempty"`
+	Items                *JsonSchema            `json:"items,omitempty"`
+	OneOf                []*JsonSchema          `json:"oneOf,omitempty"`
+	AnyOf                []*JsonSchema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*JsonSchema `json:"$defs,omitempty"`
+}
+
+func NewJsonSchema(typ any) *JsonSchema {
+	return &JsonSchema{
+		Type: typ,
+	}
+}
+
+// NewJsonSchemaByModel generates the schema of the model by the json struct tags, the nested struct models are placed in `$defs`.
+func NewJsonSchemaByModel(id, title string, model any) *JsonSchema {
+	defs := map[string]*JsonSchema{}
+	schema := newJsonSchemaByType(reflect.TypeOf(model), defs)
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/$defs/")
+		schema = defs[name]
+		delete(defs, name)
+	}
+	schema.Schema = JsonSchemaDraft
+	schema.Id = id
+	schema.Title = title
+	if len(defs) > 0 {
+		schema.Defs = defs
+	}
+	return schema
+}
+
+func newJsonSchemaByType(typ reflect.Type, defs map[string]*JsonSchema) *JsonSchema {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		return NewJsonSchema("string")
+	case reflect.Bool:
+		return NewJsonSchema("boolean")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return NewJsonSchema("integer")
+	case reflect.Float32, reflect.Float64:
+		return NewJsonSchema("number")
+	case reflect.Slice, reflect.Array:
+		schema := NewJsonSchema("array")
+		schema.Items = newJsonSchemaByType(typ.Elem(), defs)
+		return schema
+	case reflect.Map:
+		schema := NewJsonSchema("object")
+		schema.AdditionalProperties = newJsonSchemaByType(typ.Elem(), defs)
+		return schema
+	case reflect.Struct:
+		name := strings.TrimSuffix(typ.Name(), "SettingModel")
+		if _, exist := defs[name]; !exist {
+			// placeholder for recursive models
+			defs[name] = nil
+			defs[name] = newJsonSchemaByStruct(typ, defs)
+		}
+		return &JsonSchema{Ref: "#/$defs/" + name}
+	default:
+		return &JsonSchema{}
+	}
+}
+
+func newJsonSchemaByStruct(typ reflect.Type, defs map[string]*JsonSchema) *JsonSchema {
+	schema := NewJsonSchema("object")
+	schema.Properties = map[string]*JsonSchema{}
+	schema.AdditionalProperties = false
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = newJsonSchemaByType(field.Type, defs)
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	if reflect.PointerTo(typ).Implements(jsonSchemaDescriberType) {
+		reflect.New(typ).Interface().(JsonSchemaDescriber).DescribeJsonSchema(schema)
+	}
+	return schema
+}
+
+func (s *JsonSchema) GetProperty(name string) *JsonSchema {
+	if property, exist := s.Properties[name]; exist {
+		return property
+	}
+	Impossible()
+	return nil
+}
+
+func (s *JsonSchema) SetDescription(description string) *JsonSchema {
+	s.Description = description
+	return s
+}
+
+func (s *JsonSchema) SetPattern(pattern string) *JsonSchema {
+	s.Pattern = pattern
+	return s
+}
+
+func (s *JsonSchema) SetEnum(values ...any) *JsonSchema {
+	s.Enum = values
+	return s
+}
+
+func (s *JsonSchema) SetMinimum(minimum float64) *JsonSchema {
+	s.Minimum = &minimum
+	return s
+}
+
+func (s *JsonSchema) SetMinLength(length int) *JsonSchema {
+	s.MinLength = &length
+	return s
+}
+
+func (s *JsonSchema) SetItems(items *JsonSchema) *JsonSchema {
+	s.Items = items
+	return s
+}
+
+func (s *JsonSchema) SetOneOf(schemas ...*JsonSchema) *JsonSchema {
+	s.Type = nil
+	s.OneOf = schemas
+	return s
+}
+
+func (s *JsonSchema) SetAnyOf(schemas ...*JsonSchema) *JsonSchema {
+	s.Type = nil
+	s.AnyOf = schemas
+	return s
+}
+
+func (s *JsonSchema) Marshal() ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(s); err != nil {
+		return nil, ErrW(err, "marshal json schema error",
+			Reason("encode json error"),
+			KV("id", s.Id),
+		)
+	}
+	return buffer.Bytes(), nil
+}
+
+// endregion
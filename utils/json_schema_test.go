@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"testing"
+)
+
+type testJsonSchemaSettingModel struct {
+	Name  string                            `json:"name"`
+	Count *int                              `json:"count,omitempty"`
+	Items []*testJsonSchemaItemSettingModel `json:"items,omitempty"`
+}
+
+type testJsonSchemaItemSettingModel struct {
+	Value string `json:"value"`
+}
+
+func (m *testJsonSchemaSettingModel) DescribeJsonSchema(schema *JsonSchema) {
+	schema.GetProperty("name").SetPattern("^[a-z]+$").SetDescription("test name")
+}
+
+func TestJsonSchema(t *testing.T) {
+	schema := NewJsonSchemaByModel("test.json", "test", &testJsonSchemaSettingModel{})
+	if schema.Type != "object" || len(schema.Required) != 1 || schema.Required[0] != "name" {
+		t.Fatalf("unexpected root schema: %+v", schema)
+	}
+	if name := schema.GetProperty("name"); name.Pattern != "^[a-z]+$" || name.Description != "test name" {
+		t.Fatalf("unexpected name schema: %+v", name)
+	}
+	if count := schema.GetProperty("count"); count.Type != "integer" {
+		t.Fatalf("unexpected count schema: %+v", count)
+	}
+	if items := schema.GetProperty("items"); items.Type != "array" || items.Items.Ref != "#/$defs/testJsonSchemaItem" {
+		t.Fatalf("unexpected items schema: %+v", items)
+	}
+	if item := schema.Defs["testJsonSchemaItem"]; item == nil || item.GetProperty("value").Type != "string" {
+		t.Fatalf("unexpected defs: %+v", schema.Defs)
+	}
+	if _, err := schema.Marshal(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,11 +1,19 @@
 package utils
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"os"
+	"strings"
+	"sync"
+	"time"
 )
 
+// region LogLevel
+
 type LogLevel int
 
 const (
@@ -17,120 +25,378 @@ const (
 	LogLevelNone  LogLevel = 5
 )
 
+var logLevelNames = map[string]LogLevel{
+	"all":   LogLevelAll,
+	"debug": LogLevelDebug,
+	"info":  LogLevelInfo,
+	"warn":  LogLevelWarn,
+	"error": LogLevelError,
+	"none":  LogLevelNone,
+}
+
+func (l LogLevel) String() string {
+	for name, level := range logLevelNames {
+		if level == l {
+			return name
+		}
+	}
+	return ""
+}
+
+func ParseLogLevel(str string) (LogLevel, error) {
+	if level, exist := logLevelNames[strings.ToLower(strings.TrimSpace(str))]; exist {
+		return level, nil
+	}
+	return 0, ErrN("parse log level error",
+		Reason("log level invalid"),
+		KV("level", str),
+	)
+}
+
+// ParseLogLevels parses a list like "info,git=debug,option=warn", the item without name is the root level.
+func ParseLogLevels(str string) (root *LogLevel, levels map[string]LogLevel, err error) {
+	levels = map[string]LogLevel{}
+	items := strings.Split(str, ",")
+	for i := 0; i < len(items); i++ {
+		item := strings.TrimSpace(items[i])
+		if item == "" {
+			continue
+		}
+		name, value, found := strings.Cut(item, "=")
+		if !found {
+			value = name
+			name = ""
+		}
+		level, err := ParseLogLevel(value)
+		if err != nil {
+			return nil, nil, ErrW(err, "parse log levels error",
+				Reason("parse log level error"),
+				KV("item", item),
+			)
+		}
+		if name == "" {
+			root = &level
+		} else {
+			levels[strings.TrimSpace(name)] = level
+		}
+	}
+	return root, levels, nil
+}
+
+func (l LogLevel) toSlogLevel() slog.Level {
+	switch l {
+	case LogLevelAll, LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelInfo:
+		return slog.LevelInfo
+	case LogLevelWarn:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+// endregion
+
+// region LogFormat
+
+type LogFormat string
+
+const (
+	LogFormatDesc LogFormat = "desc"
+	LogFormatText LogFormat = "text"
+	LogFormatJson LogFormat = "json"
+)
+
+func ParseLogFormat(str string) (LogFormat, error) {
+	switch format := LogFormat(strings.ToLower(strings.TrimSpace(str))); format {
+	case LogFormatDesc, LogFormatText, LogFormatJson:
+		return format, nil
+	}
+	return "", ErrN("parse log format error",
+		Reason("log format invalid"),
+		KV("format", str),
+	)
+}
+
+// endregion
+
+// region Logger
+
+const (
+	LoggerNameGit      = "git"
+	LoggerNameArchive  = "archive"
+	LoggerNameOci      = "oci"
+	LoggerNameResource = "resource"
+	LoggerNameExecutor = "executor"
+	LoggerNameOption   = "option"
+)
+
 type Logger struct {
-	Level        LogLevel
+	Level  LogLevel
+	Name   string
+	config *loggerConfig
+}
+
+type loggerConfig struct {
+	mutex        sync.RWMutex
+	format       LogFormat
+	normalWriter io.Writer
+	errorWriter  io.Writer
 	normalLogger *log.Logger
 	errorLogger  *log.Logger
+	normalSlog   *slog.Logger
+	errorSlog    *slog.Logger
+	levels       map[string]LogLevel
 }
 
 func NewLogger(level LogLevel) *Logger {
+	config := &loggerConfig{
+		levels: map[string]LogLevel{},
+	}
+	config.setup(LogFormatDesc, os.Stdout, os.Stderr)
 	return &Logger{
-		Level:        level,
-		normalLogger: log.New(os.Stdout, "", 0),
-		errorLogger:  log.New(os.Stderr, "", 0),
+		Level:  level,
+		config: config,
+	}
+}
+
+func (c *loggerConfig) setup(format LogFormat, normalWriter, errorWriter io.Writer) {
+	c.format = format
+	c.normalWriter = normalWriter
+	c.errorWriter = errorWriter
+	c.normalLogger = log.New(normalWriter, "", log.LstdFlags|log.Lmicroseconds)
+	c.errorLogger = log.New(errorWriter, "", log.LstdFlags|log.Lmicroseconds)
+	options := &slog.HandlerOptions{Level: slog.LevelDebug}
+	switch format {
+	case LogFormatJson:
+		c.normalSlog = slog.New(slog.NewJSONHandler(normalWriter, options))
+		c.errorSlog = slog.New(slog.NewJSONHandler(errorWriter, options))
+	case LogFormatText:
+		c.normalSlog = slog.New(slog.NewTextHandler(normalWriter, options))
+		c.errorSlog = slog.New(slog.NewTextHandler(errorWriter, options))
+	default:
+		c.normalSlog = nil
+		c.errorSlog = nil
+	}
+}
+
+// SetFormat changes the output format of the logger and all loggers named from it.
+func (l *Logger) SetFormat(format LogFormat) *Logger {
+	l.config.mutex.Lock()
+	defer l.config.mutex.Unlock()
+	l.config.setup(format, l.config.normalWriter, l.config.errorWriter)
+	return l
+}
+
+// SetWriters changes the output writers of the logger and all loggers named from it.
+func (l *Logger) SetWriters(normalWriter, errorWriter io.Writer) *Logger {
+	l.config.mutex.Lock()
+	defer l.config.mutex.Unlock()
+	l.config.setup(l.config.format, normalWriter, errorWriter)
+	return l
+}
+
+// SetNamedLevel sets the level of the named logger, it takes effect on loggers already named.
+func (l *Logger) SetNamedLevel(name string, level LogLevel) *Logger {
+	l.config.mutex.Lock()
+	defer l.config.mutex.Unlock()
+	l.config.levels[name] = level
+	return l
+}
+
+// Named returns a logger of the subsystem, it shares the format and writers with the current logger.
+func (l *Logger) Named(name string) *Logger {
+	return &Logger{
+		Level:  l.Level,
+		Name:   name,
+		config: l.config,
+	}
+}
+
+func (l *Logger) GetFormat() LogFormat {
+	l.config.mutex.RLock()
+	defer l.config.mutex.RUnlock()
+	return l.config.format
+}
+
+func (l *Logger) getLevel() LogLevel {
+	if l.Name != "" {
+		l.config.mutex.RLock()
+		defer l.config.mutex.RUnlock()
+		if level, exist := l.config.levels[l.Name]; exist {
+			return level
+		}
 	}
+	return l.Level
+}
+
+func (l *Logger) output(level LogLevel, tag string, message string, kvs []DescKeyValue, errorOutput bool) {
+	l.config.mutex.RLock()
+	defer l.config.mutex.RUnlock()
+	if l.config.format == LogFormatDesc {
+		var text string
+		if kvs == nil {
+			text = message
+		} else {
+			text = NewDesc(message, kvs).ToString("", "\t\t")
+		}
+		if errorOutput {
+			l.config.errorLogger.Print(tag + " " + text)
+		} else {
+			l.config.normalLogger.Print(tag + " " + text)
+		}
+		return
+	}
+	logger := l.config.normalSlog
+	if errorOutput {
+		logger = l.config.errorSlog
+	}
+	attrs := make([]slog.Attr, 0, len(kvs)+1)
+	if l.Name != "" {
+		attrs = append(attrs, slog.String("logger", l.Name))
+	}
+	for i := 0; i < len(kvs); i++ {
+		attrs = append(attrs, newLogAttr(kvs[i].Key, kvs[i].Value, 0))
+	}
+	slogLevel := level.toSlogLevel()
+	if tag == "[FATAL]" || tag == "[PANIC]" {
+		slogLevel = slog.LevelError + 4
+	}
+	logger.LogAttrs(context.Background(), slogLevel, message, attrs...)
+}
+
+func newLogAttr(key string, value any, depth int) slog.Attr {
+	switch v := value.(type) {
+	case nil:
+		return slog.Any(key, nil)
+	case error:
+		return slog.String(key, v.Error())
+	case time.Duration:
+		return slog.String(key, v.String())
+	case DescKeyValuesFunc:
+		if depth >= 5 {
+			return slog.String(key, "<omit>")
+		}
+		kvs := v.DescKeyValues()
+		attrs := make([]any, 0, len(kvs))
+		for i := 0; i < len(kvs); i++ {
+			attrs = append(attrs, newLogAttr(kvs[i].Key, kvs[i].Value, depth+1))
+		}
+		return slog.Group(key, attrs...)
+	case fmt.Stringer:
+		return slog.String(key, v.String())
+	}
+	return slog.Any(key, value)
 }
 
 func (l *Logger) IsDebugEnabled() bool {
-	return l.Level <= LogLevelDebug
+	return l.getLevel() <= LogLevelDebug
 }
 
 func (l *Logger) GetDebugWriter() io.Writer {
-	return l.normalLogger.Writer()
+	return l.config.normalWriter
 }
 
 func (l *Logger) Debug(format string, v ...any) {
 	if l.IsDebugEnabled() {
-		l.normalLogger.Printf("[DEBUG] "+format, v...)
+		l.output(LogLevelDebug, "[DEBUG]", fmt.Sprintf(format, v...), nil, false)
 	}
 }
 
 func (l *Logger) DebugDesc(title string, kvs ...DescKeyValue) {
 	if l.IsDebugEnabled() {
-		l.normalLogger.Printf("[DEBUG] %+v", NewDesc(title, kvs).ToString("", "\t\t"))
+		l.output(LogLevelDebug, "[DEBUG]", title, kvs, false)
 	}
 }
 
 func (l *Logger) IsInfoEnabled() bool {
-	return l.Level <= LogLevelInfo
+	return l.getLevel() <= LogLevelInfo
 }
 
 func (l *Logger) GetInfoWriter() io.Writer {
-	return l.normalLogger.Writer()
+	return l.config.normalWriter
 }
 
 func (l *Logger) Info(format string, v ...any) {
 	if l.IsInfoEnabled() {
-		l.normalLogger.Printf("[INFO ] "+format, v...)
+		l.output(LogLevelInfo, "[INFO ]", fmt.Sprintf(format, v...), nil, false)
 	}
 }
 
 func (l *Logger) InfoDesc(title string, kvs ...DescKeyValue) {
 	if l.IsInfoEnabled() {
-		l.normalLogger.Printf("[INFO ] %+v", NewDesc(title, kvs).ToString("", "\t\t"))
+		l.output(LogLevelInfo, "[INFO ]", title, kvs, false)
 	}
 }
 
 func (l *Logger) IsWarnEnabled() bool {
-	return l.Level <= LogLevelWarn
+	return l.getLevel() <= LogLevelWarn
 }
 
 func (l *Logger) GetWarnWriter() io.Writer {
-	return l.normalLogger.Writer()
+	return l.config.normalWriter
 }
 
 func (l *Logger) Warn(format string, v ...any) {
 	if l.IsWarnEnabled() {
-		l.normalLogger.Printf("[WARN ] "+format, v...)
+		l.output(LogLevelWarn, "[WARN ]", fmt.Sprintf(format, v...), nil, false)
 	}
 }
 
 func (l *Logger) WarnDesc(title string, kvs ...DescKeyValue) {
 	if l.IsWarnEnabled() {
-		l.normalLogger.Printf("[WARN ] %+v", NewDesc(title, kvs).ToString("", "\t\t"))
+		l.output(LogLevelWarn, "[WARN ]", title, kvs, false)
 	}
 }
 
 func (l *Logger) IsErrorEnabled() bool {
-	return l.Level <= LogLevelError
+	return l.getLevel() <= LogLevelError
 }
 
 func (l *Logger) GetErrorWriter() io.Writer {
-	return l.errorLogger.Writer()
+	return l.config.errorWriter
 }
 
 func (l *Logger) Error(format string, v ...any) {
 	if l.IsErrorEnabled() {
-		l.errorLogger.Printf("[ERROR] "+format, v...)
+		l.output(LogLevelError, "[ERROR]", fmt.Sprintf(format, v...), nil, true)
 	}
 }
 
 func (l *Logger) ErrorDesc(title string, kvs ...DescKeyValue) {
 	if l.IsErrorEnabled() {
-		l.errorLogger.Printf("[ERROR] %+v", NewDesc(title, kvs).ToString("", "\t\t"))
+		l.output(LogLevelError, "[ERROR]", title, kvs, true)
 	}
 }
 
 func (l *Logger) GetFatalWriter() io.Writer {
-	return l.errorLogger.Writer()
+	return l.config.errorWriter
 }
 
 func (l *Logger) Fatal(format string, v ...any) {
-	l.errorLogger.Fatalf("[FATAL] "+format, v...)
+	l.output(LogLevelError, "[FATAL]", fmt.Sprintf(format, v...), nil, true)
+	os.Exit(1)
 }
 
 func (l *Logger) FatalDesc(title string, kvs ...DescKeyValue) {
-	l.errorLogger.Fatalf("[FATAL] %+v", NewDesc(title, kvs).ToString("", "\t\t"))
+	l.output(LogLevelError, "[FATAL]", title, kvs, true)
+	os.Exit(1)
 }
 
 func (l *Logger) GetPanicWriter() io.Writer {
-	return l.errorLogger.Writer()
+	return l.config.errorWriter
 }
 
 func (l *Logger) Panic(format string, v ...any) {
-	l.errorLogger.Panicf("[PANIC] "+format, v...)
+	message := fmt.Sprintf(format, v...)
+	l.output(LogLevelError, "[PANIC]", message, nil, true)
+	panic(message)
 }
 
 func (l *Logger) PanicDesc(title string, kvs ...DescKeyValue) {
-	l.errorLogger.Panicf("[PANIC] %+v", NewDesc(title, kvs).ToString("", "\t\t"))
+	l.output(LogLevelError, "[PANIC]", title, kvs, true)
+	panic(NewDesc(title, kvs).String())
 }
+
+// endregion
@@ -1,6 +1,12 @@
 package utils
 
-import "testing"
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
 
 func TestLogger1(t *testing.T) {
 	logger := NewLogger(LogLevelAll)
@@ -21,3 +27,58 @@ func TestLogger2(t *testing.T) {
 	logger.FatalDesc("critical error", KV("key", "value"))
 	logger.PanicDesc("critical error", KV("key", "value"))
 }
+
+func TestLogger3(t *testing.T) {
+	var normal, error_ bytes.Buffer
+	logger := NewLogger(LogLevelInfo).SetFormat(LogFormatJson).SetWriters(&normal, &error_)
+	logger.SetNamedLevel(LoggerNameGit, LogLevelDebug)
+	logger.DebugDesc("root debug desc", KV("key", "value"))
+	logger.Named(LoggerNameGit).DebugDesc("git debug desc", KV("key", "value"), KV("count", 1))
+	logger.Named(LoggerNameOption).DebugDesc("option debug desc", KV("key", "value"))
+	logger.ErrorDesc("error desc", KV("error", ErrN("crash error")))
+
+	lines := strings.Split(strings.TrimSpace(normal.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("unexpected normal output: %s", normal.String())
+	}
+	record := map[string]any{}
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record["msg"] != "git debug desc" || record["logger"] != LoggerNameGit || record["key"] != "value" || record["count"] != float64(1) || record["time"] == nil {
+		t.Fatalf("unexpected record: %v", record)
+	}
+	if !strings.Contains(error_.String(), `"error":"crash error`) {
+		t.Fatalf("unexpected error output: %s", error_.String())
+	}
+}
+
+func TestParseLogLevels(t *testing.T) {
+	root, levels, err := ParseLogLevels("info, git=debug,option=none")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root == nil || *root != LogLevelInfo || levels[LoggerNameGit] != LogLevelDebug || levels[LoggerNameOption] != LogLevelNone {
+		t.Fatalf("unexpected levels: %v %v", root, levels)
+	}
+	if _, _, err = ParseLogLevels("git=verbose"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestLoggerDescTime(t *testing.T) {
+	var normal, error_ bytes.Buffer
+	logger := NewLogger(LogLevelInfo).SetWriters(&normal, &error_)
+	logger.InfoDesc("info desc", KV("key", "value"))
+	if !regexp.MustCompile(`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}\.\d{6} \[INFO ] info desc`).MatchString(normal.String()) {
+		t.Fatalf("unexpected desc output: %s", normal.String())
+	}
+}
+
+func TestLogLevelString(t *testing.T) {
+	for name, level := range logLevelNames {
+		if level.String() != name {
+			t.Fatalf("unexpected level name: %s %s", level.String(), name)
+		}
+	}
+}
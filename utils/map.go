@@ -3,16 +3,52 @@ package utils
 import (
 	"maps"
 	"reflect"
+	"regexp"
+	"slices"
+	"strings"
 )
 
 type MapMergeMode string
 
 const (
-	MapMergeModeReplace MapMergeMode = "replace"
-	MapMergeModeInsert  MapMergeMode = "insert"
-	MapMergeModeRootKey string       = "$root"
+	MapMergeModeReplace   MapMergeMode = "replace"
+	MapMergeModeInsert    MapMergeMode = "insert"
+	MapMergeModePrepend   MapMergeMode = "prepend"
+	MapMergeModeAppend    MapMergeMode = "append"
+	MapMergeModeUnique    MapMergeMode = "unique"
+	MapMergeModeDelete    MapMergeMode = "delete"
+	MapMergeModeKeyPrefix string       = "key:"
+	MapMergeModeRootKey   string       = "$root"
 )
 
+var MapMergeModes = []MapMergeMode{
+	MapMergeModeReplace,
+	MapMergeModeInsert,
+	MapMergeModePrepend,
+	MapMergeModeAppend,
+	MapMergeModeUnique,
+	MapMergeModeDelete,
+}
+
+// MapMergeModeCheckRegex matches the valid merge modes, `key:<field>` merges a list of maps by the key field.
+var MapMergeModeCheckRegex = regexp.MustCompile("^(replace|insert|prepend|append|unique|delete|key:.+)$")
+
+func NewMapMergeModeByKey(field string) MapMergeMode {
+	return MapMergeMode(MapMergeModeKeyPrefix + field)
+}
+
+func (m MapMergeMode) IsValid() bool {
+	return MapMergeModeCheckRegex.MatchString(string(m))
+}
+
+// GetKeyField returns the key field of the `key:<field>` mode, or an empty string for other modes.
+func (m MapMergeMode) GetKeyField() string {
+	if field, found := strings.CutPrefix(string(m), MapMergeModeKeyPrefix); found {
+		return field
+	}
+	return ""
+}
+
 func MapAnyByStr[E any, M map[string]E](m M) map[string]any {
 	result := map[string]any{}
 	for k, v := range m {
@@ -52,6 +88,12 @@ func MapValues[K comparable, V any](m map[K]V) []V {
 	return result
 }
 
+// MapMerge merges the source map into the target map, and records the label of each merged field in the trace.
+// The merge modes are specified by the field path, such as `servers` or `db.options`:
+//   - map: the default mode merges the maps deeply, `replace` replaces the whole map;
+//   - list: the default mode is `append`, `insert` (or `prepend`) puts the source items first, `replace` replaces the whole list,
+//     `unique` appends and removes the duplicate items, `key:<field>` merges the map items with the same key field deeply;
+//   - any: `delete` removes the field from the target before merging, so a field can be removed by an empty source.
 func MapMerge(target map[string]any, source map[string]any, merge map[string]MapMergeMode, label string, trace map[string]any) (map[string]any, map[string]any, error) {
 	if merge == nil {
 		merge = map[string]MapMergeMode{}
@@ -60,6 +102,13 @@ func MapMerge(target map[string]any, source map[string]any, merge map[string]Map
 		clear(target)
 	}
 	tracer := newMapMergeTracer(label, trace)
+	if target != nil {
+		for field, mode := range merge {
+			if mode == MapMergeModeDelete {
+				mapDelete(target, tracer, field)
+			}
+		}
+	}
 	target, err := mapMerge(target, source, merge, tracer, "")
 	if err != nil {
 		return nil, nil, err
@@ -67,6 +116,23 @@ func MapMerge(target map[string]any, source map[string]any, merge map[string]Map
 	return target, tracer.Trace, nil
 }
 
+func mapDelete(target map[string]any, tracer *mapMergeTracer, field string) {
+	keys := strings.Split(field, ".")
+	for i := 0; i < len(keys)-1; i++ {
+		childTarget, ok := target[keys[i]].(map[string]any)
+		if !ok {
+			return
+		}
+		target = childTarget
+		tracer = tracer.child(keys[i])
+	}
+	key := keys[len(keys)-1]
+	if _, exist := target[key]; exist {
+		delete(target, key)
+		tracer.addDelete(key)
+	}
+}
+
 func mapMerge(target map[string]any, source map[string]any, merge map[string]MapMergeMode, tracer *mapMergeTracer, parent string) (map[string]any, error) {
 	if target == nil {
 		target = map[string]any{}
@@ -102,6 +168,7 @@ func mapMerge(target map[string]any, source map[string]any, merge map[string]Map
 							KV("specifyMode", mode),
 							KV("supportModes", []MapMergeMode{
 								MapMergeModeReplace,
+								MapMergeModeDelete,
 							}),
 						)
 					}
@@ -124,27 +191,10 @@ func mapMerge(target map[string]any, source map[string]any, merge map[string]Map
 				target[key] = sourceList
 				tracer.addNewList(key, len(sourceList))
 			} else if targetList, ok := targetValue.([]any); ok {
-				if mode, exist := merge[field]; exist {
-					if mode == MapMergeModeReplace {
-						target[key] = sourceList
-						tracer.addNewList(key, len(sourceList))
-					} else if mode == MapMergeModeInsert {
-						target[key] = append(sourceList, targetList...)
-						tracer.addInsertList(key, len(sourceList))
-					} else {
-						return nil, ErrN("merge map error",
-							Reason("merge type invalid"),
-							KV("field", field),
-							KV("specifyMode", mode),
-							KV("supportModes", []MapMergeMode{
-								MapMergeModeReplace,
-								MapMergeModeInsert,
-							}),
-						)
-					}
+				if targetResult, err := mapMergeList(targetList, sourceList, merge, tracer, key, field); err != nil {
+					return nil, err
 				} else {
-					target[key] = append(targetList, sourceList...)
-					tracer.addAppendList(key, len(sourceList))
+					target[key] = targetResult
 				}
 			} else {
 				return nil, ErrN("merge map error",
@@ -180,8 +230,118 @@ func mapMerge(target map[string]any, source map[string]any, merge map[string]Map
 	return target, nil
 }
 
+func mapMergeList(targetList []any, sourceList []any, merge map[string]MapMergeMode, tracer *mapMergeTracer, key string, field string) ([]any, error) {
+	mode, exist := merge[field]
+	if !exist {
+		mode = MapMergeModeAppend
+	}
+	switch mode {
+	case MapMergeModeReplace:
+		tracer.addNewList(key, len(sourceList))
+		return sourceList, nil
+	case MapMergeModeInsert, MapMergeModePrepend:
+		tracer.addInsertList(key, len(sourceList))
+		return append(sourceList, targetList...), nil
+	case MapMergeModeAppend:
+		tracer.addAppendList(key, len(sourceList))
+		return append(targetList, sourceList...), nil
+	case MapMergeModeUnique:
+		traceList := tracer.list(key, len(targetList))
+		var resultList []any
+		var resultTraceList []any
+		for i := 0; i < len(targetList)+len(sourceList); i++ {
+			var item, itemTrace any
+			if i < len(targetList) {
+				item, itemTrace = targetList[i], traceList[i]
+			} else {
+				item, itemTrace = sourceList[i-len(targetList)], tracer.Label
+			}
+			if !slices.ContainsFunc(resultList, func(v any) bool { return reflect.DeepEqual(v, item) }) {
+				resultList = append(resultList, item)
+				resultTraceList = append(resultTraceList, itemTrace)
+			}
+		}
+		tracer.Trace[key] = resultTraceList
+		return resultList, nil
+	default:
+		if keyField := mode.GetKeyField(); keyField != "" {
+			return mapMergeListByKey(targetList, sourceList, merge, tracer, key, field, keyField)
+		}
+		return nil, ErrN("merge map error",
+			Reason("merge mode invalid"),
+			KV("field", field),
+			KV("specifyMode", mode),
+			KV("supportModes", append(slices.Clone(MapMergeModes), NewMapMergeModeByKey("<field>"))),
+		)
+	}
+}
+
+func mapMergeListByKey(targetList []any, sourceList []any, merge map[string]MapMergeMode, tracer *mapMergeTracer, key string, field string, keyField string) ([]any, error) {
+	// the items may be shared with the merged sources, so the list and the matched items are cloned before merging
+	targetList = slices.Clone(targetList)
+	traceList := tracer.list(key, len(targetList))
+	for i := 0; i < len(sourceList); i++ {
+		sourceMap, ok := sourceList[i].(map[string]any)
+		if !ok || sourceMap[keyField] == nil {
+			return nil, ErrN("merge map error",
+				Reason("list item key not found"),
+				KV("field", field),
+				KV("index", i),
+				KV("keyField", keyField),
+			)
+		}
+		index := slices.IndexFunc(targetList, func(v any) bool {
+			targetMap, ok := v.(map[string]any)
+			return ok && reflect.DeepEqual(targetMap[keyField], sourceMap[keyField])
+		})
+		if index < 0 {
+			targetList = append(targetList, sourceMap)
+			traceList = append(traceList, tracer.Label)
+			continue
+		}
+		// the trace of the matched item is expanded to a map, so the fields merged from different sources can be told apart
+		itemTrace, ok := traceList[index].(map[string]any)
+		if !ok {
+			itemTrace = newMapMergeTraceValue(targetList[index], traceList[index]).(map[string]any)
+		}
+		targetMap := mapDeepClone(targetList[index]).(map[string]any)
+		if _, err := mapMerge(targetMap, sourceMap, merge, newMapMergeTracer(tracer.Label, itemTrace), field); err != nil {
+			return nil, err
+		}
+		targetList[index] = targetMap
+		traceList[index] = itemTrace
+	}
+	tracer.Trace[key] = traceList
+	return targetList, nil
+}
+
+// mapDeepClone clones the maps and lists in the value, the other values are shared.
+func mapDeepClone(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[key] = mapDeepClone(item)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i := 0; i < len(v); i++ {
+			result[i] = mapDeepClone(v[i])
+		}
+		return result
+	default:
+		return value
+	}
+}
+
 // region mapMergeTracer
 
+const (
+	mapMergeTraceDeletePrefix = "delete:"
+	mapMergeTraceSeparator    = " > "
+)
+
 type mapMergeTracer struct {
 	Label string
 	Trace map[string]any
@@ -212,10 +372,27 @@ func (t *mapMergeTracer) child(key string) *mapMergeTracer {
 	return newMapMergeTracer(t.Label, childTrace)
 }
 
+// add records the label of the key, if the key was deleted, the delete trace is kept before the label, such as `delete:a > b`.
 func (t *mapMergeTracer) add(key string) {
+	if trace, ok := t.Trace[key].(string); ok && strings.HasPrefix(trace, mapMergeTraceDeletePrefix) {
+		t.Trace[key] = strings.SplitN(trace, mapMergeTraceSeparator, 2)[0] + mapMergeTraceSeparator + t.Label
+		return
+	}
 	t.Trace[key] = t.Label
 }
 
+func (t *mapMergeTracer) addDelete(key string) {
+	t.Trace[key] = mapMergeTraceDeletePrefix + t.Label
+}
+
+// list returns the trace list of the key, which is padded or truncated to the length of the value list.
+func (t *mapMergeTracer) list(key string, n int) []any {
+	list, _ := t.Trace[key].([]any)
+	result := make([]any, n)
+	copy(result, list)
+	return result
+}
+
 func (t *mapMergeTracer) addNewList(key string, len int) {
 	var list []any
 	for i := 0; i < len; i++ {
@@ -248,4 +425,23 @@ func (t *mapMergeTracer) addAppendList(key string, len int) {
 	}
 }
 
+// newMapMergeTraceValue makes the trace of the value, the maps are expanded and the other values are traced by the label.
+func newMapMergeTraceValue(value any, label any) any {
+	if valueMap, ok := value.(map[string]any); ok {
+		trace := map[string]any{}
+		for k, v := range valueMap {
+			trace[k] = newMapMergeTraceValue(v, label)
+		}
+		return trace
+	}
+	if valueList, ok := value.([]any); ok {
+		trace := make([]any, len(valueList))
+		for i := 0; i < len(valueList); i++ {
+			trace[i] = label
+		}
+		return trace
+	}
+	return label
+}
+
 // endregion
@@ -79,3 +79,75 @@ func TestMergeMap1(t *testing.T) {
 		t.Log(err)
 	}
 }
+
+func TestMergeMap2(t *testing.T) {
+	map1 := map[string]any{
+		"servers": []any{
+			map[string]any{"name": "a", "port": 1, "tags": []any{"x"}},
+			map[string]any{"name": "b", "port": 2},
+		},
+		"list": []any{"v1", "v2"},
+		"db": map[string]any{
+			"host":     "localhost",
+			"password": "secret",
+		},
+	}
+	map2 := map[string]any{
+		"servers": []any{
+			map[string]any{"name": "b", "port": 3},
+			map[string]any{"name": "c", "port": 4},
+		},
+		"list": []any{"v2", "v3"},
+	}
+
+	result, trace, err := MapMerge(nil, map1, nil, "map1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = MapMerge(result, map2, map[string]MapMergeMode{
+		"servers":     NewMapMergeModeByKey("name"),
+		"list":        MapMergeModeUnique,
+		"db.password": MapMergeModeDelete,
+	}, "map2", trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	servers := result["servers"].([]any)
+	if len(servers) != 3 || servers[1].(map[string]any)["port"] != 3 {
+		t.Fatalf("servers = %v", servers)
+	}
+	if serverTrace := trace["servers"].([]any); serverTrace[0] != "map1" || serverTrace[1].(map[string]any)["port"] != "map2" || serverTrace[1].(map[string]any)["name"] != "map2" || serverTrace[2] != "map2" {
+		t.Fatalf("servers trace = %v", serverTrace)
+	}
+	if list := result["list"].([]any); len(list) != 3 || list[2] != "v3" {
+		t.Fatalf("list = %v", list)
+	}
+	if listTrace := trace["list"].([]any); listTrace[1] != "map1" || listTrace[2] != "map2" {
+		t.Fatalf("list trace = %v", listTrace)
+	}
+	if _, exist := result["db"].(map[string]any)["password"]; exist {
+		t.Fatalf("db = %v", result["db"])
+	}
+	if trace["db"].(map[string]any)["password"] != "delete:map2" {
+		t.Fatalf("db trace = %v", trace["db"])
+	}
+	if port := map1["servers"].([]any)[1].(map[string]any)["port"]; port != 2 {
+		t.Fatalf("source item should not be changed by the merge, port = %v", port)
+	}
+
+	_, _, err = MapMerge(result, map[string]any{"db": map[string]any{"password": "other"}}, nil, "map4", trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trace["db"].(map[string]any)["password"] != "delete:map2 > map4" {
+		t.Fatalf("db trace = %v", trace["db"])
+	}
+
+	_, _, err = MapMerge(result, map2, map[string]MapMergeMode{
+		"servers": NewMapMergeModeByKey("id"),
+	}, "map3", trace)
+	if err == nil {
+		t.Fatal("expected key not found error")
+	}
+	t.Log(err)
+}
@@ -1,7 +1,10 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 )
 
 // region Model
@@ -20,6 +23,7 @@ type ModelHelper struct {
 	Source    string
 	Field     string
 	Variables map[string]any
+	Positions *SerializationPositions
 }
 
 func NewModelHelper(logger *Logger, title, source string) *ModelHelper {
@@ -44,6 +48,7 @@ func (h *ModelHelper) Child(field string) *ModelHelper {
 		Source:    h.Source,
 		Field:     newField,
 		Variables: h.Variables,
+		Positions: h.Positions,
 	}
 }
 
@@ -58,6 +63,18 @@ func (h *ModelHelper) Item(index int) *ModelHelper {
 		Source:    h.Source,
 		Field:     fmt.Sprintf("%s[%d]", h.Field, index),
 		Variables: h.Variables,
+		Positions: h.Positions,
+	}
+}
+
+// ChildSource returns a helper for another source file, such as an included file, the variables are shared.
+func (h *ModelHelper) ChildSource(source string, positions *SerializationPositions) *ModelHelper {
+	return &ModelHelper{
+		Logger:    h.Logger,
+		Title:     h.Title,
+		Source:    source,
+		Variables: h.Variables,
+		Positions: positions,
 	}
 }
 
@@ -66,6 +83,12 @@ func (h *ModelHelper) AddVariable(key string, value any) *ModelHelper {
 	return h
 }
 
+// SetPositions sets the field positions of the source file, errors and warnings will include the file:line:column of the field and a snippet of the source lines.
+func (h *ModelHelper) SetPositions(positions *SerializationPositions) *ModelHelper {
+	h.Positions = positions
+	return h
+}
+
 func (h *ModelHelper) GetStringVariable(key string) string {
 	if value, exist := h.Variables[key]; exist {
 		return value.(string)
@@ -75,75 +98,105 @@ func (h *ModelHelper) GetStringVariable(key string) string {
 	}
 }
 
+// GetFileSystemVariable gets the file system variable, the OSFileSystem is used if the variable is not added.
+func (h *ModelHelper) GetFileSystemVariable(key string) FileSystem {
+	if value, exist := h.Variables[key]; exist {
+		return value.(FileSystem)
+	}
+	return OSFileSystem
+}
+
+func (h *ModelHelper) GetPosition() *SerializationPosition {
+	if h.Positions == nil {
+		return nil
+	}
+	return h.Positions.Get(h.Field)
+}
+
+func (h *ModelHelper) getPositionKeyValues(snippet bool) KVS {
+	position := h.GetPosition()
+	if position == nil {
+		return nil
+	}
+	kvs := KVS{KV("position", ErrorPosition(position.String()))}
+	if snippet {
+		if lines := h.Positions.GetSnippet(position, serializationSnippetAroundLines); len(lines) > 0 {
+			kvs = append(kvs, KV("snippet", lines))
+		}
+	}
+	return kvs
+}
+
 func (h *ModelHelper) WarnValueUnsound(value any) {
-	h.Logger.WarnDesc(fmt.Sprintf("%s warn", h.Title),
-		Reason("value unsound"),
-		KV("source", h.Source),
-		KV("field", h.Field),
-		KV("value", value),
-	)
+	h.Warn("value unsound", KV("value", value))
 }
 
 func (h *ModelHelper) WarnValueUseless(value any) {
-	h.Logger.WarnDesc(fmt.Sprintf("%s warn", h.Title),
-		Reason("value useless"),
-		KV("source", h.Source),
-		KV("field", h.Field),
-		KV("value", value),
-	)
+	h.Warn("value useless", KV("value", value))
 }
 
 func (h *ModelHelper) Warn(reason string, extra ...DescKeyValue) {
 	kvs := KVS{
 		Reason(reason),
-		KV("source", h.Source),
-		KV("field", h.Field),
+		KV("source", ErrorSource(h.Source)),
+		KV("field", ErrorField(h.Field)),
 	}
-	h.Logger.WarnDesc(fmt.Sprintf("%s warn", h.Title), append(kvs, extra...)...)
+	kvs = append(kvs, extra...)
+	h.Logger.WarnDesc(fmt.Sprintf("%s warn", h.Title), append(kvs, h.getPositionKeyValues(false)...)...)
 }
 
 func (h *ModelHelper) NewError(reason string, extra ...DescKeyValue) error {
 	kvs := KVS{
+		Code(ErrorCodeSettingInvalid),
 		Reason(reason),
-		KV("source", h.Source),
-		KV("field", h.Field),
+		KV("source", ErrorSource(h.Source)),
+		KV("field", ErrorField(h.Field)),
 	}
-	return NewError(1, fmt.Sprintf("%s error", h.Title), append(kvs, extra...)...)
+	kvs = append(kvs, extra...)
+	return NewError(1, fmt.Sprintf("%s error", h.Title), append(kvs, h.getPositionKeyValues(true)...)...)
 }
 
 func (h *ModelHelper) WrapError(err error, reason string, extra ...DescKeyValue) error {
 	kvs := KVS{
+		Code(ErrorCodeSettingInvalid),
 		Reason(reason),
-		KV("source", h.Source),
-		KV("field", h.Field),
+		KV("source", ErrorSource(h.Source)),
+		KV("field", ErrorField(h.Field)),
 	}
-	return WrapError(1, err, fmt.Sprintf("%s error", h.Title), append(kvs, extra...)...)
+	kvs = append(kvs, extra...)
+	return WrapError(1, err, fmt.Sprintf("%s error", h.Title), append(kvs, h.getPositionKeyValues(true)...)...)
 }
 
 func (h *ModelHelper) NewValueEmptyError() error {
-	return NewError(1, fmt.Sprintf("%s error", h.Title),
+	kvs := KVS{
+		Code(ErrorCodeSettingValueEmpty),
 		Reason("value empty"),
-		KV("source", h.Source),
-		KV("field", h.Field),
-	)
+		KV("source", ErrorSource(h.Source)),
+		KV("field", ErrorField(h.Field)),
+	}
+	return NewError(1, fmt.Sprintf("%s error", h.Title), append(kvs, h.getPositionKeyValues(true)...)...)
 }
 
 func (h *ModelHelper) NewValueInvalidError(value any) error {
-	return NewError(1, fmt.Sprintf("%s error", h.Title),
+	kvs := KVS{
+		Code(ErrorCodeSettingValueInvalid),
 		Reason("value invalid"),
-		KV("source", h.Source),
-		KV("field", h.Field),
+		KV("source", ErrorSource(h.Source)),
+		KV("field", ErrorField(h.Field)),
 		KV("value", value),
-	)
+	}
+	return NewError(1, fmt.Sprintf("%s error", h.Title), append(kvs, h.getPositionKeyValues(true)...)...)
 }
 
 func (h *ModelHelper) WrapValueInvalidError(err error, value any) error {
-	return WrapError(1, err, fmt.Sprintf("%s error", h.Title),
+	kvs := KVS{
+		Code(ErrorCodeSettingValueInvalid),
 		Reason("value invalid"),
-		KV("source", h.Source),
-		KV("field", h.Field),
+		KV("source", ErrorSource(h.Source)),
+		KV("field", ErrorField(h.Field)),
 		KV("value", value),
-	)
+	}
+	return WrapError(1, err, fmt.Sprintf("%s error", h.Title), append(kvs, h.getPositionKeyValues(true)...)...)
 }
 
 func (h *ModelHelper) CheckStringItemEmpty(field string, items []string) error {
@@ -167,4 +220,44 @@ func ConvertChildModels[R any, M Model[R]](helper *ModelHelper, field string, mo
 	return result, nil
 }
 
+// DecodeModelMap decodes the map into the fields of the model by the json tags, it is used by the list items that also
+// accept a shorthand scalar form, so the object form is decoded into a typed model. The unknown keys and the values of
+// mismatched types are rejected, the values of `any` fields are kept as is.
+func DecodeModelMap(helper *ModelHelper, value map[string]any, model any) error {
+	target := reflect.ValueOf(model).Elem()
+	fields := map[string]reflect.Value{}
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = target.Field(i)
+	}
+	for k, v := range value {
+		field, exist := fields[k]
+		if !exist {
+			return helper.Child(k).NewError("unknown field")
+		}
+		if v == nil {
+			continue
+		}
+		if field.Kind() == reflect.Interface {
+			field.Set(reflect.ValueOf(v))
+			continue
+		}
+		data, err := json.Marshal(v)
+		if err == nil {
+			err = json.Unmarshal(data, field.Addr().Interface())
+		}
+		if err != nil {
+			return helper.Child(k).WrapValueInvalidError(err, v)
+		}
+	}
+	return nil
+}
+
 // endregion
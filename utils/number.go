@@ -1,6 +1,9 @@
 package utils
 
-import "strconv"
+import (
+	"strconv"
+	"strings"
+)
 
 func ParseInt32(str string) (int, error) {
 	value, err := strconv.Atoi(str)
@@ -33,3 +36,36 @@ func ParseFloat64(str string) (float64, error) {
 	}
 	return value, nil
 }
+
+var byteSizeUnits = []struct {
+	suffix string
+	factor int64
+}{
+	{"KB", 1 << 10},
+	{"MB", 1 << 20},
+	{"GB", 1 << 30},
+	{"TB", 1 << 40},
+	{"K", 1 << 10},
+	{"M", 1 << 20},
+	{"G", 1 << 30},
+	{"T", 1 << 40},
+	{"B", 1},
+}
+
+func ParseByteSize(str string) (int64, error) {
+	text := strings.ToUpper(strings.TrimSpace(str))
+	factor := int64(1)
+	for i := 0; i < len(byteSizeUnits); i++ {
+		unit := byteSizeUnits[i]
+		if number, found := strings.CutSuffix(text, unit.suffix); found {
+			text = strings.TrimSpace(number)
+			factor = unit.factor
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value < 0 {
+		return 0, ErrN("parse byte size error", KV("str", str))
+	}
+	return int64(value * float64(factor)), nil
+}
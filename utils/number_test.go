@@ -27,3 +27,25 @@ func TestParseDecimal(t *testing.T) {
 	}
 	t.Log(decimal)
 }
+
+func TestParseByteSize(t *testing.T) {
+	cases := map[string]int64{
+		"1024":  1024,
+		"10B":   10,
+		"1k":    1024,
+		"1.5MB": 1536 * 1024,
+		"2 GB":  2 << 30,
+	}
+	for str, expected := range cases {
+		size, err := ParseByteSize(str)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size != expected {
+			t.Fatalf("%s: expected %d, got %d", str, expected, size)
+		}
+	}
+	if _, err := ParseByteSize("abc"); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,769 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// region OciReference
+
+// OciReference is the reference of an artifact in the OCI registry, such as `ghcr.io/org/repo:tag` and `ghcr.io/org/repo@sha256:<hex>`,
+// the digest takes precedence over the tag if both are set.
+type OciReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+const ociRegistryDockerHub = "docker.io"
+
+var ociRepositoryCheckRegex = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+
+var ociTagCheckRegex = regexp.MustCompile(`^\w[\w.-]{0,127}$`)
+
+var ociDigestCheckRegex = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+// ParseOciReference parses the reference, the registry is `docker.io` if the first component is not a host,
+// and the tag is `latest` if neither the tag nor the digest is set.
+func ParseOciReference(rawRef string) (*OciReference, error) {
+	name, digest, _ := strings.Cut(rawRef, "@")
+	if digest != "" && !ociDigestCheckRegex.MatchString(digest) {
+		return nil, ErrN("parse oci reference error",
+			Reason("digest is invalid"),
+			KV("rawRef", rawRef),
+			KV("digest", digest),
+		)
+	}
+	registry, repository, found := strings.Cut(name, "/")
+	if !found || !(strings.ContainsAny(registry, ".:") || registry == "localhost") {
+		registry, repository = ociRegistryDockerHub, name
+		if !strings.Contains(repository, "/") {
+			repository = "library/" + repository
+		}
+	}
+	tag := ""
+	if index := strings.LastIndex(repository, ":"); index >= 0 {
+		tag = repository[index+1:]
+		repository = repository[:index]
+		if !ociTagCheckRegex.MatchString(tag) {
+			return nil, ErrN("parse oci reference error",
+				Reason("tag is invalid"),
+				KV("rawRef", rawRef),
+				KV("tag", tag),
+			)
+		}
+	}
+	if registry == "" || !ociRepositoryCheckRegex.MatchString(repository) {
+		return nil, ErrN("parse oci reference error",
+			Reason("repository is invalid"),
+			KV("rawRef", rawRef),
+			KV("repository", repository),
+		)
+	}
+	if tag == "" && digest == "" {
+		tag = "latest"
+	}
+	ref := &OciReference{
+		Registry:   registry,
+		Repository: repository,
+		Tag:        tag,
+		Digest:     digest,
+	}
+	return ref, nil
+}
+
+func (r *OciReference) String() string {
+	str := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		str += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		str += "@" + r.Digest
+	}
+	return str
+}
+
+// GetManifestReference gets the digest if it is set, otherwise the tag.
+func (r *OciReference) GetManifestReference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// WithDigest returns a copy of the reference pinned to the digest.
+func (r *OciReference) WithDigest(digest string) *OciReference {
+	ref := *r
+	ref.Digest = digest
+	return &ref
+}
+
+// endregion
+
+// region OciManifest
+
+const (
+	OciMediaTypeImageManifest = "application/vnd.oci.image.manifest.v1+json"
+	OciMediaTypeEmpty         = "application/vnd.oci.empty.v1+json"
+	OciAnnotationTitle        = "org.opencontainers.image.title"
+)
+
+// OciEmptyData is the data of the empty descriptor, it is used as the config of the artifacts without config.
+var OciEmptyData = []byte("{}")
+
+const ociManifestSizeLimit = 4 * 1024 * 1024
+
+type OciDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+func NewOciDescriptor(mediaType string, data []byte) *OciDescriptor {
+	return &OciDescriptor{
+		MediaType: mediaType,
+		Digest:    GetOciDigest(data),
+		Size:      int64(len(data)),
+	}
+}
+
+type OciManifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        *OciDescriptor    `json:"config"`
+	Layers        []*OciDescriptor  `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+func NewOciManifest(artifactType string, config *OciDescriptor, layers []*OciDescriptor, annotations map[string]string) *OciManifest {
+	return &OciManifest{
+		SchemaVersion: 2,
+		MediaType:     OciMediaTypeImageManifest,
+		ArtifactType:  artifactType,
+		Config:        config,
+		Layers:        layers,
+		Annotations:   annotations,
+	}
+}
+
+func GetOciDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// endregion
+
+// region OciClient
+
+// OciClient is the client of the OCI distribution api, the registries on the loopback hosts are accessed by http, the others by https.
+// The basic and bearer token authorizations are supported, and the authorizations are cached by repository.
+type OciClient struct {
+	httpClient     *http.Client
+	credentials    OciCredentialProvider
+	authorizations map[string]string
+	mutex          sync.Mutex
+}
+
+// ociHttpTimeoutDefault limits the time of a request of the default http client, including reading the blob.
+const ociHttpTimeoutDefault = 10 * time.Minute
+
+// NewOciClient creates the client, the default http client with the timeout is used if the http client is nil.
+func NewOciClient(httpClient *http.Client, credentials OciCredentialProvider) *OciClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: ociHttpTimeoutDefault}
+	}
+	return &OciClient{
+		httpClient:     httpClient,
+		credentials:    credentials,
+		authorizations: map[string]string{},
+	}
+}
+
+// ResolveManifest resolves the digest of the manifest, the digest of the reference is returned as is if it is set.
+func (c *OciClient) ResolveManifest(ref *OciReference) (string, error) {
+	if ref.Digest != "" {
+		return ref.Digest, nil
+	}
+	response, err := c.do(ref, false, http.MethodHead, c.getManifestUrl(ref, ref.GetManifestReference()), ociManifestHeader(""), nil)
+	if err != nil {
+		return "", ErrW(err, "resolve oci manifest error",
+			KV("ref", ref),
+		)
+	}
+	_ = response.Body.Close()
+	if err = checkOciResponse(response, http.StatusOK); err != nil {
+		return "", ErrW(err, "resolve oci manifest error",
+			KV("ref", ref),
+		)
+	}
+	if digest := response.Header.Get("Docker-Content-Digest"); ociDigestCheckRegex.MatchString(digest) {
+		return digest, nil
+	}
+	_, digest, err := c.PullManifest(ref)
+	return digest, err
+}
+
+// PullManifest pulls the manifest and gets its digest, the digest is verified if the reference is pinned.
+func (c *OciClient) PullManifest(ref *OciReference) (*OciManifest, string, error) {
+	response, err := c.do(ref, false, http.MethodGet, c.getManifestUrl(ref, ref.GetManifestReference()), ociManifestHeader(""), nil)
+	if err != nil {
+		return nil, "", ErrW(err, "pull oci manifest error",
+			KV("ref", ref),
+		)
+	}
+	defer response.Body.Close()
+	if err = checkOciResponse(response, http.StatusOK); err != nil {
+		return nil, "", ErrW(err, "pull oci manifest error",
+			KV("ref", ref),
+		)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, ociManifestSizeLimit))
+	if err != nil {
+		return nil, "", ErrW(err, "pull oci manifest error",
+			Reason("read body error"),
+			KV("ref", ref),
+		)
+	}
+	digest := GetOciDigest(data)
+	if ref.Digest != "" && ref.Digest != digest {
+		return nil, "", ErrN("pull oci manifest error",
+			Reason("digest mismatch"),
+			KV("ref", ref),
+			KV("actual", digest),
+		)
+	}
+	manifest := &OciManifest{}
+	if err = json.Unmarshal(data, manifest); err != nil {
+		return nil, "", ErrW(err, "pull oci manifest error",
+			Reason("unmarshal manifest error"),
+			KV("ref", ref),
+		)
+	}
+	if manifest.MediaType != OciMediaTypeImageManifest || manifest.Config == nil {
+		return nil, "", ErrN("pull oci manifest error",
+			Reason("manifest not supported"),
+			KV("ref", ref),
+			KV("mediaType", manifest.MediaType),
+		)
+	}
+	return manifest, digest, nil
+}
+
+// PullBlob pulls the blob to the writer, the digest and size of the content are verified.
+func (c *OciClient) PullBlob(ref *OciReference, descriptor *OciDescriptor, writer io.Writer) error {
+	response, err := c.do(ref, false, http.MethodGet, c.getBlobUrl(ref, descriptor.Digest), nil, nil)
+	if err != nil {
+		return ErrW(err, "pull oci blob error",
+			KV("ref", ref),
+			KV("digest", descriptor.Digest),
+		)
+	}
+	defer response.Body.Close()
+	if err = checkOciResponse(response, http.StatusOK); err != nil {
+		return ErrW(err, "pull oci blob error",
+			KV("ref", ref),
+			KV("digest", descriptor.Digest),
+		)
+	}
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(writer, hash), response.Body)
+	if err != nil {
+		return ErrW(err, "pull oci blob error",
+			Reason("io copy error"),
+			KV("ref", ref),
+			KV("digest", descriptor.Digest),
+		)
+	}
+	if digest := "sha256:" + hex.EncodeToString(hash.Sum(nil)); digest != descriptor.Digest || size != descriptor.Size {
+		return ErrN("pull oci blob error",
+			Reason("digest mismatch"),
+			KV("ref", ref),
+			KV("digest", descriptor.Digest),
+			KV("actual", digest),
+			KV("size", descriptor.Size),
+			KV("actualSize", size),
+		)
+	}
+	return nil
+}
+
+// PushBlob pushes the blob by a monolithic upload, the blob is skipped if it exists.
+func (c *OciClient) PushBlob(ref *OciReference, descriptor *OciDescriptor, data []byte) error {
+	response, err := c.do(ref, true, http.MethodHead, c.getBlobUrl(ref, descriptor.Digest), nil, nil)
+	if err != nil {
+		return ErrW(err, "push oci blob error",
+			KV("ref", ref),
+			KV("digest", descriptor.Digest),
+		)
+	}
+	_ = response.Body.Close()
+	if response.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	response, err = c.do(ref, true, http.MethodPost, c.getRegistryUrl(ref)+"/v2/"+ref.Repository+"/blobs/uploads/", nil, nil)
+	if err != nil {
+		return ErrW(err, "push oci blob error",
+			KV("ref", ref),
+			KV("digest", descriptor.Digest),
+		)
+	}
+	_ = response.Body.Close()
+	if err = checkOciResponse(response, http.StatusAccepted); err != nil {
+		return ErrW(err, "push oci blob error",
+			Reason("start upload error"),
+			KV("ref", ref),
+			KV("digest", descriptor.Digest),
+		)
+	}
+	location, err := response.Request.URL.Parse(response.Header.Get("Location"))
+	if err != nil {
+		return ErrW(err, "push oci blob error",
+			Reason("parse upload location error"),
+			KV("ref", ref),
+			KV("location", response.Header.Get("Location")),
+		)
+	}
+	query := location.Query()
+	query.Set("digest", descriptor.Digest)
+	location.RawQuery = query.Encode()
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/octet-stream")
+	response, err = c.do(ref, true, http.MethodPut, location.String(), header, data)
+	if err != nil {
+		return ErrW(err, "push oci blob error",
+			KV("ref", ref),
+			KV("digest", descriptor.Digest),
+		)
+	}
+	_ = response.Body.Close()
+	if err = checkOciResponse(response, http.StatusCreated); err != nil {
+		return ErrW(err, "push oci blob error",
+			Reason("finish upload error"),
+			KV("ref", ref),
+			KV("digest", descriptor.Digest),
+		)
+	}
+	return nil
+}
+
+// PushManifest pushes the manifest by the tag of the reference, or by its digest if the tag is not set, and gets the digest.
+func (c *OciClient) PushManifest(ref *OciReference, manifest *OciManifest) (string, error) {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return "", ErrW(err, "push oci manifest error",
+			Reason("marshal manifest error"),
+			KV("ref", ref),
+		)
+	}
+	digest := GetOciDigest(data)
+	reference := ref.Tag
+	if reference == "" {
+		reference = digest
+	}
+	response, err := c.do(ref, true, http.MethodPut, c.getManifestUrl(ref, reference), ociManifestHeader(manifest.MediaType), data)
+	if err != nil {
+		return "", ErrW(err, "push oci manifest error",
+			KV("ref", ref),
+		)
+	}
+	_ = response.Body.Close()
+	if err = checkOciResponse(response, http.StatusCreated); err != nil {
+		return "", ErrW(err, "push oci manifest error",
+			KV("ref", ref),
+		)
+	}
+	return digest, nil
+}
+
+func (c *OciClient) getRegistryUrl(ref *OciReference) string {
+	host := ref.Registry
+	if host == ociRegistryDockerHub {
+		host = "registry-1.docker.io"
+	}
+	hostname := host
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		hostname = name
+	}
+	hostname = strings.Trim(hostname, "[]")
+	if ip := net.ParseIP(hostname); hostname == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return "http://" + host
+	}
+	return "https://" + host
+}
+
+func (c *OciClient) getManifestUrl(ref *OciReference, reference string) string {
+	return c.getRegistryUrl(ref) + "/v2/" + ref.Repository + "/manifests/" + reference
+}
+
+func (c *OciClient) getBlobUrl(ref *OciReference, digest string) string {
+	return c.getRegistryUrl(ref) + "/v2/" + ref.Repository + "/blobs/" + digest
+}
+
+// do sends the request, and retries it once with a new authorization if the registry responds with 401.
+func (c *OciClient) do(ref *OciReference, push bool, method string, rawUrl string, header http.Header, body []byte) (*http.Response, error) {
+	key := ref.Registry + "/" + ref.Repository + ValT(push, ":push", ":pull")
+	for i := 0; ; i++ {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
+		}
+		request, err := http.NewRequest(method, rawUrl, bodyReader)
+		if err != nil {
+			return nil, ErrW(err, "oci request error",
+				Reason("new request error"),
+				KV("method", method),
+				KV("url", rawUrl),
+			)
+		}
+		for k, v := range header {
+			request.Header[k] = v
+		}
+		c.mutex.Lock()
+		authorization := c.authorizations[key]
+		c.mutex.Unlock()
+		if authorization != "" {
+			request.Header.Set("Authorization", authorization)
+		}
+		response, err := c.httpClient.Do(request)
+		if err != nil {
+			return nil, ErrW(err, "oci request error",
+				Reason("send request error"),
+				KV("method", method),
+				KV("url", rawUrl),
+			)
+		}
+		if response.StatusCode != http.StatusUnauthorized || i > 0 {
+			return response, nil
+		}
+		_ = response.Body.Close()
+		if authorization, err = c.authorize(ref, push, response.Header.Get("WWW-Authenticate")); err != nil {
+			return nil, ErrW(err, "oci request error",
+				Reason("authorize error"),
+				KV("method", method),
+				KV("url", rawUrl),
+			)
+		}
+		c.mutex.Lock()
+		c.authorizations[key] = authorization
+		c.mutex.Unlock()
+	}
+}
+
+func (c *OciClient) authorize(ref *OciReference, push bool, challenge string) (string, error) {
+	var credential *OciCredential
+	if c.credentials != nil {
+		var err error
+		if credential, err = c.credentials(ref.Registry); err != nil {
+			return "", ErrW(err, "oci authorize error",
+				Reason("get credential error"),
+				KV("registry", ref.Registry),
+			)
+		}
+	}
+	scheme, params := parseOciChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if credential == nil || credential.Username == "" {
+			return "", ErrN("oci authorize error",
+				Reason("credential not found"),
+				KV("registry", ref.Registry),
+			)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(credential.Username+":"+credential.Password)), nil
+	case "bearer":
+		scope := "repository:" + ref.Repository + ValT(push, ":pull,push", ":pull")
+		token, err := c.getToken(params["realm"], params["service"], scope, credential)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	default:
+		return "", ErrN("oci authorize error",
+			Reason("challenge not supported"),
+			KV("registry", ref.Registry),
+			KV("challenge", challenge),
+		)
+	}
+}
+
+// getToken gets the bearer token from the realm, by the refresh token grant if the credential has an identity token,
+// otherwise anonymously or by the basic authorization.
+func (c *OciClient) getToken(realm, service, scope string, credential *OciCredential) (string, error) {
+	realmUrl, err := url.Parse(realm)
+	if err != nil || realm == "" {
+		return "", ErrN("get oci token error",
+			Reason("realm is invalid"),
+			KV("realm", realm),
+		)
+	}
+	var request *http.Request
+	if credential != nil && credential.IdentityToken != "" {
+		form := url.Values{}
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", credential.IdentityToken)
+		form.Set("service", service)
+		form.Set("scope", scope)
+		form.Set("client_id", "dsh")
+		request, err = http.NewRequest(http.MethodPost, realm, strings.NewReader(form.Encode()))
+		if err == nil {
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else {
+		query := realmUrl.Query()
+		if service != "" {
+			query.Set("service", service)
+		}
+		query.Set("scope", scope)
+		realmUrl.RawQuery = query.Encode()
+		request, err = http.NewRequest(http.MethodGet, realmUrl.String(), nil)
+		if err == nil && credential != nil && credential.Username != "" {
+			request.SetBasicAuth(credential.Username, credential.Password)
+		}
+	}
+	if err != nil {
+		return "", ErrW(err, "get oci token error",
+			Reason("new request error"),
+			KV("realm", realm),
+		)
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return "", ErrW(err, "get oci token error",
+			Reason("send request error"),
+			KV("realm", realm),
+		)
+	}
+	defer response.Body.Close()
+	if err = checkOciResponse(response, http.StatusOK); err != nil {
+		return "", ErrW(err, "get oci token error",
+			KV("realm", realm),
+		)
+	}
+	result := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err = json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return "", ErrW(err, "get oci token error",
+			Reason("decode body error"),
+			KV("realm", realm),
+		)
+	}
+	if result.Token != "" {
+		return result.Token, nil
+	} else if result.AccessToken != "" {
+		return result.AccessToken, nil
+	}
+	return "", ErrN("get oci token error",
+		Reason("token is empty"),
+		KV("realm", realm),
+	)
+}
+
+func ociManifestHeader(contentType string) http.Header {
+	header := http.Header{}
+	header.Set("Accept", OciMediaTypeImageManifest)
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	return header
+}
+
+// parseOciChallenge parses the WWW-Authenticate header such as `Bearer realm="<url>",service="<service>",scope="<scope>"`.
+func parseOciChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimLeft(rest, ", ") {
+		key, value, found := strings.Cut(rest, "=")
+		if !found {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key] = value[1 : end+1]
+			rest = value[end+2:]
+		} else {
+			value, rest, _ = strings.Cut(value, ",")
+			params[key] = strings.TrimSpace(value)
+		}
+	}
+	return scheme, params
+}
+
+func checkOciResponse(response *http.Response, status int) error {
+	if response.StatusCode == status {
+		return nil
+	}
+	body := ""
+	if response.Request == nil || response.Request.Method != http.MethodHead {
+		data, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		body = string(data)
+	}
+	return ErrN("oci response error",
+		Reason("status error"),
+		KV("status", response.Status),
+		KV("body", body),
+	)
+}
+
+// endregion
+
+// region OciCredential
+
+type OciCredential struct {
+	Username      string
+	Password      string
+	IdentityToken string
+}
+
+// OciCredentialProvider gets the credential of the registry, the credential is nil if not found.
+type OciCredentialProvider func(registry string) (*OciCredential, error)
+
+type dockerConfig struct {
+	Auths       map[string]*dockerConfigAuth `json:"auths"`
+	CredsStore  string                       `json:"credsStore"`
+	CredHelpers map[string]string            `json:"credHelpers"`
+}
+
+type dockerConfigAuth struct {
+	Auth          string `json:"auth"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	IdentityToken string `json:"identitytoken"`
+}
+
+// NewDockerCredentialProvider gets the credentials from the docker config file, the default file is used if the file is empty.
+// The credential helpers such as `docker-credential-desktop` are executed if they are configured by `credHelpers` or `credsStore`,
+// otherwise the credentials are read from `auths`.
+func NewDockerCredentialProvider(file string) OciCredentialProvider {
+	return func(registry string) (*OciCredential, error) {
+		configFile := file
+		if configFile == "" {
+			configFile = GetDockerConfigFile()
+		}
+		if configFile == "" || !IsFileExists(configFile) {
+			return nil, nil
+		}
+		config := &dockerConfig{}
+		if err := ReadJsonFile(configFile, config); err != nil {
+			return nil, ErrW(err, "get docker credential error",
+				Reason("read config file error"),
+				KV("file", configFile),
+			)
+		}
+		helper := config.CredHelpers[registry]
+		if helper == "" {
+			helper = config.CredsStore
+		}
+		if helper != "" {
+			serverAddress := registry
+			if registry == ociRegistryDockerHub {
+				serverAddress = "https://index.docker.io/v1/"
+			}
+			return getDockerHelperCredential(helper, serverAddress)
+		}
+		for key, auth := range config.Auths {
+			if normalizeDockerConfigRegistry(key) != registry || auth == nil {
+				continue
+			}
+			credential := &OciCredential{
+				Username:      auth.Username,
+				Password:      auth.Password,
+				IdentityToken: auth.IdentityToken,
+			}
+			if auth.Auth != "" {
+				data, err := base64.StdEncoding.DecodeString(auth.Auth)
+				if err != nil {
+					return nil, ErrW(err, "get docker credential error",
+						Reason("decode auth error"),
+						KV("file", configFile),
+						KV("registry", registry),
+					)
+				}
+				credential.Username, credential.Password, _ = strings.Cut(string(data), ":")
+			}
+			return credential, nil
+		}
+		return nil, nil
+	}
+}
+
+// GetDockerConfigFile gets the docker config file in the dir of the `DOCKER_CONFIG` environment variable or `~/.docker`.
+func GetDockerConfigFile() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+	homeDir, err := GetSystemHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".docker", "config.json")
+}
+
+func normalizeDockerConfigRegistry(key string) string {
+	registry := strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	registry, _, _ = strings.Cut(registry, "/")
+	switch registry {
+	case "index.docker.io", "registry-1.docker.io":
+		return ociRegistryDockerHub
+	}
+	return registry
+}
+
+func getDockerHelperCredential(helper, serverAddress string) (*OciCredential, error) {
+	command := exec.Command("docker-credential-"+helper, "get")
+	command.Stdin = strings.NewReader(serverAddress)
+	output, err := command.Output()
+	if err != nil {
+		if strings.Contains(string(output), "credentials not found") {
+			return nil, nil
+		}
+		return nil, ErrW(err, "get docker credential error",
+			Reason("execute credential helper error"),
+			KV("helper", helper),
+			KV("serverAddress", serverAddress),
+		)
+	}
+	result := struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}{}
+	if err = json.Unmarshal(output, &result); err != nil {
+		return nil, ErrW(err, "get docker credential error",
+			Reason("unmarshal output error"),
+			KV("helper", helper),
+			KV("serverAddress", serverAddress),
+		)
+	}
+	if result.Username == "<token>" {
+		return &OciCredential{IdentityToken: result.Secret}, nil
+	}
+	return &OciCredential{Username: result.Username, Password: result.Secret}, nil
+}
+
+// endregion
@@ -0,0 +1,208 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseOciReference(t *testing.T) {
+	digest := GetOciDigest([]byte("test"))
+	cases := map[string]string{
+		"ghcr.io/org/repo:1.0.0":               "ghcr.io/org/repo:1.0.0",
+		"localhost:5000/repo":                  "localhost:5000/repo:latest",
+		"repo:tag":                             "docker.io/library/repo:tag",
+		"org/repo@" + digest:                   "docker.io/org/repo@" + digest,
+		"127.0.0.1:5000/org/repo:v1@" + digest: "127.0.0.1:5000/org/repo:v1@" + digest,
+	}
+	for rawRef, expected := range cases {
+		ref, err := ParseOciReference(rawRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ref.String() != expected {
+			t.Fatal(rawRef, ref.String())
+		}
+	}
+	for _, rawRef := range []string{"ghcr.io/Org/repo", "ghcr.io/org/repo@sha256:abc", "ghcr.io/org/repo:-tag"} {
+		if _, err := ParseOciReference(rawRef); err != nil {
+			t.Log(err)
+		} else {
+			Impossible()
+		}
+	}
+}
+
+func TestOciClient(t *testing.T) {
+	registry := newTestOciRegistry("user", "secret")
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	config := `{"auths": {"http://` + host + `/v2/": {"auth": "` + base64.StdEncoding.EncodeToString([]byte("user:secret")) + `"}}}`
+	if err := os.WriteFile(configFile, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	client := NewOciClient(nil, NewDockerCredentialProvider(configFile))
+	ref, err := ParseOciReference(host + "/org/project:1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layerData := []byte("project content")
+	layer := NewOciDescriptor("application/octet-stream", layerData)
+	emptyConfig := NewOciDescriptor(OciMediaTypeEmpty, OciEmptyData)
+	if err = client.PushBlob(ref, emptyConfig, OciEmptyData); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.PushBlob(ref, layer, layerData); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.PushBlob(ref, layer, layerData); err != nil {
+		t.Fatal(err)
+	}
+	digest, err := client.PushManifest(ref, NewOciManifest("application/vnd.test", emptyConfig, []*OciDescriptor{layer}, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if registry.uploads != 2 || registry.tokens != 1 {
+		t.Fatal(registry.uploads, registry.tokens)
+	}
+
+	resolved, err := client.ResolveManifest(ref)
+	if err != nil || resolved != digest {
+		t.Fatal(resolved, err)
+	}
+	manifest, pulled, err := client.PullManifest(ref.WithDigest(digest))
+	if err != nil || pulled != digest || manifest.ArtifactType != "application/vnd.test" || len(manifest.Layers) != 1 {
+		t.Fatal(manifest, pulled, err)
+	}
+	buffer := &bytes.Buffer{}
+	if err = client.PullBlob(ref, manifest.Layers[0], buffer); err != nil || buffer.String() != "project content" {
+		t.Fatal(buffer.String(), err)
+	}
+
+	registry.blobs[layer.Digest] = []byte("tampered content")
+	if err = client.PullBlob(ref, manifest.Layers[0], io.Discard); err != nil {
+		t.Log(err)
+	} else {
+		Impossible()
+	}
+	if _, _, err = client.PullManifest(ref.WithDigest(GetOciDigest([]byte("missing")))); err != nil {
+		t.Log(err)
+	} else {
+		Impossible()
+	}
+	if _, err = NewOciClient(nil, nil).ResolveManifest(ref); err != nil {
+		t.Log(err)
+	} else {
+		Impossible()
+	}
+	if NewOciClient(nil, nil).httpClient.Timeout <= 0 {
+		t.Fatal("default http client should have a timeout")
+	}
+}
+
+func TestParseOciChallenge(t *testing.T) {
+	scheme, params := parseOciChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:org/repo:pull,push"`)
+	if scheme != "Bearer" || params["realm"] != "https://auth.example.com/token" || params["service"] != "registry.example.com" || params["scope"] != "repository:org/repo:pull,push" {
+		t.Fatal(scheme, params)
+	}
+	scheme, params = parseOciChallenge(`Basic realm=registry`)
+	if scheme != "Basic" || params["realm"] != "registry" {
+		t.Fatal(scheme, params)
+	}
+}
+
+// testOciRegistry is an in-process registry of the OCI distribution api, the requests are authorized by the bearer token,
+// and the token is issued by the basic authorization.
+type testOciRegistry struct {
+	username  string
+	password  string
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	uploads   int
+	tokens    int
+	mutex     sync.Mutex
+}
+
+func newTestOciRegistry(username, password string) *testOciRegistry {
+	return &testOciRegistry{
+		username:  username,
+		password:  password,
+		blobs:     map[string][]byte{},
+		manifests: map[string][]byte{},
+	}
+}
+
+func (r *testOciRegistry) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if request.URL.Path == "/token" {
+		if username, password, ok := request.BasicAuth(); !ok || username != r.username || password != r.password {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		r.tokens++
+		_ = json.NewEncoder(writer).Encode(map[string]string{"token": "test-token"})
+		return
+	}
+	if request.Header.Get("Authorization") != "Bearer test-token" {
+		writer.Header().Set("WWW-Authenticate", `Bearer realm="http://`+request.Host+`/token",service="test"`)
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(request.URL.Path, "/v2/")
+	if repository, found := strings.CutSuffix(path, "/blobs/uploads/"); found && request.Method == http.MethodPost {
+		writer.Header().Set("Location", "/v2/"+repository+"/blobs/uploads/1?state=test")
+		writer.WriteHeader(http.StatusAccepted)
+	} else if strings.Contains(path, "/blobs/uploads/") && request.Method == http.MethodPut {
+		data, _ := io.ReadAll(request.Body)
+		digest := request.URL.Query().Get("digest")
+		if request.URL.Query().Get("state") != "test" || GetOciDigest(data) != digest {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.blobs[digest] = data
+		r.uploads++
+		writer.WriteHeader(http.StatusCreated)
+	} else if index := strings.LastIndex(path, "/blobs/"); index >= 0 {
+		data, exist := r.blobs[path[index+len("/blobs/"):]]
+		if !exist {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = writer.Write(data)
+	} else if index = strings.LastIndex(path, "/manifests/"); index >= 0 {
+		repository, reference := path[:index], path[index+len("/manifests/"):]
+		if request.Method == http.MethodPut {
+			data, _ := io.ReadAll(request.Body)
+			digest := GetOciDigest(data)
+			r.manifests[repository+"@"+reference] = data
+			r.manifests[repository+"@"+digest] = data
+			writer.Header().Set("Docker-Content-Digest", digest)
+			writer.WriteHeader(http.StatusCreated)
+			return
+		}
+		data, exist := r.manifests[repository+"@"+reference]
+		if !exist {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writer.Header().Set("Content-Type", OciMediaTypeImageManifest)
+		writer.Header().Set("Docker-Content-Digest", GetOciDigest(data))
+		_, _ = writer.Write(data)
+	} else {
+		writer.WriteHeader(http.StatusNotFound)
+	}
+}
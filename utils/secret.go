@@ -0,0 +1,307 @@
+package utils
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// region base
+
+const SecretReferencePrefix = "secret:"
+
+const SecretMask = "******"
+
+var secretProviders = map[string]SecretProvider{
+	"env":  SecretProviderFunc(resolveEnvSecret),
+	"file": SecretProviderFunc(resolveFileSecret),
+}
+
+var secretProvidersLock sync.RWMutex
+
+// RegisterSecretProvider registers the provider of the secret references like `secret:<name>:<key>`,
+// the builtin providers `env` and `file` can be replaced.
+func RegisterSecretProvider(name string, provider SecretProvider) {
+	secretProvidersLock.Lock()
+	defer secretProvidersLock.Unlock()
+	secretProviders[name] = provider
+}
+
+// secretReferenceKeys records the keys of the parsed secret references by provider, so the values can be masked
+// where they are exposed out of the secrets, such as the system variables named by the env secrets in the inspection.
+var secretReferenceKeys = map[string]map[string]bool{}
+
+var secretReferenceKeysLock sync.RWMutex
+
+func addSecretReferenceKey(provider, key string) {
+	secretReferenceKeysLock.Lock()
+	defer secretReferenceKeysLock.Unlock()
+	if secretReferenceKeys[provider] == nil {
+		secretReferenceKeys[provider] = map[string]bool{}
+	}
+	secretReferenceKeys[provider][key] = true
+}
+
+// IsSecretReferenceKey checks whether the key of the provider is referenced by any parsed secret, such as `DB_PASS` of `secret:env:DB_PASS`.
+func IsSecretReferenceKey(provider, key string) bool {
+	secretReferenceKeysLock.RLock()
+	defer secretReferenceKeysLock.RUnlock()
+	return secretReferenceKeys[provider][key]
+}
+
+func getSecretProvider(name string) SecretProvider {
+	secretProvidersLock.RLock()
+	defer secretProvidersLock.RUnlock()
+	return secretProviders[name]
+}
+
+func resolveEnvSecret(key string) (string, error) {
+	value, exist := os.LookupEnv(key)
+	if !exist {
+		return "", ErrN("resolve env secret error",
+			Reason("env not found"),
+			KV("key", key),
+		)
+	}
+	return value, nil
+}
+
+func resolveFileSecret(key string) (string, error) {
+	path := key
+	if strings.HasPrefix(path, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", ErrW(err, "resolve file secret error",
+				Reason("get home dir error"),
+				KV("key", key),
+			)
+		}
+		path = filepath.Join(homeDir, path[2:])
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", ErrW(err, "resolve file secret error",
+			Reason("read file error"),
+			KV("key", key),
+		)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// endregion
+
+// region SecretProvider
+
+type SecretProvider interface {
+	Resolve(key string) (string, error)
+}
+
+type SecretProviderFunc func(key string) (string, error)
+
+func (f SecretProviderFunc) Resolve(key string) (string, error) {
+	return f(key)
+}
+
+// endregion
+
+// region Secret
+
+// Secret is a reference to an external secret, the value is resolved on the first use.
+// The secret is masked when it is formatted, serialized, described or logged,
+// the value is only available by Resolve or in the data of templates.
+type Secret struct {
+	Provider string
+	Key      string
+	once     sync.Once
+	value    string
+	err      error
+}
+
+func IsSecretReference(str string) bool {
+	return strings.HasPrefix(str, SecretReferencePrefix)
+}
+
+// ParseSecret parses the secret reference like `secret:env:DB_PASS` or `secret:file:~/.secrets/db`.
+func ParseSecret(str string) (*Secret, error) {
+	provider, key, found := strings.Cut(strings.TrimPrefix(str, SecretReferencePrefix), ":")
+	if !IsSecretReference(str) || !found || provider == "" || key == "" {
+		return nil, ErrN("parse secret error",
+			Reason("reference invalid"),
+			KV("reference", str),
+		)
+	}
+	addSecretReferenceKey(provider, key)
+	return &Secret{
+		Provider: provider,
+		Key:      key,
+	}, nil
+}
+
+func (s *Secret) Resolve() (string, error) {
+	s.once.Do(func() {
+		provider := getSecretProvider(s.Provider)
+		if provider == nil {
+			s.err = ErrN("resolve secret error",
+				Reason("provider not found"),
+				KV("provider", s.Provider),
+				KV("key", s.Key),
+			)
+			return
+		}
+		if s.value, s.err = provider.Resolve(s.Key); s.err != nil {
+			s.err = ErrW(s.err, "resolve secret error",
+				KV("provider", s.Provider),
+				KV("key", s.Key),
+			)
+		}
+	})
+	return s.value, s.err
+}
+
+func (s *Secret) GetReference() string {
+	return SecretReferencePrefix + s.Provider + ":" + s.Key
+}
+
+func (s *Secret) String() string {
+	return SecretMask
+}
+
+func (s *Secret) MarshalText() ([]byte, error) {
+	return []byte(SecretMask), nil
+}
+
+// ParseSecretValues returns a copy of the value, the strings of secret references in the maps and lists are replaced by secrets.
+func ParseSecretValues(value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if IsSecretReference(v) {
+			return ParseSecret(v)
+		}
+		return v, nil
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			parsed, err := ParseSecretValues(item)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = parsed
+		}
+		return result, nil
+	case []any:
+		result := make([]any, len(v))
+		for i := 0; i < len(v); i++ {
+			parsed, err := ParseSecretValues(v[i])
+			if err != nil {
+				return nil, err
+			}
+			result[i] = parsed
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// ResolveSecretValues returns a copy of the value, the secrets in the maps and lists are replaced by the resolved values.
+// The maps and lists without secret are not copied.
+func ResolveSecretValues(value any) (any, error) {
+	resolver := &secretResolver{}
+	result, _ := resolver.resolve(value)
+	if len(resolver.failures) > 0 {
+		return nil, resolver.failures[0]
+	}
+	return result, nil
+}
+
+// endregion
+
+// region secretResolver
+
+// secretResolver resolves the secrets in the data of templates, a secret failing to resolve is replaced by a
+// secretResolveFailure, and the error is only reported if the secret is used by the template.
+type secretResolver struct {
+	failures []error
+	used     []error
+}
+
+func (r *secretResolver) resolve(value any) (_ any, changed bool) {
+	switch v := value.(type) {
+	case *Secret:
+		resolved, err := v.Resolve()
+		if err != nil {
+			r.failures = append(r.failures, err)
+			return secretResolveFailure{resolver: r, err: err}, true
+		}
+		return resolved, true
+	case map[string]any:
+		return r.resolveMap(v)
+	case EvalData:
+		result, changed := r.resolveMap(v)
+		if !changed {
+			return v, false
+		}
+		return EvalData(result), true
+	case []any:
+		var result []any
+		for i := 0; i < len(v); i++ {
+			resolved, itemChanged := r.resolve(v[i])
+			if itemChanged && result == nil {
+				result = slices.Clone(v)
+			}
+			if result != nil {
+				result[i] = resolved
+			}
+		}
+		if result == nil {
+			return v, false
+		}
+		return result, true
+	default:
+		return value, false
+	}
+}
+
+func (r *secretResolver) resolveMap(m map[string]any) (map[string]any, bool) {
+	var result map[string]any
+	for key, item := range m {
+		resolved, itemChanged := r.resolve(item)
+		if itemChanged && result == nil {
+			result = maps.Clone(m)
+		}
+		if result != nil {
+			result[key] = resolved
+		}
+	}
+	if result == nil {
+		return m, false
+	}
+	return result, true
+}
+
+// getUsedError returns the error of the first failed secret used by the template.
+func (r *secretResolver) getUsedError() error {
+	if len(r.used) > 0 {
+		return r.used[0]
+	}
+	return nil
+}
+
+// endregion
+
+// region secretResolveFailure
+
+type secretResolveFailure struct {
+	resolver *secretResolver
+	err      error
+}
+
+func (f secretResolveFailure) String() string {
+	f.resolver.used = append(f.resolver.used, f.err)
+	return SecretMask
+}
+
+// endregion
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"strings"
+	"testing"
+)
+
+func TestSecret(t *testing.T) {
+	t.Setenv("DSH_TEST_SECRET", "p@ss")
+	RegisterSecretProvider("test", SecretProviderFunc(func(key string) (string, error) {
+		return "test-" + key, nil
+	}))
+
+	if _, err := ParseSecret("secret:env"); err == nil {
+		t.Fatal("expected reference invalid error")
+	}
+	value, err := ParseSecretValues(map[string]any{
+		"db": map[string]any{
+			"user": "admin",
+			"pass": "secret:env:DSH_TEST_SECRET",
+		},
+		"tokens": []any{"secret:test:a", "plain"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := value.(map[string]any)
+	secret := data["db"].(map[string]any)["pass"].(*Secret)
+	if secret.GetReference() != "secret:env:DSH_TEST_SECRET" {
+		t.Fatal(secret.GetReference())
+	}
+
+	jsonData, _ := json.Marshal(data)
+	yamlData, _ := yaml.Marshal(data)
+	desc := DescN("test", KV("data", data))
+	for _, str := range []string{fmt.Sprint(data), string(jsonData), string(yamlData), desc} {
+		if strings.Contains(str, "p@ss") || strings.Contains(str, "test-a") || !strings.Contains(str, SecretMask) {
+			t.Fatalf("secret not masked: %s", str)
+		}
+	}
+
+	result, err := EvalStringTemplate("{{ .db.user }}:{{ .db.pass }} {{ index .tokens 0 }}", data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != "admin:p@ss test-a" {
+		t.Fatal(result)
+	}
+	if _, ok := data["db"].(map[string]any)["pass"].(*Secret); !ok {
+		t.Fatal("original data changed")
+	}
+
+	missing, _ := ParseSecret("secret:env:DSH_TEST_SECRET_MISSING")
+	if _, err = EvalStringTemplate("{{ .pass }}", map[string]any{"pass": missing}, nil); err == nil {
+		t.Fatal("expected resolve secret error")
+	} else if strings.Contains(err.Error(), "p@ss") {
+		t.Fatal(err)
+	}
+	if result, err = EvalStringTemplate("{{ .user }}", map[string]any{"user": "admin", "pass": missing}, nil); err != nil || result != "admin" {
+		t.Fatal("unused secret should not fail the template", result, err)
+	}
+	if _, err = ResolveSecretValues(map[string]any{"pass": missing}); err == nil {
+		t.Fatal("expected resolve secret error")
+	}
+}
@@ -1,15 +1,17 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/pelletier/go-toml/v2"
 	"gopkg.in/yaml.v3"
-	"os"
+	"strings"
 )
 
 type SerializationMetadata struct {
-	File   string
-	Format SerializationFormat
+	File      string
+	Format    SerializationFormat
+	Positions *SerializationPositions
 }
 
 type SerializationFormat string
@@ -40,9 +42,35 @@ func GetSerializationFormat(fileType FileType) SerializationFormat {
 	return ""
 }
 
+func GetSerializationFormatByFile(file string) SerializationFormat {
+	fileType := GetFileType(file, serializationSupportedFileTypes)
+	if fileType == "" {
+		return ""
+	}
+	return GetSerializationFormat(fileType)
+}
+
+func GetSerializer(format SerializationFormat) Serializer {
+	switch format {
+	case SerializationFormatYaml:
+		return YamlSerializerDefault
+	case SerializationFormatToml:
+		return TomlSerializerDefault
+	case SerializationFormatJson:
+		return JsonSerializerDefault
+	default:
+		Impossible()
+	}
+	return nil
+}
+
 func DeserializeDir(dir string, globs []string, model any, required bool) (metadata *SerializationMetadata, err error) {
+	return DeserializeDirFS(OSFileSystem, dir, globs, model, required)
+}
+
+func DeserializeDirFS(fsys FileSystem, dir string, globs []string, model any, required bool) (metadata *SerializationMetadata, err error) {
 	names := GetFileNames(globs, serializationSupportedFileTypes)
-	file := FindFile(dir, names, serializationSupportedFileTypes)
+	file := FindFileFS(fsys, dir, names, serializationSupportedFileTypes)
 	if file == nil {
 		if required {
 			return nil, ErrN("deserialize error",
@@ -55,12 +83,16 @@ func DeserializeDir(dir string, globs []string, model any, required bool) (metad
 		}
 	}
 
-	return DeserializeFile(file.Path, GetSerializationFormat(file.Type), model)
+	return DeserializeFileFS(fsys, file.Path, GetSerializationFormat(file.Type), model)
 }
 
 func DeserializeFile(file string, format SerializationFormat, model any) (metadata *SerializationMetadata, err error) {
+	return DeserializeFileFS(OSFileSystem, file, format, model)
+}
+
+func DeserializeFileFS(fsys FileSystem, file string, format SerializationFormat, model any) (metadata *SerializationMetadata, err error) {
 	if format == "" {
-		if !IsFileExists(file) {
+		if !IsFileExistsFS(fsys, file) {
 			return nil, ErrN("deserialize error",
 				Reason("file not found"),
 				KV("file", file),
@@ -76,28 +108,87 @@ func DeserializeFile(file string, format SerializationFormat, model any) (metada
 		format = GetSerializationFormat(fileType)
 	}
 
+	data, err := fsys.ReadFile(file)
+	if err != nil {
+		return nil, ErrW(err, "deserialize error",
+			Reason("read file error"),
+			KV("file", file),
+			KV("format", format),
+		)
+	}
+	return deserializeData(file, data, format, model)
+}
+
+// DeserializeEncryptedFile decrypts the file encrypted by EncryptFile with one of the keys, and deserializes the plain data,
+// the format is detected by the file name without `.enc` ext if it is empty.
+func DeserializeEncryptedFile(file string, format SerializationFormat, keys []*EncryptionKey, model any) (metadata *SerializationMetadata, err error) {
+	return DeserializeEncryptedFileFS(OSFileSystem, file, format, keys, model)
+}
+
+func DeserializeEncryptedFileFS(fsys FileSystem, file string, format SerializationFormat, keys []*EncryptionKey, model any) (metadata *SerializationMetadata, err error) {
+	if format == "" {
+		format = GetSerializationFormatByFile(strings.TrimSuffix(file, EncryptedFileExt))
+		if format == "" {
+			return nil, ErrN("deserialize error",
+				Reason("file type not supported"),
+				KV("file", file),
+			)
+		}
+	}
+
+	encrypted, err := fsys.ReadFile(file)
+	if err != nil {
+		return nil, ErrW(err, "deserialize error",
+			Reason("read file error"),
+			KV("file", file),
+			KV("format", format),
+		)
+	}
+	data, _, err := DecryptData(keys, encrypted)
+	if err != nil {
+		return nil, ErrW(err, "deserialize error",
+			Reason("decrypt file error"),
+			KV("file", file),
+			KV("format", format),
+		)
+	}
+	metadata, err = deserializeData(file, data, format, model)
+	if err != nil {
+		// the unmarshal errors may quote the decrypted values, so they are not wrapped
+		return nil, ErrN("deserialize error",
+			Reason("unmarshal decrypted data error"),
+			KV("file", file),
+			KV("format", format),
+		)
+	}
+	// the positions of the encrypted file are kept without the lines, so the decrypted values are not shown in the snippets
+	metadata.Positions.redacted = true
+	return metadata, nil
+}
+
+func deserializeData(file string, data []byte, format SerializationFormat, model any) (metadata *SerializationMetadata, err error) {
 	switch format {
 	case SerializationFormatYaml:
-		err = ReadYamlFile(file, model)
+		err = yaml.Unmarshal(data, model)
 	case SerializationFormatToml:
-		err = ReadTomlFile(file, model)
+		err = toml.Unmarshal(data, model)
 	case SerializationFormatJson:
-		err = ReadJsonFile(file, model)
+		err = json.Unmarshal(data, model)
 	default:
 		Impossible()
 	}
-
 	if err != nil {
 		return nil, ErrW(err, "deserialize error",
-			Reason("read file error"),
+			Reason("unmarshal error"),
 			KV("file", file),
 			KV("format", format),
 		)
 	}
 
 	metadata = &SerializationMetadata{
-		File:   file,
-		Format: format,
+		File:      file,
+		Format:    format,
+		Positions: NewSerializationPositions(file, data, format),
 	}
 	return metadata, nil
 }
@@ -109,9 +200,29 @@ type Serializer interface {
 
 	GetFileExt() string
 
+	Serialize(model any) ([]byte, error)
+
 	SerializeFile(file string, model any) error
 }
 
+// SerializeFileFS serializes the model and writes the data to the file in the file system.
+func SerializeFileFS(fsys FileSystem, serializer Serializer, file string, model any) error {
+	data, err := serializer.Serialize(model)
+	if err != nil {
+		return ErrW(err, "serialize error",
+			Reason("serialize model error"),
+			KV("file", file),
+		)
+	}
+	if err = fsys.WriteFile(file, data, 0666); err != nil {
+		return ErrW(err, "serialize error",
+			Reason("write file error"),
+			KV("file", file),
+		)
+	}
+	return nil
+}
+
 // endregion
 
 // region YamlSerializer
@@ -140,27 +251,25 @@ func (s *YamlSerializer) GetFileExt() string {
 	return ".yml"
 }
 
-func (s *YamlSerializer) SerializeFile(file string, model any) error {
-	writer, err := os.Create(file)
-	if err != nil {
-		return ErrW(err, "serialize error",
-			Reason("create writer error"),
-			KV("file", file),
-		)
-	}
-	defer writer.Close()
-
-	encoder := yaml.NewEncoder(writer)
+func (s *YamlSerializer) Serialize(model any) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buffer)
 	encoder.SetIndent(s.Indent)
-	defer encoder.Close()
-
-	if err = encoder.Encode(model); err != nil {
-		return ErrW(err, "serialize error",
+	if err := encoder.Encode(model); err != nil {
+		return nil, ErrW(err, "serialize error",
 			Reason("encode yaml error"),
-			KV("file", file),
 		)
 	}
-	return nil
+	if err := encoder.Close(); err != nil {
+		return nil, ErrW(err, "serialize error",
+			Reason("close yaml encoder error"),
+		)
+	}
+	return buffer.Bytes(), nil
+}
+
+func (s *YamlSerializer) SerializeFile(file string, model any) error {
+	return SerializeFileFS(OSFileSystem, s, file, model)
 }
 
 // endregion
@@ -209,28 +318,23 @@ func (s *TomlSerializer) GetFileExt() string {
 	return ".toml"
 }
 
-func (s *TomlSerializer) SerializeFile(file string, model any) error {
-	writer, err := os.Create(file)
-	if err != nil {
-		return ErrW(err, "serialize error",
-			Reason("create writer error"),
-			KV("file", file),
-		)
-	}
-	defer writer.Close()
-
-	encoder := toml.NewEncoder(writer)
+func (s *TomlSerializer) Serialize(model any) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	encoder := toml.NewEncoder(buffer)
 	encoder.SetTablesInline(s.TablesInline)
 	encoder.SetIndentTables(s.IndentTables)
 	encoder.SetArraysMultiline(s.ArraysMultiline)
 	encoder.SetIndentSymbol(s.IndentSymbol)
-	if err = encoder.Encode(model); err != nil {
-		return ErrW(err, "serialize error",
+	if err := encoder.Encode(model); err != nil {
+		return nil, ErrW(err, "serialize error",
 			Reason("encode toml error"),
-			KV("file", file),
 		)
 	}
-	return nil
+	return buffer.Bytes(), nil
+}
+
+func (s *TomlSerializer) SerializeFile(file string, model any) error {
+	return SerializeFileFS(OSFileSystem, s, file, model)
 }
 
 // endregion
@@ -267,7 +371,7 @@ func (s *JsonSerializer) GetFileExt() string {
 	return ".json"
 }
 
-func (s *JsonSerializer) SerializeFile(file string, model any) error {
+func (s *JsonSerializer) Serialize(model any) ([]byte, error) {
 	var data []byte
 	var err error
 	if s.PrefixSymbol != "" || s.IndentSymbol != "" {
@@ -276,19 +380,15 @@ func (s *JsonSerializer) SerializeFile(file string, model any) error {
 		data, err = json.Marshal(model)
 	}
 	if err != nil {
-		return ErrW(err, "serialize error",
+		return nil, ErrW(err, "serialize error",
 			Reason("marshal json error"),
-			KV("file", file),
 		)
 	}
+	return data, nil
+}
 
-	if err = os.WriteFile(file, data, os.ModePerm); err != nil {
-		return ErrW(err, "serialize error",
-			Reason("write file error"),
-			KV("file", file),
-		)
-	}
-	return nil
+func (s *JsonSerializer) SerializeFile(file string, model any) error {
+	return SerializeFileFS(OSFileSystem, s, file, model)
 }
 
 // endregion
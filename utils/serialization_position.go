@@ -0,0 +1,350 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pelletier/go-toml/v2/unstable"
+	"gopkg.in/yaml.v3"
+	"strings"
+	"sync"
+)
+
+// region base
+
+var serializationSnippetAroundLines = 1
+
+// endregion
+
+// region SerializationPosition
+
+type SerializationPosition struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func NewSerializationPosition(file string, line, column int) *SerializationPosition {
+	return &SerializationPosition{
+		File:   file,
+		Line:   line,
+		Column: column,
+	}
+}
+
+func (p *SerializationPosition) String() string {
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// endregion
+
+// region SerializationPositions
+
+// SerializationPositions records the position of each field in a deserialized file,
+// the field uses the same path form as ModelHelper, such as `option.items[3].name`.
+// The file is parsed on the first access, since the positions are only used to report errors and warnings.
+type SerializationPositions struct {
+	File     string
+	data     []byte
+	format   SerializationFormat
+	redacted bool
+	once     sync.Once
+	err      error
+	lines    []string
+	dict     map[string]*SerializationPosition
+}
+
+func NewSerializationPositions(file string, data []byte, format SerializationFormat) *SerializationPositions {
+	return &SerializationPositions{
+		File:   file,
+		data:   data,
+		format: format,
+	}
+}
+
+// Parse parses the positions if not parsed yet, if the parsing fails, the error is returned and no position is found.
+func (p *SerializationPositions) Parse() error {
+	p.once.Do(func() {
+		p.lines = strings.Split(strings.TrimSuffix(strings.ReplaceAll(string(p.data), "\r\n", "\n"), "\n"), "\n")
+		p.dict = map[string]*SerializationPosition{}
+		var err error
+		switch p.format {
+		case SerializationFormatYaml:
+			err = p.parseYaml(p.data)
+		case SerializationFormatToml:
+			err = p.parseToml(p.data)
+		case SerializationFormatJson:
+			err = p.parseJson(p.data)
+		default:
+			Impossible()
+		}
+		if err != nil {
+			p.dict = map[string]*SerializationPosition{}
+			p.err = ErrW(err, "parse positions error",
+				Reason("parse file error"),
+				KV("file", p.File),
+				KV("format", p.format),
+			)
+		}
+		p.data = nil
+		if p.redacted {
+			p.lines = nil
+		}
+	})
+	return p.err
+}
+
+// Get returns the position of the field, if the field is not found, the position of the nearest parent field is returned.
+func (p *SerializationPositions) Get(field string) *SerializationPosition {
+	if p.Parse() != nil {
+		return nil
+	}
+	for {
+		if position, exist := p.dict[field]; exist {
+			return position
+		}
+		if field == "" {
+			return nil
+		}
+		index := strings.LastIndexAny(field, ".[")
+		if index < 0 {
+			field = ""
+		} else {
+			field = field[:index]
+		}
+	}
+}
+
+// GetSnippet returns the lines around the position, the line of the position is marked by `>` and followed by a `^` pointer line.
+func (p *SerializationPositions) GetSnippet(position *SerializationPosition, around int) []string {
+	if p.redacted {
+		return nil
+	}
+	_ = p.Parse()
+	if position == nil || position.Line < 1 || position.Line > len(p.lines) {
+		return nil
+	}
+	start := max(position.Line-around, 1)
+	end := min(position.Line+around, len(p.lines))
+	width := len(fmt.Sprint(end))
+	var snippet []string
+	for i := start; i <= end; i++ {
+		line := strings.ReplaceAll(p.lines[i-1], "\t", " ")
+		if i == position.Line {
+			snippet = append(snippet, fmt.Sprintf("> %*d | %s", width, i, line))
+			snippet = append(snippet, fmt.Sprintf("  %*s | %s^", width, "", strings.Repeat(" ", max(position.Column-1, 0))))
+		} else {
+			snippet = append(snippet, fmt.Sprintf("  %*d | %s", width, i, line))
+		}
+	}
+	return snippet
+}
+
+func (p *SerializationPositions) add(field string, line, column int) {
+	if _, exist := p.dict[field]; !exist {
+		p.dict[field] = NewSerializationPosition(p.File, line, column)
+	}
+}
+
+func joinSerializationField(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func (p *SerializationPositions) parseYaml(data []byte) error {
+	node := &yaml.Node{}
+	if err := yaml.Unmarshal(data, node); err != nil {
+		return err
+	}
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		p.walkYaml("", node.Content[0])
+	}
+	return nil
+}
+
+func (p *SerializationPositions) walkYaml(field string, node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			child := joinSerializationField(field, key.Value)
+			p.add(child, key.Line, key.Column)
+			p.walkYaml(child, node.Content[i+1])
+		}
+	case yaml.SequenceNode:
+		for i := 0; i < len(node.Content); i++ {
+			item := node.Content[i]
+			child := fmt.Sprintf("%s[%d]", field, i)
+			p.add(child, item.Line, item.Column)
+			p.walkYaml(child, item)
+		}
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			p.walkYaml(field, node.Alias)
+		}
+	default:
+	}
+}
+
+func (p *SerializationPositions) parseToml(data []byte) error {
+	parser := &unstable.Parser{}
+	parser.Reset(data)
+	arrayIndexes := map[string]int{}
+	table := ""
+	for parser.NextExpression() {
+		expr := parser.Expression()
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			it := expr.Key()
+			field := ""
+			for it.Next() {
+				key := it.Node()
+				field = joinSerializationField(field, string(key.Data))
+				if expr.Kind == unstable.ArrayTable && it.IsLast() {
+					index := 0
+					if last, exist := arrayIndexes[field]; exist {
+						index = last + 1
+					}
+					arrayIndexes[field] = index
+					p.addToml(parser, field, key)
+					field = fmt.Sprintf("%s[%d]", field, index)
+				} else if index, exist := arrayIndexes[field]; exist {
+					field = fmt.Sprintf("%s[%d]", field, index)
+				}
+				p.addToml(parser, field, key)
+			}
+			table = field
+		case unstable.KeyValue:
+			p.walkTomlKeyValue(parser, table, expr)
+		default:
+		}
+	}
+	return parser.Error()
+}
+
+func (p *SerializationPositions) walkTomlKeyValue(parser *unstable.Parser, parent string, node *unstable.Node) {
+	field := parent
+	var last *unstable.Node
+	it := node.Key()
+	for it.Next() {
+		last = it.Node()
+		field = joinSerializationField(field, string(last.Data))
+		p.addToml(parser, field, last)
+	}
+	p.walkTomlValue(parser, field, node.Value())
+}
+
+func (p *SerializationPositions) walkTomlValue(parser *unstable.Parser, field string, node *unstable.Node) {
+	switch node.Kind {
+	case unstable.Array:
+		index := 0
+		it := node.Children()
+		for it.Next() {
+			child := fmt.Sprintf("%s[%d]", field, index)
+			item := it.Node()
+			p.addToml(parser, child, item)
+			p.walkTomlValue(parser, child, item)
+			index++
+		}
+	case unstable.InlineTable:
+		it := node.Children()
+		for it.Next() {
+			if it.Node().Kind == unstable.KeyValue {
+				p.walkTomlKeyValue(parser, field, it.Node())
+			}
+		}
+	default:
+	}
+}
+
+func (p *SerializationPositions) addToml(parser *unstable.Parser, field string, node *unstable.Node) {
+	if node.Raw.Length == 0 {
+		return
+	}
+	shape := parser.Shape(node.Raw)
+	p.add(field, shape.Start.Line, shape.Start.Column)
+}
+
+func (p *SerializationPositions) parseJson(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	offsets := newSerializationLineOffsets(data)
+	return p.walkJson(decoder, data, offsets, "")
+}
+
+func (p *SerializationPositions) walkJson(decoder *json.Decoder, data []byte, offsets []int, field string) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil
+	}
+	switch delim {
+	case '{':
+		for decoder.More() {
+			offset := skipJsonSeparators(data, int(decoder.InputOffset()))
+			token, err = decoder.Token()
+			if err != nil {
+				return err
+			}
+			child := joinSerializationField(field, token.(string))
+			line, column := getSerializationLineColumn(offsets, offset)
+			p.add(child, line, column)
+			if err = p.walkJson(decoder, data, offsets, child); err != nil {
+				return err
+			}
+		}
+	case '[':
+		index := 0
+		for decoder.More() {
+			offset := skipJsonSeparators(data, int(decoder.InputOffset()))
+			child := fmt.Sprintf("%s[%d]", field, index)
+			line, column := getSerializationLineColumn(offsets, offset)
+			p.add(child, line, column)
+			if err = p.walkJson(decoder, data, offsets, child); err != nil {
+				return err
+			}
+			index++
+		}
+	default:
+	}
+	// consume the closing delimiter
+	_, err = decoder.Token()
+	return err
+}
+
+func skipJsonSeparators(data []byte, offset int) int {
+	for offset < len(data) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+func newSerializationLineOffsets(data []byte) []int {
+	offsets := []int{0}
+	for i := 0; i < len(data); i++ {
+		if data[i] == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
+
+func getSerializationLineColumn(offsets []int, offset int) (line, column int) {
+	line = 1
+	for i := 1; i < len(offsets) && offsets[i] <= offset; i++ {
+		line = i + 1
+	}
+	return line, offset - offsets[line-1] + 1
+}
+
+// endregion
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"testing"
+)
+
+func checkSerializationPosition(t *testing.T, positions *SerializationPositions, field string, line, column int) {
+	position := positions.Get(field)
+	if position == nil {
+		t.Fatalf("position not found: %s", field)
+	}
+	if position.Line != line || position.Column != column {
+		t.Fatalf("position of %s: expected %d:%d, actual %d:%d", field, line, column, position.Line, position.Column)
+	}
+}
+
+func TestSerializationPositionsYaml(t *testing.T) {
+	data := "name: test\noption:\n  items:\n    - name: a\n      type: string\n    - name: b\n      choices: [1, 2]\n"
+	positions := NewSerializationPositions("test.yml", []byte(data), SerializationFormatYaml)
+	if err := positions.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	checkSerializationPosition(t, positions, "name", 1, 1)
+	checkSerializationPosition(t, positions, "option.items[1]", 6, 7)
+	checkSerializationPosition(t, positions, "option.items[1].name", 6, 7)
+	checkSerializationPosition(t, positions, "option.items[0].type", 5, 7)
+	checkSerializationPosition(t, positions, "option.items[1].choices[1]", 7, 20)
+	// fallback to the parent field
+	checkSerializationPosition(t, positions, "option.items[0].usage", 4, 7)
+
+	snippet := positions.GetSnippet(positions.Get("option.items[0].type"), 1)
+	if len(snippet) != 4 || snippet[1] != "> 5 |       type: string" || snippet[2] != "    |       ^" {
+		t.Fatalf("unexpected snippet: %q", snippet)
+	}
+}
+
+func TestSerializationPositionsToml(t *testing.T) {
+	data := "name = \"test\"\n\n[[option.items]]\nname = \"a\"\n\n[[option.items]]\nname = \"b\"\nchoices = [1, 2]\n\n[option.items.rule]\nmin = 1\n"
+	positions := NewSerializationPositions("test.toml", []byte(data), SerializationFormatToml)
+	if err := positions.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	checkSerializationPosition(t, positions, "name", 1, 1)
+	checkSerializationPosition(t, positions, "option.items[0].name", 4, 1)
+	checkSerializationPosition(t, positions, "option.items[1].name", 7, 1)
+	checkSerializationPosition(t, positions, "option.items[1].choices[1]", 8, 15)
+	checkSerializationPosition(t, positions, "option.items[1].rule.min", 11, 1)
+}
+
+func TestSerializationPositionsJson(t *testing.T) {
+	data := "{\n  \"name\": \"test\",\n  \"option\": {\n    \"items\": [\n      {\"name\": \"a\"},\n      {\"name\": \"b\", \"choices\": [1, 2]}\n    ]\n  }\n}\n"
+	positions := NewSerializationPositions("test.json", []byte(data), SerializationFormatJson)
+	if err := positions.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	checkSerializationPosition(t, positions, "name", 2, 3)
+	checkSerializationPosition(t, positions, "option.items[0]", 5, 7)
+	checkSerializationPosition(t, positions, "option.items[1].name", 6, 8)
+	checkSerializationPosition(t, positions, "option.items[1].choices[1]", 6, 36)
+}
+
+func TestSerializationPositionsInvalid(t *testing.T) {
+	positions := NewSerializationPositions("test.toml", []byte("name = \"test\"\nname = = 1\n"), SerializationFormatToml)
+	if positions.Get("name") != nil {
+		t.Fatal("position should not be found when the parsing fails")
+	}
+	if err := positions.Parse(); err == nil {
+		t.Fatal("parse error expected")
+	}
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"golang.org/x/term"
+	"io"
+	"os"
+	"strings"
+)
+
+// region Terminal
+
+type Terminal struct {
+	input  *os.File
+	output io.Writer
+	reader *bufio.Reader
+}
+
+func NewTerminal(input *os.File, output io.Writer) *Terminal {
+	if input == nil {
+		input = os.Stdin
+	}
+	if output == nil {
+		output = os.Stderr
+	}
+	return &Terminal{
+		input:  input,
+		output: output,
+		reader: bufio.NewReader(input),
+	}
+}
+
+func (t *Terminal) IsTerminal() bool {
+	return term.IsTerminal(int(t.input.Fd()))
+}
+
+func (t *Terminal) Print(format string, args ...any) {
+	_, _ = fmt.Fprintf(t.output, format, args...)
+}
+
+func (t *Terminal) ReadLine() (string, error) {
+	line, err := t.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", ErrW(err, "read terminal line error")
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (t *Terminal) ReadSecret() (string, error) {
+	if !t.IsTerminal() {
+		return t.ReadLine()
+	}
+	bytes, err := term.ReadPassword(int(t.input.Fd()))
+	t.Print("\n")
+	if err != nil {
+		return "", ErrW(err, "read terminal secret error")
+	}
+	return string(bytes), nil
+}
+
+// endregion
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"reflect"
 	"strconv"
+	"time"
 )
 
 type CastType string
@@ -49,6 +50,8 @@ func CastToString(value any) (*string, error) {
 			stringValue = strconv.FormatFloat(float64(value.(float32)), 'f', -1, 32)
 		case float64:
 			stringValue = strconv.FormatFloat(value.(float64), 'f', -1, 64)
+		case time.Duration:
+			stringValue = value.(time.Duration).String()
 		case []any:
 			if bytes, err := json.Marshal(value.([]any)); err != nil {
 				return nil, ErrW(err, "cast to string error",